@@ -1,15 +1,19 @@
 package plugininstaller
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/utils/downloader"
-	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
 )
 
 type PluginDownloader struct {
@@ -18,11 +22,10 @@ type PluginDownloader struct {
 }
 type downloadFromPath func(string, downloader.Downloader) string
 
-func (downloader *PluginDownloader) downloadFromPath(pluginSourceFilepath string) string {
+func (downloader *PluginDownloader) downloadFromPath(pluginSourceFilepath string) (string, error) {
 	size, filename, err := downloader.FileDownloader.DownloadFile(pluginSourceFilepath)
-
 	if err != nil {
-		downloader.UI.Failed(fmt.Sprintf(T("Download attempt failed: {{.Error}}\n\nUnable to install, plugin is not available from the given url.", map[string]interface{}{"Error": err.Error()})))
+		return "", errors.New(T("Download attempt failed: {{.Error}}\n\nUnable to install, plugin is not available from the given url.", map[string]interface{}{"Error": err.Error()}))
 	}
 
 	downloader.UI.Say(fmt.Sprintf("%d "+T("bytes downloaded")+"...", size))
@@ -30,53 +33,69 @@ func (downloader *PluginDownloader) downloadFromPath(pluginSourceFilepath string
 	executablePath := filepath.Join(downloader.FileDownloader.SavePath(), filename)
 	err = os.Chmod(executablePath, 0700)
 	if err != nil {
-		downloader.UI.Failed(fmt.Sprintf(T("Failed to make plugin executable: {{.Error}}", map[string]interface{}{"Error": err.Error()})))
+		return "", errors.New(T("Failed to make plugin executable: {{.Error}}", map[string]interface{}{"Error": err.Error()}))
 	}
 
-	return executablePath
+	return executablePath, nil
 }
 
-func (downloader *PluginDownloader) downloadFromPlugin(plugin clipr.Plugin) (string, string) {
+// downloadFromPlugin downloads the binary of the plugin for the current
+// platform and returns its path along with the binary's repo metadata.
+func (downloader *PluginDownloader) downloadFromPlugin(plugin pluginrepo.Plugin) (string, pluginrepo.Binary, error) {
 	arch := runtime.GOARCH
 
+	var platform string
 	switch runtime.GOOS {
 	case "darwin":
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "osx")), downloader.getBinaryChecksum(plugin, "osx")
+		platform = "osx"
 	case "linux":
+		platform = "linux64"
 		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux32")), downloader.getBinaryChecksum(plugin, "linux32")
+			platform = "linux32"
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux64")), downloader.getBinaryChecksum(plugin, "linux64")
 	case "windows":
+		platform = "win64"
 		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win32")), downloader.getBinaryChecksum(plugin, "win32")
+			platform = "win32"
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win64")), downloader.getBinaryChecksum(plugin, "win64")
 	default:
-		downloader.binaryNotAvailable()
+		return "", pluginrepo.Binary{}, binaryNotAvailable()
 	}
-	return "", ""
-}
 
-func (downloader *PluginDownloader) getBinaryURL(plugin clipr.Plugin, os string) string {
 	for _, binary := range plugin.Binaries {
-		if binary.Platform == os {
-			return binary.Url
+		if binary.Platform == platform {
+			executablePath, err := downloader.downloadFromPath(binary.Url)
+			return executablePath, binary, err
 		}
 	}
-	downloader.binaryNotAvailable()
-	return ""
+	return "", pluginrepo.Binary{}, binaryNotAvailable()
 }
 
-func (downloader *PluginDownloader) getBinaryChecksum(plugin clipr.Plugin, os string) string {
-	for _, binary := range plugin.Binaries {
-		if binary.Platform == os {
-			return binary.Checksum
-		}
+// downloadSignature fetches the detached signature published next to the
+// binary at url, as url with a .sig extension, through the downloader of the
+// binary. It returns an empty string when the server has no signature.
+func (downloader *PluginDownloader) downloadSignature(url string) (string, error) {
+	_, filename, err := downloader.FileDownloader.DownloadFile(url + ".sig")
+	if signatureNotPublished(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
 	}
-	return ""
+	defer downloader.FileDownloader.RemoveFile()
+
+	signature, err := ioutil.ReadFile(filepath.Join(downloader.FileDownloader.SavePath(), filename))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(signature)), nil
+}
+
+func signatureNotPublished(err error) bool {
+	downloadErr, ok := err.(*downloader.DownloadError)
+	return ok && downloadErr.StatusCode == http.StatusNotFound
 }
 
-func (downloader *PluginDownloader) binaryNotAvailable() {
-	downloader.UI.Failed(T("Plugin requested has no binary available for your OS: ") + runtime.GOOS + ", " + runtime.GOARCH)
+func binaryNotAvailable() error {
+	return errors.New(T("Plugin requested has no binary available for your OS: ") + runtime.GOOS + ", " + runtime.GOARCH)
 }
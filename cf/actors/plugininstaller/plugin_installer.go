@@ -11,16 +11,22 @@ import (
 //go:generate counterfeiter . PluginInstaller
 
 type PluginInstaller interface {
-	Install(inputSourceFilepath string) string
+	Install(inputSourceFilepath string) (string, error)
 }
 
 type Context struct {
-	Checksummer    utils.Sha1Checksum
-	FileDownloader downloader.Downloader
-	GetPluginRepos pluginReposFetcher
-	PluginRepo     pluginrepo.PluginRepo
-	RepoName       string
-	UI             terminal.UI
+	AllowUnsigned     bool
+	Checksummer       utils.Sha1Checksum
+	FileDownloader    downloader.Downloader
+	GetPluginRepos    pluginReposFetcher
+	PluginRepo        pluginrepo.PluginRepo
+	RepoName          string
+	SignatureVerifier utils.SignatureVerifier
+	UI                terminal.UI
+	// Version selects the entry of the plugin to install when the repo
+	// carries several versions of it. The first entry is installed when it
+	// is empty.
+	Version string
 }
 
 type pluginReposFetcher func() []models.PluginRepo
@@ -29,11 +35,17 @@ func NewPluginInstaller(context *Context) PluginInstaller {
 	var installer PluginInstaller
 
 	pluginDownloader := &PluginDownloader{UI: context.UI, FileDownloader: context.FileDownloader}
+	signatureChecker := &signatureChecker{
+		UI:            context.UI,
+		Verifier:      context.SignatureVerifier,
+		AllowUnsigned: context.AllowUnsigned,
+	}
 	if context.RepoName == "" {
 		installer = &pluginInstallerWithoutRepo{
 			UI:               context.UI,
 			PluginDownloader: pluginDownloader,
 			RepoName:         context.RepoName,
+			SignatureChecker: signatureChecker,
 		}
 	} else {
 		installer = &pluginInstallerWithRepo{
@@ -43,6 +55,8 @@ func NewPluginInstaller(context *Context) PluginInstaller {
 			Checksummer:      context.Checksummer,
 			PluginRepo:       context.PluginRepo,
 			GetPluginRepos:   context.GetPluginRepos,
+			SignatureChecker: signatureChecker,
+			Version:          context.Version,
 		}
 	}
 	return installer
@@ -0,0 +1,161 @@
+package plugininstaller_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/actors/plugininstaller"
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo/pluginrepofakes"
+	"code.cloudfoundry.org/cli/cf/models"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/downloader"
+	"code.cloudfoundry.org/cli/utils/utilsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PluginInstaller", func() {
+	var (
+		context        *plugininstaller.Context
+		fakeChecksum   *utilsfakes.FakeSha1Checksum
+		fakeVerifier   *utilsfakes.FakeSignatureVerifier
+		fakePluginRepo *pluginrepofakes.FakePluginRepo
+		testServer     *httptest.Server
+		downloadDir    string
+	)
+
+	BeforeEach(func() {
+		testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, "binary")
+		}))
+
+		var err error
+		downloadDir, err = ioutil.TempDir("", "plugin-installer")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeChecksum = new(utilsfakes.FakeSha1Checksum)
+		fakeChecksum.CheckSha1Returns(true)
+		fakeVerifier = new(utilsfakes.FakeSignatureVerifier)
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
+
+		binaries := []pluginrepo.Binary{}
+		for _, platform := range []string{"osx", "win32", "win64", "linux32", "linux64"} {
+			binaries = append(binaries, pluginrepo.Binary{Platform: platform, Url: testServer.URL + "/plugin1.exe", Checksum: "abc"})
+		}
+		fakePluginRepo.GetPluginsReturns(map[string][]pluginrepo.Plugin{
+			"repo1": {{Name: "plugin1", Version: "1.0.0", Binaries: binaries}},
+		}, nil)
+
+		context = &plugininstaller.Context{
+			AllowUnsigned:  true,
+			Checksummer:    fakeChecksum,
+			FileDownloader: downloader.NewDownloader(downloadDir),
+			GetPluginRepos: func() []models.PluginRepo {
+				return []models.PluginRepo{{Name: "repo1", URL: "https://repo1.example.com"}}
+			},
+			PluginRepo:        fakePluginRepo,
+			RepoName:          "repo1",
+			SignatureVerifier: fakeVerifier,
+			UI:                &testterm.FakeUI{},
+		}
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+		os.RemoveAll(downloadDir)
+	})
+
+	Describe("installing from a repository", func() {
+		It("returns the path of the downloaded binary", func() {
+			path, err := plugininstaller.NewPluginInstaller(context).Install("plugin1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(BeAnExistingFile())
+			Expect(fakeChecksum.CheckSha1ArgsForCall(0)).To(Equal("abc"))
+		})
+
+		It("returns an error when the checksum does not match, even when unsigned plugins are allowed", func() {
+			fakeChecksum.CheckSha1Returns(false)
+
+			path, err := plugininstaller.NewPluginInstaller(context).Install("plugin1")
+			Expect(err).To(MatchError("Downloaded plugin binary's checksum does not match repo metadata"))
+			Expect(path).To(BeEmpty())
+			Expect(fakeVerifier.VerifyCallCount()).To(Equal(0))
+		})
+
+		It("installs the entry of the requested version", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]pluginrepo.Plugin{
+				"repo1": {
+					{Name: "plugin1", Version: "1.0.0", Binaries: []pluginrepo.Binary{{Platform: "none"}}},
+					{Name: "plugin1", Version: "v2.0.0", Binaries: []pluginrepo.Binary{
+						{Platform: "osx", Url: testServer.URL + "/plugin1.exe", Checksum: "def"},
+						{Platform: "win32", Url: testServer.URL + "/plugin1.exe", Checksum: "def"},
+						{Platform: "win64", Url: testServer.URL + "/plugin1.exe", Checksum: "def"},
+						{Platform: "linux32", Url: testServer.URL + "/plugin1.exe", Checksum: "def"},
+						{Platform: "linux64", Url: testServer.URL + "/plugin1.exe", Checksum: "def"},
+					}},
+				},
+			}, nil)
+			context.Version = "2.0.0"
+
+			_, err := plugininstaller.NewPluginInstaller(context).Install("plugin1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeChecksum.CheckSha1ArgsForCall(0)).To(Equal("def"))
+		})
+
+		It("returns an error when the requested version is not in the repository", func() {
+			context.Version = "3.0.0"
+
+			_, err := plugininstaller.NewPluginInstaller(context).Install("plugin1")
+			Expect(err).To(MatchError("plugin1 v3.0.0 is not available in repo 'repo1'"))
+		})
+
+		It("returns an error when the repository is not added", func() {
+			context.RepoName = "repo2"
+
+			_, err := plugininstaller.NewPluginInstaller(context).Install("plugin1")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("repo2 not found"))
+			Expect(fakePluginRepo.GetPluginsCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when the repository metadata cannot be read", func() {
+			fakePluginRepo.GetPluginsReturns(nil, []string{"repo error"})
+
+			_, err := plugininstaller.NewPluginInstaller(context).Install("plugin1")
+			Expect(err).To(MatchError("Error getting plugin metadata from repo: repo error"))
+		})
+
+		It("returns an error when the plugin is not in the repository", func() {
+			_, err := plugininstaller.NewPluginInstaller(context).Install("plugin2")
+			Expect(err).To(MatchError("plugin2 is not available in repo 'repo1'"))
+			Expect(fakeChecksum.CheckSha1CallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("installing from a path", func() {
+		BeforeEach(func() {
+			context.RepoName = ""
+		})
+
+		It("returns an error when the file does not exist", func() {
+			path, err := plugininstaller.NewPluginInstaller(context).Install("./no/file/is/here.exe")
+			Expect(err).To(MatchError("File not found locally, make sure the file exists at given path ./no/file/is/here.exe"))
+			Expect(path).To(BeEmpty())
+		})
+
+		It("returns an error when the binary cannot be downloaded", func() {
+			notFoundServer := httptest.NewServer(http.NotFoundHandler())
+			defer notFoundServer.Close()
+
+			path, err := plugininstaller.NewPluginInstaller(context).Install(notFoundServer.URL + "/plugin1.exe")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unable to install, plugin is not available from the given url."))
+			Expect(path).To(BeEmpty())
+		})
+	})
+})
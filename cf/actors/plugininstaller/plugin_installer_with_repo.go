@@ -9,7 +9,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/utils"
-	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
+	"github.com/blang/semver"
 )
 
 type pluginInstallerWithRepo struct {
@@ -20,44 +20,66 @@ type pluginInstallerWithRepo struct {
 	Checksummer      utils.Sha1Checksum
 	PluginRepo       pluginrepo.PluginRepo
 	GetPluginRepos   pluginReposFetcher
+	SignatureChecker *signatureChecker
+	Version          string
 }
 
-func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) string {
-	var outputSourceFilepath string
-
+func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) (string, error) {
 	targetPluginName := strings.ToLower(inputSourceFilepath)
 
 	installer.UI.Say(T("Looking up '{{.filePath}}' from repository '{{.repoName}}'", map[string]interface{}{"filePath": inputSourceFilepath, "repoName": installer.RepoName}))
 
 	repoModel, err := installer.getRepoFromConfig(installer.RepoName)
 	if err != nil {
-		installer.UI.Failed(err.Error() + "\n" + T("Tip: use 'add-plugin-repo' to register the repo"))
+		return "", errors.New(err.Error() + "\n" + T("Tip: use 'add-plugin-repo' to register the repo"))
 	}
 
 	pluginList, repoAry := installer.PluginRepo.GetPlugins([]models.PluginRepo{repoModel})
 	if len(repoAry) != 0 {
-		installer.UI.Failed(T("Error getting plugin metadata from repo: ") + repoAry[0])
+		return "", errors.New(T("Error getting plugin metadata from repo: ") + repoAry[0])
 	}
 
-	found := false
-	sha1 := ""
 	for _, plugin := range findRepoCaseInsensity(pluginList, installer.RepoName) {
-		if strings.ToLower(plugin.Name) == targetPluginName {
-			found = true
-			outputSourceFilepath, sha1 = installer.PluginDownloader.downloadFromPlugin(plugin)
-
-			installer.Checksummer.SetFilePath(outputSourceFilepath)
-			if !installer.Checksummer.CheckSha1(sha1) {
-				installer.UI.Failed(T("Downloaded plugin binary's checksum does not match repo metadata"))
-			}
+		if strings.ToLower(plugin.Name) != targetPluginName {
+			continue
+		}
+		if installer.Version != "" && !sameVersion(plugin.Version, installer.Version) {
+			continue
+		}
+
+		outputSourceFilepath, binary, err := installer.PluginDownloader.downloadFromPlugin(plugin)
+		if err != nil {
+			return "", err
+		}
+
+		installer.Checksummer.SetFilePath(outputSourceFilepath)
+		if !installer.Checksummer.CheckSha1(binary.Checksum) {
+			return "", errors.New(T("Downloaded plugin binary's checksum does not match repo metadata"))
 		}
 
+		err = installer.SignatureChecker.check(outputSourceFilepath, binary.Signature)
+		if err != nil {
+			return "", err
+		}
+		return outputSourceFilepath, nil
 	}
-	if !found {
-		installer.UI.Failed(inputSourceFilepath + T(" is not available in repo '") + installer.RepoName + "'")
+
+	if installer.Version != "" {
+		return "", errors.New(T("{{.PluginName}} v{{.Version}} is not available in repo '{{.RepoName}}'",
+			map[string]interface{}{"PluginName": inputSourceFilepath, "Version": installer.Version, "RepoName": installer.RepoName}))
 	}
+	return "", errors.New(inputSourceFilepath + T(" is not available in repo '") + installer.RepoName + "'")
+}
 
-	return outputSourceFilepath
+// sameVersion reports whether two plugin versions are the same, comparing
+// them as semantic versions when both can be parsed as one.
+func sameVersion(a, b string) bool {
+	versionA, errA := semver.ParseTolerant(a)
+	versionB, errB := semver.ParseTolerant(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return versionA.Equals(versionB)
 }
 
 func (installer *pluginInstallerWithRepo) getRepoFromConfig(repoName string) (models.PluginRepo, error) {
@@ -73,7 +95,7 @@ func (installer *pluginInstallerWithRepo) getRepoFromConfig(repoName string) (mo
 	return models.PluginRepo{}, errors.New(repoName + T(" not found"))
 }
 
-func findRepoCaseInsensity(repoList map[string][]clipr.Plugin, repoName string) []clipr.Plugin {
+func findRepoCaseInsensity(repoList map[string][]pluginrepo.Plugin, repoName string) []pluginrepo.Plugin {
 	target := strings.ToLower(repoName)
 	for k, repo := range repoList {
 		if strings.ToLower(k) == target {
@@ -1,6 +1,8 @@
 package plugininstaller
 
 import (
+	"errors"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,9 +16,12 @@ type pluginInstallerWithoutRepo struct {
 	PluginDownloader *PluginDownloader
 	DownloadFromPath downloadFromPath
 	RepoName         string
+	SignatureChecker *signatureChecker
 }
 
-func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string) (outputSourceFilepath string) {
+func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string) (string, error) {
+	var outputSourceFilepath string
+
 	if filepath.Dir(inputSourceFilepath) == "." {
 		outputSourceFilepath = "./" + filepath.Clean(inputSourceFilepath)
 	} else {
@@ -27,12 +32,32 @@ func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string)
 	if strings.HasPrefix(outputSourceFilepath, "https://") || strings.HasPrefix(outputSourceFilepath, "http://") ||
 		strings.HasPrefix(outputSourceFilepath, "ftp://") || strings.HasPrefix(outputSourceFilepath, "ftps://") {
 		installer.UI.Say(T("Attempting to download binary file from internet address..."))
-		return installer.PluginDownloader.downloadFromPath(outputSourceFilepath)
+		// The signature is fetched first, as the downloader only keeps track
+		// of its last file for removal.
+		signature, err := installer.PluginDownloader.downloadSignature(outputSourceFilepath)
+		if err != nil {
+			return "", errors.New(T("Download attempt failed: {{.Error}}\n\nUnable to install, the plugin signature could not be downloaded from the given url.", map[string]interface{}{"Error": err.Error()}))
+		}
+		downloadedFilepath, err := installer.PluginDownloader.downloadFromPath(outputSourceFilepath)
+		if err != nil {
+			return "", err
+		}
+		return downloadedFilepath, installer.SignatureChecker.check(downloadedFilepath, signature)
 	} else if !installer.ensureCandidatePluginBinaryExistsAtGivenPath(outputSourceFilepath) {
-		installer.UI.Failed(T("File not found locally, make sure the file exists at given path {{.filepath}}", map[string]interface{}{"filepath": outputSourceFilepath}))
+		return "", errors.New(T("File not found locally, make sure the file exists at given path {{.filepath}}", map[string]interface{}{"filepath": outputSourceFilepath}))
 	}
 
-	return outputSourceFilepath
+	return outputSourceFilepath, installer.SignatureChecker.check(outputSourceFilepath, readSignatureFile(outputSourceFilepath))
+}
+
+// readSignatureFile reads the detached signature stored next to a local
+// plugin binary, in a file named after the binary with a .sig extension.
+func readSignatureFile(pluginSourceFilepath string) string {
+	signature, err := ioutil.ReadFile(pluginSourceFilepath + ".sig")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(signature))
 }
 
 func (installer *pluginInstallerWithoutRepo) ensureCandidatePluginBinaryExistsAtGivenPath(pluginSourceFilepath string) bool {
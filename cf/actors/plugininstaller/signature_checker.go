@@ -0,0 +1,42 @@
+package plugininstaller
+
+import (
+	"errors"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/utils"
+)
+
+type signatureChecker struct {
+	UI            terminal.UI
+	Verifier      utils.SignatureVerifier
+	AllowUnsigned bool
+}
+
+// check returns an error unless the plugin binary at path is signed by a
+// trusted publisher. With AllowUnsigned, unsigned and untrusted binaries
+// only get a warning.
+func (checker *signatureChecker) check(path string, signature string) error {
+	if signature == "" {
+		if !checker.AllowUnsigned {
+			return errors.New(T("The plugin binary is not signed.\nUse '--allow-unsigned' to install it anyway."))
+		}
+		checker.UI.Warn(T("Installing an unsigned plugin binary."))
+		return nil
+	}
+
+	publisher, err := checker.Verifier.Verify(path, signature)
+	switch {
+	case err == nil:
+		checker.UI.Say(T("Plugin signature verified, signed by {{.Publisher}}", map[string]interface{}{"Publisher": terminal.EntityNameColor(publisher)}))
+	case err != utils.ErrUntrustedSignature:
+		return errors.New(T("Could not verify the plugin signature: {{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	case checker.AllowUnsigned:
+		checker.UI.Warn(T("Installing a plugin binary that is not signed by a trusted publisher."))
+	default:
+		return errors.New(T("The plugin binary is not signed by a trusted publisher.\nAdd the publisher's public key to {{.Path}}, or use '--allow-unsigned' to install it anyway.", map[string]interface{}{"Path": checker.Verifier.TrustedKeysPath()}))
+	}
+
+	return nil
+}
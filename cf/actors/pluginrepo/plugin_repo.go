@@ -6,17 +6,50 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/models"
-	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
 
 	. "code.cloudfoundry.org/cli/cf/i18n"
 )
 
+// Plugin is a plugin as listed by a plugin repo. It follows the listing of
+// the cli-plugin-repo, with the signatures of the binaries added.
+type Plugin struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Version     string    `json:"version"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+	Company     string    `json:"company"`
+	Authors     []Author  `json:"authors"`
+	Homepage    string    `json:"homepage"`
+	Binaries    []Binary  `json:"binaries"`
+}
+
+// Binary is the build of a plugin for one platform. Signature is the
+// detached signature of the binary, empty when the repo publishes none.
+type Binary struct {
+	Platform  string `json:"platform"`
+	Url       string `json:"url"`
+	Checksum  string `json:"checksum"`
+	Signature string `json:"signature"`
+}
+
+type Author struct {
+	Name     string `json:"name"`
+	Homepage string `json:"homepage"`
+	Contact  string `json:"contact"`
+}
+
+type pluginsJSON struct {
+	Plugins []Plugin `json:"plugins"`
+}
+
 //go:generate counterfeiter . PluginRepo
 
 type PluginRepo interface {
-	GetPlugins([]models.PluginRepo) (map[string][]clipr.Plugin, []string)
+	GetPlugins([]models.PluginRepo) (map[string][]Plugin, []string)
 }
 
 type pluginRepo struct{}
@@ -25,10 +58,10 @@ func NewPluginRepo() PluginRepo {
 	return pluginRepo{}
 }
 
-func (r pluginRepo) GetPlugins(repos []models.PluginRepo) (map[string][]clipr.Plugin, []string) {
-	var pluginList clipr.PluginsJson
+func (r pluginRepo) GetPlugins(repos []models.PluginRepo) (map[string][]Plugin, []string) {
+	var pluginList pluginsJSON
 	repoError := []string{}
-	repoPlugins := make(map[string][]clipr.Plugin)
+	repoPlugins := make(map[string][]Plugin)
 
 	for _, repo := range repos {
 		resp, err := http.Get(getListEndpoint(repo.URL))
@@ -44,7 +77,7 @@ func (r pluginRepo) GetPlugins(repos []models.PluginRepo) (map[string][]clipr.Pl
 				continue
 			}
 
-			pluginList = clipr.PluginsJson{Plugins: nil}
+			pluginList = pluginsJSON{Plugins: nil}
 			err = json.Unmarshal(body, &pluginList)
 			if err != nil {
 				repoError = append(repoError, fmt.Sprintf(T("Invalid json data from")+" '%s' - %s", repo.Name, err.Error()))
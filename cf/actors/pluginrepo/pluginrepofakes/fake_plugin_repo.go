@@ -6,24 +6,23 @@ import (
 
 	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
 	"code.cloudfoundry.org/cli/cf/models"
-	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
 )
 
 type FakePluginRepo struct {
-	GetPluginsStub        func([]models.PluginRepo) (map[string][]clipr.Plugin, []string)
+	GetPluginsStub        func([]models.PluginRepo) (map[string][]pluginrepo.Plugin, []string)
 	getPluginsMutex       sync.RWMutex
 	getPluginsArgsForCall []struct {
 		arg1 []models.PluginRepo
 	}
 	getPluginsReturns struct {
-		result1 map[string][]clipr.Plugin
+		result1 map[string][]pluginrepo.Plugin
 		result2 []string
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePluginRepo) GetPlugins(arg1 []models.PluginRepo) (map[string][]clipr.Plugin, []string) {
+func (fake *FakePluginRepo) GetPlugins(arg1 []models.PluginRepo) (map[string][]pluginrepo.Plugin, []string) {
 	var arg1Copy []models.PluginRepo
 	if arg1 != nil {
 		arg1Copy = make([]models.PluginRepo, len(arg1))
@@ -54,10 +53,10 @@ func (fake *FakePluginRepo) GetPluginsArgsForCall(i int) []models.PluginRepo {
 	return fake.getPluginsArgsForCall[i].arg1
 }
 
-func (fake *FakePluginRepo) GetPluginsReturns(result1 map[string][]clipr.Plugin, result2 []string) {
+func (fake *FakePluginRepo) GetPluginsReturns(result1 map[string][]pluginrepo.Plugin, result2 []string) {
 	fake.GetPluginsStub = nil
 	fake.getPluginsReturns = struct {
-		result1 map[string][]clipr.Plugin
+		result1 map[string][]pluginrepo.Plugin
 		result2 []string
 	}{result1, result2}
 }
@@ -0,0 +1,81 @@
+package pluginrepo
+
+import (
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/plugin"
+	"github.com/blang/semver"
+)
+
+// PluginUpdate is a newer version of an installed plugin, available in a
+// plugin repository.
+type PluginUpdate struct {
+	Name             string
+	InstalledVersion semver.Version
+	Version          semver.Version
+	RepoName         string
+}
+
+// FindUpdates compares the installed plugins with the plugins of the
+// repositories and returns the plugins that have a newer version available,
+// sorted by name. When several repositories carry a plugin, the update is
+// the highest version among them. Plugin names are matched case
+// insensitively, like install-plugin does, and repository versions that are
+// not semantic versions are ignored.
+func FindUpdates(installed map[string]plugin.VersionType, repoPlugins map[string][]Plugin) []PluginUpdate {
+	repoNames := make([]string, 0, len(repoPlugins))
+	for repoName := range repoPlugins {
+		repoNames = append(repoNames, repoName)
+	}
+	sort.Strings(repoNames)
+
+	names := make([]string, 0, len(installed))
+	for name := range installed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	updates := []PluginUpdate{}
+	for _, name := range names {
+		update := PluginUpdate{
+			Name:             name,
+			InstalledVersion: InstalledVersion(installed[name]),
+		}
+		update.Version = update.InstalledVersion
+
+		for _, repoName := range repoNames {
+			for _, p := range repoPlugins[repoName] {
+				if !strings.EqualFold(p.Name, name) {
+					continue
+				}
+
+				version, err := semver.ParseTolerant(p.Version)
+				if err != nil {
+					continue
+				}
+
+				if version.GT(update.Version) {
+					update.Version = version
+					update.RepoName = repoName
+				}
+			}
+		}
+
+		if update.RepoName != "" {
+			updates = append(updates, update)
+		}
+	}
+
+	return updates
+}
+
+// InstalledVersion converts the version a plugin reports in its metadata to
+// a semantic version.
+func InstalledVersion(version plugin.VersionType) semver.Version {
+	return semver.Version{
+		Major: uint64(version.Major),
+		Minor: uint64(version.Minor),
+		Patch: uint64(version.Build),
+	}
+}
@@ -0,0 +1,71 @@
+package pluginrepo_test
+
+import (
+	. "code.cloudfoundry.org/cli/cf/actors/pluginrepo"
+	"code.cloudfoundry.org/cli/plugin"
+	"github.com/blang/semver"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindUpdates", func() {
+	var installed map[string]plugin.VersionType
+
+	BeforeEach(func() {
+		installed = map[string]plugin.VersionType{
+			"echo":    {Major: 1, Minor: 2, Build: 0},
+			"diego":   {Major: 0, Minor: 5, Build: 1},
+			"current": {Major: 2, Minor: 0, Build: 0},
+		}
+	})
+
+	It("returns the installed plugins with a newer version, sorted by name", func() {
+		updates := FindUpdates(installed, map[string][]Plugin{
+			"CF-Community": {
+				{Name: "echo", Version: "1.3.0"},
+				{Name: "Diego", Version: "0.6"},
+				{Name: "current", Version: "2.0.0"},
+				{Name: "not-installed", Version: "9.0.0"},
+			},
+		})
+
+		Expect(updates).To(Equal([]PluginUpdate{
+			{
+				Name:             "diego",
+				InstalledVersion: semver.MustParse("0.5.1"),
+				Version:          semver.MustParse("0.6.0"),
+				RepoName:         "CF-Community",
+			},
+			{
+				Name:             "echo",
+				InstalledVersion: semver.MustParse("1.2.0"),
+				Version:          semver.MustParse("1.3.0"),
+				RepoName:         "CF-Community",
+			},
+		}))
+	})
+
+	It("picks the highest version across all repositories", func() {
+		updates := FindUpdates(installed, map[string][]Plugin{
+			"repo-a": {{Name: "echo", Version: "1.4.0"}},
+			"repo-b": {{Name: "echo", Version: "1.10.0"}},
+			"repo-c": {{Name: "echo", Version: "1.3.0"}},
+		})
+
+		Expect(updates).To(HaveLen(1))
+		Expect(updates[0].Version).To(Equal(semver.MustParse("1.10.0")))
+		Expect(updates[0].RepoName).To(Equal("repo-b"))
+	})
+
+	It("ignores older versions and versions that cannot be parsed", func() {
+		updates := FindUpdates(installed, map[string][]Plugin{
+			"repo": {
+				{Name: "echo", Version: "1.1.9"},
+				{Name: "diego", Version: "latest"},
+			},
+		})
+
+		Expect(updates).To(BeEmpty())
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 
@@ -105,6 +106,24 @@ func (actor PushActorImpl) ProcessPath(dirOrZipFile string, f func(string) error
 	return nil
 }
 
+// cfIgnoreFiles returns the paths of the .cfignore files that may apply to
+// the given files: the one at the top of the app and one per directory that
+// holds any of the files.
+func cfIgnoreFiles(localFiles []models.AppFileFields) []string {
+	ignoreFiles := []string{".cfignore"}
+	seen := map[string]bool{".": true}
+
+	for _, file := range localFiles {
+		dir := path.Dir(file.Path)
+		if !seen[dir] {
+			seen[dir] = true
+			ignoreFiles = append(ignoreFiles, filepath.Join(filepath.FromSlash(dir), ".cfignore"))
+		}
+	}
+
+	return ignoreFiles
+}
+
 func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error) {
 	appFileResource := []resources.AppFileResource{}
 	for _, file := range localFiles {
@@ -136,11 +155,13 @@ func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir
 		return []resources.AppFileResource{}, false, err
 	}
 
-	_, err = os.Stat(filepath.Join(appDir, ".cfignore"))
-	if err == nil {
-		err = fileutils.CopyPathToPath(filepath.Join(appDir, ".cfignore"), filepath.Join(uploadDir, ".cfignore"))
-		if err != nil {
-			return []resources.AppFileResource{}, false, err
+	for _, ignoreFile := range cfIgnoreFiles(localFiles) {
+		_, err = os.Stat(filepath.Join(appDir, ignoreFile))
+		if err == nil {
+			err = fileutils.CopyPathToPath(filepath.Join(appDir, ignoreFile), filepath.Join(uploadDir, ignoreFile))
+			if err != nil {
+				return []resources.AppFileResource{}, false, err
+			}
 		}
 	}
 
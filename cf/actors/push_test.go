@@ -113,6 +113,40 @@ var _ = Describe("Push Actor", func() {
 				_, err = os.Stat(filepath.Join(tmpDir, ".cfignore"))
 				Expect(os.IsNotExist(err)).To(BeFalse())
 			})
+
+			Context("when subdirectories have their own .cfignore", func() {
+				BeforeEach(func() {
+					var err error
+					appDir, err = ioutil.TempDir("", "nested-cfignore")
+					Expect(err).NotTo(HaveOccurred())
+
+					err = os.MkdirAll(filepath.Join(appDir, "lib", "vendor"), 0700)
+					Expect(err).NotTo(HaveOccurred())
+					err = ioutil.WriteFile(filepath.Join(appDir, "lib", ".cfignore"), []byte("*.log\n"), 0600)
+					Expect(err).NotTo(HaveOccurred())
+					err = ioutil.WriteFile(filepath.Join(appDir, "lib", "vendor", ".cfignore"), []byte("*.tmp\n"), 0600)
+					Expect(err).NotTo(HaveOccurred())
+
+					allFiles = []models.AppFileFields{
+						{Path: "lib", Sha1: "0"},
+						{Path: "lib/app.rb"},
+					}
+				})
+
+				AfterEach(func() {
+					os.RemoveAll(appDir)
+				})
+
+				It("copies the .cfignore files of the directories holding app files", func() {
+					_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = os.Stat(filepath.Join(tmpDir, "lib", ".cfignore"))
+					Expect(err).NotTo(HaveOccurred())
+					_, err = os.Stat(filepath.Join(tmpDir, "lib", "vendor", ".cfignore"))
+					Expect(os.IsNotExist(err)).To(BeTrue())
+				})
+			})
 		})
 
 		It("returns files to upload with file mode unchanged on non-Windows platforms", func() {
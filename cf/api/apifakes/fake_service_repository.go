@@ -174,6 +174,15 @@ type FakeServiceRepository struct {
 		result1 int
 		result2 error
 	}
+	GetServiceInstanceParametersStub        func(instanceGUID string) (map[string]interface{}, error)
+	getServiceInstanceParametersMutex       sync.RWMutex
+	getServiceInstanceParametersArgsForCall []struct {
+		instanceGUID string
+	}
+	getServiceInstanceParametersReturns struct {
+		result1 map[string]interface{}
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -801,6 +810,40 @@ func (fake *FakeServiceRepository) MigrateServicePlanFromV1ToV2Returns(result1 i
 	}{result1, result2}
 }
 
+func (fake *FakeServiceRepository) GetServiceInstanceParameters(instanceGUID string) (map[string]interface{}, error) {
+	fake.getServiceInstanceParametersMutex.Lock()
+	fake.getServiceInstanceParametersArgsForCall = append(fake.getServiceInstanceParametersArgsForCall, struct {
+		instanceGUID string
+	}{instanceGUID})
+	fake.recordInvocation("GetServiceInstanceParameters", []interface{}{instanceGUID})
+	fake.getServiceInstanceParametersMutex.Unlock()
+	if fake.GetServiceInstanceParametersStub != nil {
+		return fake.GetServiceInstanceParametersStub(instanceGUID)
+	} else {
+		return fake.getServiceInstanceParametersReturns.result1, fake.getServiceInstanceParametersReturns.result2
+	}
+}
+
+func (fake *FakeServiceRepository) GetServiceInstanceParametersCallCount() int {
+	fake.getServiceInstanceParametersMutex.RLock()
+	defer fake.getServiceInstanceParametersMutex.RUnlock()
+	return len(fake.getServiceInstanceParametersArgsForCall)
+}
+
+func (fake *FakeServiceRepository) GetServiceInstanceParametersArgsForCall(i int) string {
+	fake.getServiceInstanceParametersMutex.RLock()
+	defer fake.getServiceInstanceParametersMutex.RUnlock()
+	return fake.getServiceInstanceParametersArgsForCall[i].instanceGUID
+}
+
+func (fake *FakeServiceRepository) GetServiceInstanceParametersReturns(result1 map[string]interface{}, result2 error) {
+	fake.GetServiceInstanceParametersStub = nil
+	fake.getServiceInstanceParametersReturns = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeServiceRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -840,6 +883,8 @@ func (fake *FakeServiceRepository) Invocations() map[string][][]interface{} {
 	defer fake.getServiceInstanceCountForServicePlanMutex.RUnlock()
 	fake.migrateServicePlanFromV1ToV2Mutex.RLock()
 	defer fake.migrateServicePlanFromV1ToV2Mutex.RUnlock()
+	fake.getServiceInstanceParametersMutex.RLock()
+	defer fake.getServiceInstanceParametersMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -35,6 +35,15 @@ type FakeUserProvidedServiceInstanceRepository struct {
 		result1 models.UserProvidedServiceSummary
 		result2 error
 	}
+	GetStub        func(guid string) (models.UserProvidedService, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		guid string
+	}
+	getReturns struct {
+		result1 models.UserProvidedService
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -134,6 +143,40 @@ func (fake *FakeUserProvidedServiceInstanceRepository) GetSummariesReturns(resul
 	}{result1, result2}
 }
 
+func (fake *FakeUserProvidedServiceInstanceRepository) Get(guid string) (models.UserProvidedService, error) {
+	fake.getMutex.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		guid string
+	}{guid})
+	fake.recordInvocation("Get", []interface{}{guid})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(guid)
+	} else {
+		return fake.getReturns.result1, fake.getReturns.result2
+	}
+}
+
+func (fake *FakeUserProvidedServiceInstanceRepository) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeUserProvidedServiceInstanceRepository) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].guid
+}
+
+func (fake *FakeUserProvidedServiceInstanceRepository) GetReturns(result1 models.UserProvidedService, result2 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 models.UserProvidedService
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeUserProvidedServiceInstanceRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -143,6 +186,8 @@ func (fake *FakeUserProvidedServiceInstanceRepository) Invocations() map[string]
 	defer fake.updateMutex.RUnlock()
 	fake.getSummariesMutex.RLock()
 	defer fake.getSummariesMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"net/textproto"
 	"os"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/appfiles"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/gofileutils/fileutils"
@@ -19,6 +22,10 @@ import (
 
 const (
 	DefaultAppUploadBitsTimeout = 15 * time.Minute
+	DefaultAppUploadAttempts    = 5
+	DefaultAppUploadBackoff     = 2 * time.Second
+
+	maxAppUploadBackoff = time.Minute
 )
 
 //go:generate counterfeiter . Repository
@@ -29,17 +36,74 @@ type Repository interface {
 }
 
 type CloudControllerApplicationBitsRepository struct {
-	config  coreconfig.Reader
-	gateway net.Gateway
+	config         coreconfig.Reader
+	gateway        net.Gateway
+	uploadAttempts int
+	uploadBackoff  time.Duration
+	resourceCache  *appfiles.ResourceCache
 }
 
 func NewCloudControllerApplicationBitsRepository(config coreconfig.Reader, gateway net.Gateway) (repo CloudControllerApplicationBitsRepository) {
 	repo.config = config
 	repo.gateway = gateway
+	repo.uploadAttempts = DefaultAppUploadAttempts
+	repo.uploadBackoff = DefaultAppUploadBackoff
 	return
 }
 
+// WithUploadRetries returns a copy of the repository that tries an upload up
+// to attempts times, waiting backoff before the first retry and twice as long
+// before each following one.
+func (repo CloudControllerApplicationBitsRepository) WithUploadRetries(attempts int, backoff time.Duration) CloudControllerApplicationBitsRepository {
+	repo.uploadAttempts = attempts
+	repo.uploadBackoff = backoff
+	return repo
+}
+
+// WithResourceCache returns a copy of the repository that remembers which
+// resources the Cloud Controller already has and only asks it about the
+// others.
+func (repo CloudControllerApplicationBitsRepository) WithResourceCache(cache *appfiles.ResourceCache) CloudControllerApplicationBitsRepository {
+	repo.resourceCache = cache
+	return repo
+}
+
+// StaleResourcesError is returned by UploadBits when an upload that listed
+// resources known from the resource cache fails. They may have been pruned
+// from the resource pool since they were matched. The cache has been cleared,
+// so gathering the files again matches all of them against the Cloud
+// Controller.
+type StaleResourcesError struct {
+	Err error
+}
+
+func (err *StaleResourcesError) Error() string {
+	return err.Err.Error()
+}
+
 func (repo CloudControllerApplicationBitsRepository) UploadBits(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) (apiErr error) {
+	defer func() {
+		if apiErr != nil {
+			endpoint := repo.config.APIEndpoint()
+			usedCache := false
+			for _, presentFile := range presentFiles {
+				if repo.resourceCache.HasResource(endpoint, presentFile.Sha1) {
+					usedCache = true
+					break
+				}
+			}
+
+			// the present files may have come from the cache and no longer be
+			// in the resource pool, so ask the Cloud Controller again
+			repo.resourceCache.ForgetResources(endpoint)
+			_ = repo.resourceCache.Save()
+
+			if usedCache {
+				apiErr = &StaleResourcesError{Err: apiErr}
+			}
+		}
+	}()
+
 	apiURL := fmt.Sprintf("/v2/apps/%s/bits", appGUID)
 	fileutils.TempFile("requests", func(requestFile *os.File, err error) {
 		if err != nil {
@@ -64,26 +128,102 @@ func (repo CloudControllerApplicationBitsRepository) UploadBits(appGUID string,
 			return
 		}
 
-		var request *net.Request
-		request, apiErr = repo.gateway.NewRequestForFile("PUT", repo.config.APIEndpoint()+apiURL, repo.config.AccessToken(), requestFile)
-		if apiErr != nil {
-			return
-		}
+		apiErr = repo.uploadBody(apiURL, requestFile, boundary)
+	})
 
-		contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
-		request.HTTPReq.Header.Set("Content-Type", contentType)
+	return
+}
 
-		response := &resources.Resource{}
-		_, apiErr = repo.gateway.PerformPollingRequestForJSONResponse(repo.config.APIEndpoint(), request, response, DefaultAppUploadBitsTimeout)
-		if apiErr != nil {
-			return
+// uploadBody sends the prepared request body and waits for the Cloud
+// Controller to process it. The Cloud Controller has no resumable uploads,
+// so when the PUT fails on a transient network or server error the whole
+// prepared body is sent again after a backoff. Only zipping the app files
+// again is avoided. Errors while waiting for the upload job are not retried,
+// as the bits have been received by then.
+func (repo CloudControllerApplicationBitsRepository) uploadBody(apiURL string, requestFile *os.File, boundary string) error {
+	backoff := repo.uploadBackoff
+
+	for attempt := 1; ; attempt++ {
+		jobURL, err := repo.putBody(apiURL, requestFile, boundary)
+		if err == nil {
+			if jobURL == "" {
+				return nil
+			}
+			return repo.gateway.WaitForJob(repo.config.APIEndpoint()+jobURL, repo.config.AccessToken(), DefaultAppUploadBitsTimeout)
+		}
+		if attempt >= repo.uploadAttempts || !isTransientUploadError(err) {
+			return err
 		}
-	})
 
-	return
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxAppUploadBackoff {
+			backoff = maxAppUploadBackoff
+		}
+	}
+}
+
+// putBody sends the whole prepared request body, from its first byte, and
+// returns the URL of the job that processes the upload.
+func (repo CloudControllerApplicationBitsRepository) putBody(apiURL string, requestFile *os.File, boundary string) (string, error) {
+	request, err := repo.gateway.NewRequestForFile("PUT", repo.config.APIEndpoint()+apiURL, repo.config.AccessToken(), requestFile)
+	if err != nil {
+		return "", err
+	}
+
+	contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
+	request.HTTPReq.Header.Set("Content-Type", contentType)
+
+	response := &resources.Resource{}
+	jobURL, _, err := repo.gateway.PerformAsyncRequestForJSONResponse(request, response)
+	return jobURL, err
+}
+
+func isTransientUploadError(err error) bool {
+	switch typedErr := err.(type) {
+	case *errors.NetworkError:
+		return true
+	case errors.HTTPError:
+		switch typedErr.StatusCode() {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
 }
 
 func (repo CloudControllerApplicationBitsRepository) GetApplicationFiles(appFilesToCheck []resources.AppFileResource) ([]resources.AppFileResource, error) {
+	endpoint := repo.config.APIEndpoint()
+
+	var cachedFiles, unknownFiles []resources.AppFileResource
+	for _, appFile := range appFilesToCheck {
+		if repo.resourceCache.HasResource(endpoint, appFile.Sha1) {
+			cachedFiles = append(cachedFiles, appFile)
+		} else {
+			unknownFiles = append(unknownFiles, appFile)
+		}
+	}
+
+	if len(unknownFiles) == 0 && len(cachedFiles) > 0 {
+		return cachedFiles, nil
+	}
+
+	matchedFiles, err := repo.matchResources(unknownFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	var matchedShas []string
+	for _, appFile := range matchedFiles {
+		matchedShas = append(matchedShas, appFile.Sha1)
+	}
+	repo.resourceCache.AddResources(endpoint, matchedShas)
+	_ = repo.resourceCache.Save()
+
+	return append(cachedFiles, matchedFiles...), nil
+}
+
+func (repo CloudControllerApplicationBitsRepository) matchResources(appFilesToCheck []resources.AppFileResource) ([]resources.AppFileResource, error) {
 	integrityFieldsJSON, err := json.Marshal(mapAppFilesToIntegrityFields(appFilesToCheck))
 	if err != nil {
 		apiErr := fmt.Errorf("%s: %s", T("Failed to create json for resource_match request"), err.Error())
@@ -3,6 +3,7 @@ package applicationbits_test
 import (
 	"archive/zip"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httptest"
@@ -15,10 +16,12 @@ import (
 
 	testapi "code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/appfiles"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
 	testnet "code.cloudfoundry.org/cli/testhelpers/net"
 
 	. "code.cloudfoundry.org/cli/cf/api/applicationbits"
@@ -37,6 +40,7 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 		file4       resources.AppFileResource
 		testServer  *httptest.Server
 		configRepo  coreconfig.ReadWriter
+		gateway     net.Gateway
 	)
 
 	BeforeEach(func() {
@@ -46,7 +50,7 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 
 		configRepo = testconfig.NewRepositoryWithDefaults()
 
-		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		gateway = net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
 		gateway.PollingThrottle = time.Duration(0)
 
 		repo = NewCloudControllerApplicationBitsRepository(configRepo, gateway)
@@ -125,6 +129,173 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 			Expect(apiErr).To(HaveOccurred())
 		})
 
+		Context("when the upload fails with a transient error", func() {
+			var failedUpload testnet.TestRequest
+
+			BeforeEach(func() {
+				gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+				gateway.PollingThrottle = time.Duration(0)
+				repo = NewCloudControllerApplicationBitsRepository(configRepo, gateway).WithUploadRetries(2, 0)
+
+				failedUpload = testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+					Method:  "PUT",
+					Path:    "/v2/apps/my-cool-app-guid/bits",
+					Matcher: uploadBodyMatcher(defaultZipCheck),
+					Response: testnet.TestResponse{
+						Status: http.StatusServiceUnavailable,
+						Body:   `{"code": 10001, "description": "unavailable"}`,
+					},
+				})
+			})
+
+			It("retries the upload with the same body", func() {
+				setupTestServer(failedUpload, testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+					Method:  "PUT",
+					Path:    "/v2/apps/my-cool-app-guid/bits",
+					Matcher: uploadBodyMatcher(defaultZipCheck),
+					Response: testnet.TestResponse{
+						Status: http.StatusCreated,
+						Body: `
+						{
+							"metadata":{
+								"guid": "my-job-guid",
+								"url": "/v2/jobs/my-job-guid"
+							}
+						}`,
+					},
+				}),
+					createProgressEndpoint("finished"),
+				)
+
+				apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+				Expect(apiErr).NotTo(HaveOccurred())
+			})
+
+			It("does not upload the body again when waiting for the upload job fails", func() {
+				var handler *testnet.TestHandler
+				testServer, handler = testnet.NewServer([]testnet.TestRequest{
+					testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+						Method:  "PUT",
+						Path:    "/v2/apps/my-cool-app-guid/bits",
+						Matcher: uploadBodyMatcher(defaultZipCheck),
+						Response: testnet.TestResponse{
+							Status: http.StatusCreated,
+							Body: `
+							{
+								"metadata":{
+									"guid": "my-job-guid",
+									"url": "/v2/jobs/my-job-guid"
+								}
+							}`,
+						},
+					}),
+					{
+						Method: "GET",
+						Path:   "/v2/jobs/my-job-guid",
+						Response: testnet.TestResponse{
+							Status: http.StatusServiceUnavailable,
+							Body:   `{"code": 10001, "description": "unavailable"}`,
+						},
+					},
+				})
+				configRepo.SetAPIEndpoint(testServer.URL)
+
+				apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+				Expect(apiErr).To(HaveOccurred())
+				Expect(handler.AllRequestsCalled()).To(BeTrue())
+			})
+
+			It("gives up after the configured number of attempts", func() {
+				setupTestServer(failedUpload, failedUpload)
+
+				apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+				Expect(apiErr).To(HaveOccurred())
+				Expect(apiErr.Error()).To(ContainSubstring("unavailable"))
+			})
+		})
+
+		Context("when the upload fails with a client error", func() {
+			It("does not retry the upload", func() {
+				setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+					Method:  "PUT",
+					Path:    "/v2/apps/my-cool-app-guid/bits",
+					Matcher: uploadBodyMatcher(defaultZipCheck),
+					Response: testnet.TestResponse{
+						Status: http.StatusBadRequest,
+						Body:   `{"code": 160001, "description": "bad zip"}`,
+					},
+				}))
+
+				apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+				Expect(apiErr).To(HaveOccurred())
+				Expect(apiErr.Error()).To(ContainSubstring("bad zip"))
+			})
+
+			It("forgets the cached resources of the endpoint", func() {
+				setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+					Method:  "PUT",
+					Path:    "/v2/apps/my-cool-app-guid/bits",
+					Matcher: uploadBodyMatcher(defaultZipCheck),
+					Response: testnet.TestResponse{
+						Status: http.StatusBadRequest,
+						Body:   `{"code": 160001, "description": "bad zip"}`,
+					},
+				}))
+
+				cacheDir, err := ioutil.TempDir("", "resource-cache")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.RemoveAll(cacheDir)
+
+				cache := appfiles.NewResourceCache(filepath.Join(cacheDir, "resource_cache.json"))
+				cache.AddResources(configRepo.APIEndpoint(), []string{file1.Sha1})
+				repo = NewCloudControllerApplicationBitsRepository(configRepo, gateway).WithResourceCache(cache)
+
+				apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+				Expect(apiErr).To(HaveOccurred())
+				Expect(cache.HasResource(configRepo.APIEndpoint(), file1.Sha1)).To(BeFalse())
+			})
+
+			It("reports that the upload relied on cached resources", func() {
+				setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+					Method:  "PUT",
+					Path:    "/v2/apps/my-cool-app-guid/bits",
+					Matcher: uploadBodyMatcher(defaultZipCheck),
+					Response: testnet.TestResponse{
+						Status: http.StatusBadRequest,
+						Body:   `{"code": 160001, "description": "resource not found"}`,
+					},
+				}))
+
+				cacheDir, err := ioutil.TempDir("", "resource-cache")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.RemoveAll(cacheDir)
+
+				cache := appfiles.NewResourceCache(filepath.Join(cacheDir, "resource_cache.json"))
+				cache.AddResources(configRepo.APIEndpoint(), []string{file1.Sha1})
+				repo = NewCloudControllerApplicationBitsRepository(configRepo, gateway).WithResourceCache(cache)
+
+				apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+				Expect(apiErr).To(BeAssignableToTypeOf(&StaleResourcesError{}))
+				Expect(apiErr.Error()).To(ContainSubstring("resource not found"))
+			})
+
+			It("does not report stale resources when none came from the cache", func() {
+				setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+					Method:  "PUT",
+					Path:    "/v2/apps/my-cool-app-guid/bits",
+					Matcher: uploadBodyMatcher(defaultZipCheck),
+					Response: testnet.TestResponse{
+						Status: http.StatusBadRequest,
+						Body:   `{"code": 160001, "description": "bad zip"}`,
+					},
+				}))
+
+				apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+				_, stale := apiErr.(*StaleResourcesError)
+				Expect(stale).To(BeFalse())
+			})
+		})
+
 		Context("when there are no files to upload", func() {
 			It("makes a request without a zipfile", func() {
 				setupTestServer(
@@ -214,6 +385,66 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 			Expect(matchedFiles).To(Equal([]resources.AppFileResource{file4}))
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		Context("when a resource cache is provided", func() {
+			var (
+				cacheDir string
+				cache    *appfiles.ResourceCache
+			)
+
+			BeforeEach(func() {
+				var err error
+				cacheDir, err = ioutil.TempDir("", "resource-cache")
+				Expect(err).NotTo(HaveOccurred())
+
+				cache = appfiles.NewResourceCache(filepath.Join(cacheDir, "resource_cache.json"))
+				repo = NewCloudControllerApplicationBitsRepository(configRepo, gateway).WithResourceCache(cache)
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(cacheDir)
+			})
+
+			It("remembers the matched files and only asks about the others next time", func() {
+				var handler *testnet.TestHandler
+				testServer, handler = testnet.NewServer([]testnet.TestRequest{matchResourceRequest, matchUnknownResourcesRequest})
+				configRepo.SetAPIEndpoint(testServer.URL)
+
+				matchedFiles, err := repo.GetApplicationFiles([]resources.AppFileResource{file1, file2, file3, file4})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(matchedFiles).To(Equal([]resources.AppFileResource{file3, file4}))
+
+				matchedFiles, err = repo.GetApplicationFiles([]resources.AppFileResource{file1, file2, file3, file4})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(matchedFiles).To(Equal([]resources.AppFileResource{file3, file4}))
+				Expect(handler).To(HaveAllRequestsCalled())
+			})
+
+			It("matches every file again once a stale cache was forgotten", func() {
+				var handler *testnet.TestHandler
+				testServer, handler = testnet.NewServer([]testnet.TestRequest{matchResourceRequest})
+				configRepo.SetAPIEndpoint(testServer.URL)
+				cache.AddResources(testServer.URL, []string{file1.Sha1, file2.Sha1})
+				cache.ForgetResources(testServer.URL)
+
+				matchedFiles, err := repo.GetApplicationFiles([]resources.AppFileResource{file1, file2, file3, file4})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(matchedFiles).To(Equal([]resources.AppFileResource{file3, file4}))
+				Expect(handler).To(HaveAllRequestsCalled())
+			})
+
+			It("does not make a request when every file is known", func() {
+				var handler *testnet.TestHandler
+				testServer, handler = testnet.NewServer([]testnet.TestRequest{})
+				configRepo.SetAPIEndpoint(testServer.URL)
+				cache.AddResources(testServer.URL, []string{file3.Sha1, file4.Sha1})
+
+				matchedFiles, err := repo.GetApplicationFiles([]resources.AppFileResource{file3, file4})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(matchedFiles).To(Equal([]resources.AppFileResource{file3, file4}))
+				Expect(handler.CallCount).To(Equal(0))
+			})
+		})
 	})
 })
 
@@ -261,6 +492,25 @@ func uploadApplicationRequest(zipCheck func(*zip.Reader)) testnet.TestRequest {
 	})
 }
 
+var matchUnknownResourcesRequest = testnet.TestRequest{
+	Method: "PUT",
+	Path:   "/v2/resource_match",
+	Matcher: testnet.RequestBodyMatcher(testnet.RemoveWhiteSpaceFromBody(`[
+	{
+        "sha1": "2474735f5163ba7612ef641f438f4b5bee00127b",
+        "size": 51
+    },
+    {
+        "sha1": "f097424ce1fa66c6cb9f5e8a18c317376ec12e05",
+        "size": 70
+    }
+]`)),
+	Response: testnet.TestResponse{
+		Status: http.StatusOK,
+		Body:   "[]",
+	},
+}
+
 var matchResourceRequest = testnet.TestRequest{
 	Method: "PUT",
 	Path:   "/v2/resource_match",
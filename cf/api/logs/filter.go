@@ -0,0 +1,71 @@
+package logs
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SourceTypes are the source types log messages can be filtered on.
+var SourceTypes = []string{"APP", "RTR", "STG", "CELL"}
+
+// Filter selects log messages. A zero Filter matches every message.
+type Filter struct {
+	// SourceTypes lists the accepted source types. A source type also
+	// matches the sources nested below it, such as APP/PROC/WEB for APP.
+	SourceTypes []string
+	// Instance is the accepted source instance, or "" for any instance.
+	Instance string
+	// Since and Until bound the timestamps of the accepted messages. A zero
+	// time leaves that end of the window open.
+	Since time.Time
+	Until time.Time
+	// Pattern has to match the message text, unless it is nil.
+	Pattern *regexp.Regexp
+}
+
+// IsSourceType reports whether name is one of the known SourceTypes,
+// ignoring case.
+func IsSourceType(name string) bool {
+	for _, sourceType := range SourceTypes {
+		if strings.EqualFold(name, sourceType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Filter) Matches(msg Loggable) bool {
+	if len(f.SourceTypes) > 0 && !f.matchesSourceType(msg.GetSourceName()) {
+		return false
+	}
+
+	if f.Instance != "" && msg.GetSourceInstance() != f.Instance {
+		return false
+	}
+
+	timestamp := msg.GetTimestamp()
+	if !f.Since.IsZero() && timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && timestamp.After(f.Until) {
+		return false
+	}
+
+	if f.Pattern != nil && !f.Pattern.MatchString(msg.ToSimpleLog()) {
+		return false
+	}
+
+	return true
+}
+
+func (f Filter) matchesSourceType(sourceName string) bool {
+	sourceName = strings.ToUpper(sourceName)
+	for _, sourceType := range f.SourceTypes {
+		sourceType = strings.ToUpper(sourceType)
+		if sourceName == sourceType || strings.HasPrefix(sourceName, sourceType+"/") {
+			return true
+		}
+	}
+	return false
+}
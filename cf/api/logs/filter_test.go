@@ -0,0 +1,68 @@
+package logs_test
+
+import (
+	"regexp"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	testlogs "code.cloudfoundry.org/cli/testhelpers/logs"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter", func() {
+	var (
+		date time.Time
+		msg  logs.Loggable
+	)
+
+	BeforeEach(func() {
+		date = time.Date(2014, 4, 4, 11, 39, 20, 5, time.UTC)
+		msg = testlogs.NewLogMessage("Hello World!", "", "APP/PROC/WEB", "3", logmessage.LogMessage_OUT, date)
+	})
+
+	It("matches every message when empty", func() {
+		Expect(logs.Filter{}.Matches(msg)).To(BeTrue())
+	})
+
+	It("matches the source type and the sources nested below it", func() {
+		Expect(logs.Filter{SourceTypes: []string{"APP"}}.Matches(msg)).To(BeTrue())
+		Expect(logs.Filter{SourceTypes: []string{"rtr", "app"}}.Matches(msg)).To(BeTrue())
+		Expect(logs.Filter{SourceTypes: []string{"APP/PROC"}}.Matches(msg)).To(BeTrue())
+		Expect(logs.Filter{SourceTypes: []string{"AP"}}.Matches(msg)).To(BeFalse())
+		Expect(logs.Filter{SourceTypes: []string{"RTR"}}.Matches(msg)).To(BeFalse())
+	})
+
+	It("matches the instance", func() {
+		Expect(logs.Filter{Instance: "3"}.Matches(msg)).To(BeTrue())
+		Expect(logs.Filter{Instance: "0"}.Matches(msg)).To(BeFalse())
+	})
+
+	It("matches the time window", func() {
+		Expect(logs.Filter{Since: date.Add(-time.Minute), Until: date.Add(time.Minute)}.Matches(msg)).To(BeTrue())
+		Expect(logs.Filter{Since: date.Add(time.Minute)}.Matches(msg)).To(BeFalse())
+		Expect(logs.Filter{Until: date.Add(-time.Minute)}.Matches(msg)).To(BeFalse())
+	})
+
+	It("matches the pattern against the message", func() {
+		Expect(logs.Filter{Pattern: regexp.MustCompile("^Hello")}.Matches(msg)).To(BeTrue())
+		Expect(logs.Filter{Pattern: regexp.MustCompile("Goodbye")}.Matches(msg)).To(BeFalse())
+	})
+})
+
+var _ = Describe("NewRecord", func() {
+	It("holds the fields of the message", func() {
+		date := time.Date(2014, 4, 4, 11, 39, 20, 5, time.FixedZone("the-zone", 3*60*60))
+		msg := testlogs.NewLogMessage("Hello World!\n", "", "RTR", "1", logmessage.LogMessage_ERR, date)
+
+		Expect(logs.NewRecord(msg)).To(Equal(logs.Record{
+			Timestamp: "2014-04-04T08:39:20.000000005Z",
+			Source:    "RTR",
+			Instance:  "1",
+			Stream:    "ERR",
+			Message:   "Hello World!",
+		}))
+	})
+})
@@ -104,6 +104,17 @@ func (repo *LoggregatorLogsRepository) TailLogsFor(appGUID string, onConnect fun
 	}()
 }
 
+// TailLogsForApps only supports a single app, as the loggregator endpoint
+// streams the logs of one app per consumer.
+func (repo *LoggregatorLogsRepository) TailLogsForApps(appGUIDs []string, onConnect func(), logChan chan<- Loggable, errChan chan<- error) {
+	if len(appGUIDs) != 1 {
+		errChan <- errors.New(T("Tailing the logs of several apps at once is not supported by this version of Cloud Foundry"))
+		return
+	}
+
+	repo.TailLogsFor(appGUIDs[0], onConnect, logChan, errChan)
+}
+
 func (repo *LoggregatorLogsRepository) flushMessages(c chan<- Loggable) {
 	repo.messageQueue.EnumerateAndClear(func(m *logmessage.LogMessage) {
 		c <- NewLoggregatorLogMessage(m)
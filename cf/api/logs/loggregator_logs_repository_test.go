@@ -273,6 +273,16 @@ var _ = Describe("loggregator logs repository", func() {
 			})
 		})
 	})
+
+	Describe("TailLogsForApps", func() {
+		It("returns an error when given several apps", func() {
+			errChan := make(chan error, 1)
+			logsRepo.TailLogsForApps([]string{"app-1", "app-2"}, func() {}, make(chan Loggable), errChan)
+
+			Expect(errChan).To(Receive(MatchError(ContainSubstring("not supported"))))
+			Expect(fakeConsumer.TailCallCount()).To(Equal(0))
+		})
+	})
 })
 
 func makeLogMessage(message string, timestamp int64) *logmessage.LogMessage {
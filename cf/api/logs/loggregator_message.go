@@ -25,6 +25,25 @@ func (m *loggregatorLogMessage) GetSourceName() string {
 	return m.msg.GetSourceName()
 }
 
+func (m *loggregatorLogMessage) GetAppGUID() string {
+	return m.msg.GetAppId()
+}
+
+func (m *loggregatorLogMessage) GetSourceInstance() string {
+	return m.msg.GetSourceId()
+}
+
+func (m *loggregatorLogMessage) GetTimestamp() time.Time {
+	return time.Unix(0, m.msg.GetTimestamp())
+}
+
+func (m *loggregatorLogMessage) GetStream() string {
+	if m.msg.GetMessageType() == logmessage.LogMessage_ERR {
+		return StreamErr
+	}
+	return StreamOut
+}
+
 func (m *loggregatorLogMessage) ToLog(loc *time.Location) string {
 	logMsg := m.msg
 
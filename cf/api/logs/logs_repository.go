@@ -1,11 +1,25 @@
 package logs
 
-import "time"
+import (
+	"sort"
+	"time"
+)
+
+const (
+	StreamOut = "OUT"
+	StreamErr = "ERR"
+)
 
 type Loggable interface {
 	ToLog(loc *time.Location) string
 	ToSimpleLog() string
 	GetSourceName() string
+	GetAppGUID() string
+	GetSourceInstance() string
+	GetTimestamp() time.Time
+	// GetStream returns StreamOut or StreamErr depending on the stream the
+	// message was written to.
+	GetStream() string
 }
 
 //go:generate counterfeiter . Repository
@@ -13,9 +27,22 @@ type Loggable interface {
 type Repository interface {
 	RecentLogsFor(appGUID string) ([]Loggable, error)
 	TailLogsFor(appGUID string, onConnect func(), logChan chan<- Loggable, errChan chan<- error)
+	TailLogsForApps(appGUIDs []string, onConnect func(), logChan chan<- Loggable, errChan chan<- error)
 	Close()
 }
 
+// SortByTimestamp sorts the messages from the oldest to the newest, keeping
+// the order of the messages with the same timestamp.
+func SortByTimestamp(messages []Loggable) {
+	sort.Stable(byTimestamp(messages))
+}
+
+type byTimestamp []Loggable
+
+func (m byTimestamp) Len() int           { return len(m) }
+func (m byTimestamp) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m byTimestamp) Less(i, j int) bool { return m[i].GetTimestamp().Before(m[j].GetTimestamp()) }
+
 const defaultBufferTime time.Duration = 25 * time.Millisecond
 
 func max(a, b int) int {
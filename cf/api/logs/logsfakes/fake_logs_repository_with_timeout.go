@@ -18,6 +18,11 @@ func (fake *FakeLogsRepositoryWithTimeout) TailLogsFor(appGuid string, onConnect
 	errChan <- errors.New("Fake http timeout error")
 }
 
+func (fake *FakeLogsRepositoryWithTimeout) TailLogsForApps(appGuids []string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+	time.Sleep(150 * time.Millisecond)
+	errChan <- errors.New("Fake http timeout error")
+}
+
 func (fake *FakeLogsRepositoryWithTimeout) Close() {}
 
 func (fake *FakeLogsRepositoryWithTimeout) FlushMessages(c chan<- logs.Loggable) {}
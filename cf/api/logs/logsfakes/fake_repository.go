@@ -25,6 +25,14 @@ type FakeRepository struct {
 		logChan   chan<- logs.Loggable
 		errChan   chan<- error
 	}
+	TailLogsForAppsStub        func(appGUIDs []string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error)
+	tailLogsForAppsMutex       sync.RWMutex
+	tailLogsForAppsArgsForCall []struct {
+		appGUIDs  []string
+		onConnect func()
+		logChan   chan<- logs.Loggable
+		errChan   chan<- error
+	}
 	CloseStub        func()
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct{}
@@ -93,6 +101,38 @@ func (fake *FakeRepository) TailLogsForArgsForCall(i int) (string, func(), chan<
 	return fake.tailLogsForArgsForCall[i].appGUID, fake.tailLogsForArgsForCall[i].onConnect, fake.tailLogsForArgsForCall[i].logChan, fake.tailLogsForArgsForCall[i].errChan
 }
 
+func (fake *FakeRepository) TailLogsForApps(appGUIDs []string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+	var appGUIDsCopy []string
+	if appGUIDs != nil {
+		appGUIDsCopy = make([]string, len(appGUIDs))
+		copy(appGUIDsCopy, appGUIDs)
+	}
+	fake.tailLogsForAppsMutex.Lock()
+	fake.tailLogsForAppsArgsForCall = append(fake.tailLogsForAppsArgsForCall, struct {
+		appGUIDs  []string
+		onConnect func()
+		logChan   chan<- logs.Loggable
+		errChan   chan<- error
+	}{appGUIDsCopy, onConnect, logChan, errChan})
+	fake.recordInvocation("TailLogsForApps", []interface{}{appGUIDsCopy, onConnect, logChan, errChan})
+	fake.tailLogsForAppsMutex.Unlock()
+	if fake.TailLogsForAppsStub != nil {
+		fake.TailLogsForAppsStub(appGUIDs, onConnect, logChan, errChan)
+	}
+}
+
+func (fake *FakeRepository) TailLogsForAppsCallCount() int {
+	fake.tailLogsForAppsMutex.RLock()
+	defer fake.tailLogsForAppsMutex.RUnlock()
+	return len(fake.tailLogsForAppsArgsForCall)
+}
+
+func (fake *FakeRepository) TailLogsForAppsArgsForCall(i int) ([]string, func(), chan<- logs.Loggable, chan<- error) {
+	fake.tailLogsForAppsMutex.RLock()
+	defer fake.tailLogsForAppsMutex.RUnlock()
+	return fake.tailLogsForAppsArgsForCall[i].appGUIDs, fake.tailLogsForAppsArgsForCall[i].onConnect, fake.tailLogsForAppsArgsForCall[i].logChan, fake.tailLogsForAppsArgsForCall[i].errChan
+}
+
 func (fake *FakeRepository) Close() {
 	fake.closeMutex.Lock()
 	fake.closeArgsForCall = append(fake.closeArgsForCall, struct{}{})
@@ -116,6 +156,8 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.recentLogsForMutex.RUnlock()
 	fake.tailLogsForMutex.RLock()
 	defer fake.tailLogsForMutex.RUnlock()
+	fake.tailLogsForAppsMutex.RLock()
+	defer fake.tailLogsForAppsMutex.RUnlock()
 	fake.closeMutex.RLock()
 	defer fake.closeMutex.RUnlock()
 	return fake.invocations
@@ -2,6 +2,7 @@ package logs
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -112,6 +113,91 @@ func (repo *NoaaLogsRepository) TailLogsFor(appGUID string, onConnect func(), lo
 	}()
 }
 
+// TailLogsForApps tails the logs of all the given apps, each over a
+// connection of its own. The messages of all the apps go through the same
+// message queue, so they are sent to logChan ordered by timestamp. onConnect
+// is called once every app is connected.
+func (repo *NoaaLogsRepository) TailLogsForApps(appGUIDs []string, onConnect func(), logChan chan<- Loggable, errChan chan<- error) {
+	endpoint := repo.config.DopplerEndpoint()
+	if endpoint == "" {
+		errChan <- errors.New(T("Loggregator endpoint missing from config file"))
+		return
+	}
+
+	var (
+		connected int
+		mutex     sync.Mutex
+	)
+	repo.consumer.SetOnConnectCallback(func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		connected++
+		if connected == len(appGUIDs) {
+			onConnect()
+		}
+	})
+
+	results := make(chan error, len(appGUIDs))
+	for _, appGUID := range appGUIDs {
+		go func(appGUID string) {
+			results <- repo.queueMessagesFor(appGUID)
+		}(appGUID)
+	}
+
+	ticker := time.NewTicker(repo.BufferTime)
+	go func() {
+		defer ticker.Stop()
+
+		for remaining := len(appGUIDs); remaining > 0; {
+			select {
+			case <-ticker.C:
+				repo.flushMessages(logChan)
+			case err := <-results:
+				remaining--
+				if err != nil {
+					errChan <- err
+					close(logChan)
+					close(errChan)
+					return
+				}
+			}
+		}
+
+		repo.flushMessages(logChan)
+		close(logChan)
+		close(errChan)
+	}()
+}
+
+// queueMessagesFor pushes the messages of the app to the message queue until
+// its connection is closed or fails.
+func (repo *NoaaLogsRepository) queueMessagesFor(appGUID string) error {
+	c, e := repo.consumer.TailingLogsWithoutReconnect(appGUID, repo.config.AccessToken())
+
+	for {
+		select {
+		case msg, ok := <-c:
+			if !ok {
+				return nil
+			}
+			repo.messageQueue.PushMessage(msg)
+		case err, ok := <-e:
+			switch err.(type) {
+			case nil:
+				if !ok {
+					e = nil
+				}
+			case *noaa_errors.UnauthorizedError:
+				_, _ = repo.tokenRefresher.RefreshAuthToken()
+				c, e = repo.consumer.TailingLogsWithoutReconnect(appGUID, repo.config.AccessToken())
+			default:
+				return err
+			}
+		}
+	}
+}
+
 func (repo *NoaaLogsRepository) flushMessages(c chan<- Loggable) {
 	repo.messageQueue.EnumerateAndClear(func(m *events.LogMessage) {
 		c <- NewNoaaLogMessage(m)
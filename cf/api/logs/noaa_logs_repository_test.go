@@ -361,6 +361,106 @@ var _ = Describe("logs with noaa repository", func() {
 			})
 		})
 	})
+
+	Describe("TailLogsForApps", func() {
+		var (
+			errChan     chan error
+			logChan     chan logs.Loggable
+			appChannels map[string]chan *events.LogMessage
+			errChannels map[string]chan error
+			channelsMu  sync.Mutex
+		)
+
+		BeforeEach(func() {
+			errChan = make(chan error)
+			logChan = make(chan logs.Loggable)
+			appChannels = map[string]chan *events.LogMessage{}
+			errChannels = map[string]chan error{}
+
+			fakeNoaaConsumer.TailingLogsWithoutReconnectStub = func(appGuid string, authToken string) (<-chan *events.LogMessage, <-chan error) {
+				channelsMu.Lock()
+				defer channelsMu.Unlock()
+
+				appChannels[appGuid] = make(chan *events.LogMessage, 10)
+				errChannels[appGuid] = make(chan error, 1)
+				return appChannels[appGuid], errChannels[appGuid]
+			}
+		})
+
+		appChannel := func(appGuid string) chan *events.LogMessage {
+			channelsMu.Lock()
+			defer channelsMu.Unlock()
+			return appChannels[appGuid]
+		}
+
+		errChannel := func(appGuid string) chan error {
+			channelsMu.Lock()
+			defer channelsMu.Unlock()
+			return errChannels[appGuid]
+		}
+
+		It("merges the messages of all the apps ordered by timestamp", func() {
+			repo.BufferTime = 10 * time.Second
+			repo.TailLogsForApps([]string{"app-1", "app-2"}, func() {}, logChan, errChan)
+			Eventually(fakeNoaaConsumer.TailingLogsWithoutReconnectCallCount).Should(Equal(2))
+
+			msg1 := makeNoaaLogMessage("hello1", 100)
+			msg2 := makeNoaaLogMessage("hello2", 200)
+			msg3 := makeNoaaLogMessage("hello3", 300)
+			appChannel("app-2") <- msg3
+			appChannel("app-1") <- msg2
+			appChannel("app-2") <- msg1
+			close(appChannel("app-1"))
+			close(appChannel("app-2"))
+
+			Eventually(logChan).Should(Receive(Equal(logs.NewNoaaLogMessage(msg1))))
+			Eventually(logChan).Should(Receive(Equal(logs.NewNoaaLogMessage(msg2))))
+			Eventually(logChan).Should(Receive(Equal(logs.NewNoaaLogMessage(msg3))))
+			Eventually(logChan).Should(BeClosed())
+			Eventually(errChan).Should(BeClosed())
+		})
+
+		It("calls onConnect once every app is connected", func() {
+			connected := make(chan bool, 2)
+			repo.TailLogsForApps([]string{"app-1", "app-2"}, func() { connected <- true }, logChan, errChan)
+
+			Expect(fakeNoaaConsumer.SetOnConnectCallbackCallCount()).To(Equal(1))
+			callback := fakeNoaaConsumer.SetOnConnectCallbackArgsForCall(0)
+
+			callback()
+			Consistently(connected).ShouldNot(Receive())
+			callback()
+			Eventually(connected).Should(Receive())
+
+			Eventually(fakeNoaaConsumer.TailingLogsWithoutReconnectCallCount).Should(Equal(2))
+			close(appChannel("app-1"))
+			close(appChannel("app-2"))
+			Eventually(logChan).Should(BeClosed())
+		})
+
+		It("reports the error of any app", func() {
+			repo.TailLogsForApps([]string{"app-1", "app-2"}, func() {}, logChan, errChan)
+			Eventually(fakeNoaaConsumer.TailingLogsWithoutReconnectCallCount).Should(Equal(2))
+
+			errChannel("app-2") <- errors.New("oops")
+
+			Eventually(errChan).Should(Receive(MatchError("oops")))
+			Eventually(logChan).Should(BeClosed())
+		})
+
+		It("refreshes the access token and reconnects the app when it is unauthorized", func() {
+			repo.TailLogsForApps([]string{"app-1"}, func() {}, logChan, errChan)
+			Eventually(fakeNoaaConsumer.TailingLogsWithoutReconnectCallCount).Should(Equal(1))
+
+			errChannel("app-1") <- noaa_errors.NewUnauthorizedError("i'm sorry dave")
+
+			Eventually(fakeNoaaConsumer.TailingLogsWithoutReconnectCallCount).Should(Equal(2))
+			Expect(fakeTokenRefresher.RefreshAuthTokenCallCount()).To(Equal(1))
+
+			close(appChannel("app-1"))
+			Eventually(logChan).Should(BeClosed())
+		})
+	})
 })
 
 func makeNoaaLogMessage(message string, timestamp int64) *events.LogMessage {
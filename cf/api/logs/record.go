@@ -0,0 +1,28 @@
+package logs
+
+import "time"
+
+// Record is the structured form of a log message, used when the messages
+// are printed as JSON or YAML.
+type Record struct {
+	// App is the name of the app, set when the logs of several apps are
+	// printed together.
+	App       string `json:"app,omitempty" yaml:"app,omitempty"`
+	Timestamp string `json:"timestamp" yaml:"timestamp"`
+	Source    string `json:"source" yaml:"source"`
+	Instance  string `json:"instance" yaml:"instance"`
+	Stream    string `json:"stream" yaml:"stream"`
+	Message   string `json:"message" yaml:"message"`
+}
+
+// NewRecord converts msg into a Record. The timestamp is formatted as an
+// RFC 3339 time in UTC.
+func NewRecord(msg Loggable) Record {
+	return Record{
+		Timestamp: msg.GetTimestamp().UTC().Format(time.RFC3339Nano),
+		Source:    msg.GetSourceName(),
+		Instance:  msg.GetSourceInstance(),
+		Stream:    msg.GetStream(),
+		Message:   msg.ToSimpleLog(),
+	}
+}
@@ -46,7 +46,7 @@ type RepositoryLocator struct {
 	quotaRepo                       quotas.QuotaRepository
 	spaceRepo                       spaces.SpaceRepository
 	appRepo                         applications.Repository
-	appBitsRepo                     applicationbits.CloudControllerApplicationBitsRepository
+	appBitsRepo                     applicationbits.Repository
 	appSummaryRepo                  AppSummaryRepository
 	appInstancesRepo                appinstances.Repository
 	appEventsRepo                   appevents.Repository
@@ -105,19 +105,7 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	loc.domainRepo = NewCloudControllerDomainRepository(config, cloudControllerGateway, strategy)
 	loc.endpointRepo = NewEndpointRepository(cloudControllerGateway)
 
-	tlsConfig := net.NewTLSConfig([]tls.Certificate{}, config.IsSSLDisabled())
-
-	apiVersion, _ := semver.Make(config.APIVersion())
-
-	if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
-		consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, http.ProxyFromEnvironment)
-		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewNoaaLogsRepository(config, consumer, loc.authRepo)
-	} else {
-		consumer := loggregator_consumer.New(config.LoggregatorEndpoint(), tlsConfig, http.ProxyFromEnvironment)
-		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewLoggregatorLogsRepository(config, consumer, loc.authRepo)
-	}
+	loc.logsRepo = NewLogsRepository(config, loc.authRepo, logger)
 
 	loc.organizationRepo = organizations.NewCloudControllerOrganizationRepository(config, cloudControllerGateway)
 	loc.passwordRepo = password.NewCloudControllerRepository(config, uaaGateway)
@@ -153,6 +141,24 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	return
 }
 
+// NewLogsRepository returns a logs repository with a consumer of its own,
+// for the Doppler or Loggregator endpoint depending on the API version.
+func NewLogsRepository(config coreconfig.Reader, tokenRefresher authentication.TokenRefresher, logger trace.Printer) logs.Repository {
+	tlsConfig := net.NewTLSConfig([]tls.Certificate{}, config.IsSSLDisabled())
+
+	apiVersion, _ := semver.Make(config.APIVersion())
+
+	if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
+		consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, http.ProxyFromEnvironment)
+		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
+		return logs.NewNoaaLogsRepository(config, consumer, tokenRefresher)
+	}
+
+	consumer := loggregator_consumer.New(config.LoggregatorEndpoint(), tlsConfig, http.ProxyFromEnvironment)
+	consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
+	return logs.NewLoggregatorLogsRepository(config, consumer, tokenRefresher)
+}
+
 func (locator RepositoryLocator) SetAuthenticationRepository(repo authentication.Repository) RepositoryLocator {
 	locator.authRepo = repo
 	return locator
@@ -216,6 +222,11 @@ func (locator RepositoryLocator) GetApplicationRepository() applications.Reposit
 	return locator.appRepo
 }
 
+func (locator RepositoryLocator) SetApplicationBitsRepository(repo applicationbits.Repository) RepositoryLocator {
+	locator.appBitsRepo = repo
+	return locator
+}
+
 func (locator RepositoryLocator) GetApplicationBitsRepository() applicationbits.Repository {
 	return locator.appBitsRepo
 }
@@ -113,16 +113,16 @@ var _ = Describe("RunningSecurityGroupsRepo", func() {
 					Name:     "name-71",
 					GUID:     "cd186158-b356-474d-9861-724f34f48502",
 					SpaceURL: "/v2/security_groups/d3374b62-7eac-4823-afbd-460d2bf44c67/spaces",
-					Rules: []map[string]interface{}{{
-						"protocol": "udp",
+					Rules: []models.SecurityGroupRule{{
+						Protocol: "udp",
 					}},
 				},
 				{
 					Name:     "name-72",
 					GUID:     "d3374b62-7eac-4823-afbd-460d2bf44c67",
 					SpaceURL: "/v2/security_groups/d3374b62-7eac-4823-afbd-460d2bf44c67/spaces",
-					Rules: []map[string]interface{}{{
-						"destination": "198.41.191.47/1",
+					Rules: []models.SecurityGroupRule{{
+						Destination: "198.41.191.47/1",
 					}},
 				},
 			}))
@@ -14,8 +14,8 @@ import (
 //go:generate counterfeiter . SecurityGroupRepo
 
 type SecurityGroupRepo interface {
-	Create(name string, rules []map[string]interface{}) error
-	Update(guid string, rules []map[string]interface{}) error
+	Create(name string, rules []models.SecurityGroupRule) error
+	Update(guid string, rules []models.SecurityGroupRule) error
 	Read(string) (models.SecurityGroup, error)
 	Delete(string) error
 	FindAll() ([]models.SecurityGroup, error)
@@ -33,7 +33,7 @@ func NewSecurityGroupRepo(config coreconfig.Reader, gateway net.Gateway) Securit
 	}
 }
 
-func (repo cloudControllerSecurityGroupRepo) Create(name string, rules []map[string]interface{}) error {
+func (repo cloudControllerSecurityGroupRepo) Create(name string, rules []models.SecurityGroupRule) error {
 	path := "/v2/security_groups"
 	params := models.SecurityGroupParams{
 		Name:  name,
@@ -84,7 +84,7 @@ func (repo cloudControllerSecurityGroupRepo) Read(name string) (models.SecurityG
 	return group, err
 }
 
-func (repo cloudControllerSecurityGroupRepo) Update(guid string, rules []map[string]interface{}) error {
+func (repo cloudControllerSecurityGroupRepo) Update(guid string, rules []models.SecurityGroupRule) error {
 	url := fmt.Sprintf("/v2/security_groups/%s", guid)
 	return repo.gateway.UpdateResourceFromStruct(repo.config.APIEndpoint(), url, models.SecurityGroupParams{Rules: rules})
 }
@@ -49,10 +49,9 @@ var _ = Describe("app security group api", func() {
 			req := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
 				Method: "POST",
 				Path:   "/v2/security_groups",
-				// FIXME: this matcher depend on the order of the key/value pairs in the map
 				Matcher: testnet.RequestBodyMatcher(`{
 					"name": "mygroup",
-					"rules": [{"my-house": "my-rules"}]
+					"rules": [{"protocol": "tcp", "destination": "10.0.0.1", "ports": "443"}]
 				}`),
 				Response: testnet.TestResponse{Status: http.StatusCreated},
 			})
@@ -60,7 +59,7 @@ var _ = Describe("app security group api", func() {
 
 			err := repo.Create(
 				"mygroup",
-				[]map[string]interface{}{{"my-house": "my-rules"}},
+				[]models.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443"}},
 			)
 
 			Expect(err).NotTo(HaveOccurred())
@@ -85,7 +84,7 @@ var _ = Describe("app security group api", func() {
          },
          "entity": {
             "name": "the-name",
-            "rules": [{"key": "value"}],
+            "rules": [{"protocol": "icmp", "destination": "10.0.0.1", "type": 0, "code": -1}],
 						"spaces_url": "/v2/security_groups/guid-id/spaces"
          }
       }
@@ -144,6 +143,7 @@ var _ = Describe("app security group api", func() {
 			setupTestServer(apifakes.NewCloudControllerTestRequest(req1), apifakes.NewCloudControllerTestRequest(req2))
 
 			group, err := repo.Read("the-name")
+			icmpType, icmpCode := 0, -1
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(testHandler).To(HaveAllRequestsCalled())
@@ -152,7 +152,7 @@ var _ = Describe("app security group api", func() {
 					Name:     "the-name",
 					GUID:     "the-group-guid",
 					SpaceURL: "/v2/security_groups/guid-id/spaces",
-					Rules:    []map[string]interface{}{{"key": "value"}},
+					Rules:    []models.SecurityGroupRule{{Protocol: "icmp", Destination: "10.0.0.1", Type: &icmpType, Code: &icmpCode}},
 				},
 				Spaces: []models.Space{
 					{
@@ -246,7 +246,7 @@ var _ = Describe("app security group api", func() {
 				SecurityGroupFields: models.SecurityGroupFields{
 					Name:     "name-71",
 					GUID:     "cd186158-b356-474d-9861-724f34f48502",
-					Rules:    []map[string]interface{}{{"protocol": "udp"}},
+					Rules:    []models.SecurityGroupRule{{Protocol: "udp"}},
 					SpaceURL: "/v2/security_groups/cd186158-b356-474d-9861-724f34f48502/spaces",
 				},
 				Spaces: []models.Space{
@@ -260,7 +260,7 @@ var _ = Describe("app security group api", func() {
 				SecurityGroupFields: models.SecurityGroupFields{
 					Name:     "name-72",
 					GUID:     "d3374b62-7eac-4823-afbd-460d2bf44c67",
-					Rules:    []map[string]interface{}{{"destination": "198.41.191.47/1"}},
+					Rules:    []models.SecurityGroupRule{{Destination: "198.41.191.47/1"}},
 					SpaceURL: "/v2/security_groups/d3374b62-7eac-4823-afbd-460d2bf44c67/spaces",
 				},
 				Spaces: []models.Space{
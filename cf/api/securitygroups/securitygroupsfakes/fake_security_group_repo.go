@@ -9,20 +9,20 @@ import (
 )
 
 type FakeSecurityGroupRepo struct {
-	CreateStub        func(name string, rules []map[string]interface{}) error
+	CreateStub        func(name string, rules []models.SecurityGroupRule) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
 		name  string
-		rules []map[string]interface{}
+		rules []models.SecurityGroupRule
 	}
 	createReturns struct {
 		result1 error
 	}
-	UpdateStub        func(guid string, rules []map[string]interface{}) error
+	UpdateStub        func(guid string, rules []models.SecurityGroupRule) error
 	updateMutex       sync.RWMutex
 	updateArgsForCall []struct {
 		guid  string
-		rules []map[string]interface{}
+		rules []models.SecurityGroupRule
 	}
 	updateReturns struct {
 		result1 error
@@ -55,16 +55,16 @@ type FakeSecurityGroupRepo struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeSecurityGroupRepo) Create(name string, rules []map[string]interface{}) error {
-	var rulesCopy []map[string]interface{}
+func (fake *FakeSecurityGroupRepo) Create(name string, rules []models.SecurityGroupRule) error {
+	var rulesCopy []models.SecurityGroupRule
 	if rules != nil {
-		rulesCopy = make([]map[string]interface{}, len(rules))
+		rulesCopy = make([]models.SecurityGroupRule, len(rules))
 		copy(rulesCopy, rules)
 	}
 	fake.createMutex.Lock()
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
 		name  string
-		rules []map[string]interface{}
+		rules []models.SecurityGroupRule
 	}{name, rulesCopy})
 	fake.recordInvocation("Create", []interface{}{name, rulesCopy})
 	fake.createMutex.Unlock()
@@ -81,7 +81,7 @@ func (fake *FakeSecurityGroupRepo) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeSecurityGroupRepo) CreateArgsForCall(i int) (string, []map[string]interface{}) {
+func (fake *FakeSecurityGroupRepo) CreateArgsForCall(i int) (string, []models.SecurityGroupRule) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
 	return fake.createArgsForCall[i].name, fake.createArgsForCall[i].rules
@@ -94,16 +94,16 @@ func (fake *FakeSecurityGroupRepo) CreateReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeSecurityGroupRepo) Update(guid string, rules []map[string]interface{}) error {
-	var rulesCopy []map[string]interface{}
+func (fake *FakeSecurityGroupRepo) Update(guid string, rules []models.SecurityGroupRule) error {
+	var rulesCopy []models.SecurityGroupRule
 	if rules != nil {
-		rulesCopy = make([]map[string]interface{}, len(rules))
+		rulesCopy = make([]models.SecurityGroupRule, len(rules))
 		copy(rulesCopy, rules)
 	}
 	fake.updateMutex.Lock()
 	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
 		guid  string
-		rules []map[string]interface{}
+		rules []models.SecurityGroupRule
 	}{guid, rulesCopy})
 	fake.recordInvocation("Update", []interface{}{guid, rulesCopy})
 	fake.updateMutex.Unlock()
@@ -120,7 +120,7 @@ func (fake *FakeSecurityGroupRepo) UpdateCallCount() int {
 	return len(fake.updateArgsForCall)
 }
 
-func (fake *FakeSecurityGroupRepo) UpdateArgsForCall(i int) (string, []map[string]interface{}) {
+func (fake *FakeSecurityGroupRepo) UpdateArgsForCall(i int) (string, []models.SecurityGroupRule) {
 	fake.updateMutex.RLock()
 	defer fake.updateMutex.RUnlock()
 	return fake.updateArgsForCall[i].guid, fake.updateArgsForCall[i].rules
@@ -27,6 +27,7 @@ type ServiceRepository interface {
 	GetAllServiceOfferings() (offerings models.ServiceOfferings, apiErr error)
 	GetServiceOfferingsForSpace(spaceGUID string) (offerings models.ServiceOfferings, apiErr error)
 	FindInstanceByName(name string) (instance models.ServiceInstance, apiErr error)
+	GetServiceInstanceParameters(instanceGUID string) (params map[string]interface{}, apiErr error)
 	PurgeServiceInstance(instance models.ServiceInstance) error
 	CreateServiceInstance(name, planGUID string, params map[string]interface{}, tags []string) (apiErr error)
 	UpdateServiceInstance(instanceGUID, planGUID string, params map[string]interface{}, tags []string) (apiErr error)
@@ -138,6 +139,18 @@ func (repo CloudControllerServiceRepository) FindInstanceByName(name string) (in
 	return
 }
 
+func (repo CloudControllerServiceRepository) GetServiceInstanceParameters(instanceGUID string) (map[string]interface{}, error) {
+	path := fmt.Sprintf("%s/v2/service_instances/%s/parameters", repo.config.APIEndpoint(), instanceGUID)
+
+	params := map[string]interface{}{}
+	err := repo.gateway.GetResource(path, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
 func (repo CloudControllerServiceRepository) CreateServiceInstance(name, planGUID string, params map[string]interface{}, tags []string) (err error) {
 	path := "/v2/service_instances?accepts_incomplete=true"
 	request := models.ServiceInstanceCreateRequest{
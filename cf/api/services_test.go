@@ -564,6 +564,33 @@ var _ = Describe("Services Repo", func() {
 		})
 	})
 
+	Describe("GetServiceInstanceParameters", func() {
+		It("returns the parameters of the instance", func() {
+			setupTestServer(apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:   "GET",
+				Path:     "/v2/service_instances/instance-guid/parameters",
+				Response: testnet.TestResponse{Status: http.StatusOK, Body: `{"size": "large", "replicas": 3}`},
+			}))
+
+			params, err := repo.GetServiceInstanceParameters("instance-guid")
+			Expect(testHandler).To(HaveAllRequestsCalled())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(map[string]interface{}{"size": "large", "replicas": float64(3)}))
+		})
+
+		It("returns an error when the broker does not support fetching parameters", func() {
+			setupTestServer(apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:   "GET",
+				Path:     "/v2/service_instances/instance-guid/parameters",
+				Response: testnet.TestResponse{Status: http.StatusBadGateway, Body: `{"code": 10001, "description": "This service does not support fetching service instance parameters."}`},
+			}))
+
+			_, err := repo.GetServiceInstanceParameters("instance-guid")
+			Expect(testHandler).To(HaveAllRequestsCalled())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("DeleteService", func() {
 		It("deletes the service when no apps and keys are bound", func() {
 			setupTestServer(apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
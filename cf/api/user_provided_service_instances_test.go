@@ -182,6 +182,44 @@ var _ = Describe("UserProvidedServiceRepository", func() {
 		})
 	})
 
+	Context("Get()", func() {
+		It("returns the user provided service with the guid", func() {
+			req := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "GET",
+				Path:   "/v2/user_provided_service_instances/my-ups-guid",
+				Response: testnet.TestResponse{Status: http.StatusOK, Body: `
+{
+   "metadata": {
+      "guid": "my-ups-guid"
+   },
+   "entity": {
+      "name": "my-ups",
+      "credentials": {
+         "username": "admin"
+      },
+      "space_guid": "my-space-guid",
+      "syslog_drain_url": "syslog://example.com",
+      "route_service_url": "https://route.example.com"
+   }
+}`},
+			})
+
+			ts, handler, repo := createUserProvidedServiceInstanceRepo([]testnet.TestRequest{req})
+			defer ts.Close()
+
+			service, apiErr := repo.Get("my-ups-guid")
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(handler).To(HaveAllRequestsCalled())
+			Expect(service).To(Equal(models.UserProvidedService{
+				Name:            "my-ups",
+				Credentials:     map[string]interface{}{"username": "admin"},
+				SpaceGUID:       "my-space-guid",
+				SysLogDrainURL:  "syslog://example.com",
+				RouteServiceURL: "https://route.example.com",
+			}))
+		})
+	})
+
 })
 
 func createUserProvidedServiceInstanceRepo(req []testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo UserProvidedServiceInstanceRepository) {
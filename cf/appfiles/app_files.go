@@ -4,10 +4,10 @@ import (
 	"crypto/sha1"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/gofileutils/fileutils"
@@ -18,15 +18,20 @@ const windowsPathPrefix = `\\?\`
 //go:generate counterfeiter . AppFiles
 
 type AppFiles interface {
-	AppFilesInDir(dir string) (appFiles []models.AppFileFields, err error)
+	AppFilesInDir(dir string, options IgnoreOptions) (appFiles []models.AppFileFields, err error)
+	IgnoredFilesInDir(dir string, options IgnoreOptions) (ignoredFiles []string, err error)
 	CopyFiles(appFiles []models.AppFileFields, fromDir, toDir string) (err error)
 	CountFiles(directory string) int64
 	WalkAppFiles(dir string, onEachFile func(string, string) error) (err error)
 }
 
-type ApplicationFiles struct{}
+// ApplicationFiles finds the files of an app on disk. When Cache is set, the
+// SHA1s of unchanged files are taken from it instead of being recomputed.
+type ApplicationFiles struct {
+	Cache *ResourceCache
+}
 
-func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFields, error) {
+func (appfiles ApplicationFiles) AppFilesInDir(dir string, options IgnoreOptions) ([]models.AppFileFields, error) {
 	appFiles := []models.AppFileFields{}
 
 	fullDirPath, toplevelErr := filepath.Abs(dir)
@@ -34,7 +39,9 @@ func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFiel
 		return appFiles, toplevelErr
 	}
 
-	toplevelErr = appfiles.WalkAppFiles(fullDirPath, func(fileName string, fullPath string) error {
+	var fullPaths []string
+	var fileInfos []os.FileInfo
+	toplevelErr = appfiles.walkAppFiles(fullDirPath, options, func(fileName string, fullPath string) error {
 		fileInfo, err := os.Lstat(fullPath)
 		if err != nil {
 			return err
@@ -48,20 +55,64 @@ func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFiel
 		if fileInfo.IsDir() {
 			appFile.Sha1 = "0"
 			appFile.Size = 0
-		} else {
-			sha, err := appfiles.shaFile(fullPath)
-			if err != nil {
-				return err
-			}
+		} else if sha, found := appfiles.Cache.FileSha1(fullPath, fileInfo); found {
 			appFile.Sha1 = sha
 		}
 
 		appFiles = append(appFiles, appFile)
+		fullPaths = append(fullPaths, fullPath)
+		fileInfos = append(fileInfos, fileInfo)
 
 		return nil
-	})
+	}, nil)
+	if toplevelErr != nil {
+		return appFiles, toplevelErr
+	}
+
+	toplevelErr = appfiles.shaFiles(appFiles, fullPaths, fileInfos)
+	if toplevelErr != nil {
+		return appFiles, toplevelErr
+	}
+
+	// the cache only saves work, failing to write it must not fail the push
+	_ = appfiles.Cache.Save()
+
+	return appFiles, nil
+}
+
+// shaFiles computes the SHA1 of every file that does not have one yet,
+// hashing several files at once.
+func (appfiles ApplicationFiles) shaFiles(appFiles []models.AppFileFields, fullPaths []string, fileInfos []os.FileInfo) error {
+	indexes := make(chan int)
+	errs := make(chan error, len(appFiles))
+
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				sha, err := appfiles.shaFile(fullPaths[index])
+				if err != nil {
+					errs <- err
+					continue
+				}
+				appFiles[index].Sha1 = sha
+				appfiles.Cache.SetFileSha1(fullPaths[index], fileInfos[index], sha)
+			}
+		}()
+	}
+
+	for index, appFile := range appFiles {
+		if appFile.Sha1 == "" {
+			indexes <- index
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	close(errs)
 
-	return appFiles, toplevelErr
+	return <-errs
 }
 
 func (appfiles ApplicationFiles) shaFile(fullPath string) (string, error) {
@@ -162,8 +213,35 @@ func (appfiles ApplicationFiles) CountFiles(directory string) int64 {
 	return count
 }
 
+// IgnoredFilesInDir lists the files and directories of dir that are left
+// out by its ignore files. Directories end in a slash, their contents are
+// not listed.
+func (appfiles ApplicationFiles) IgnoredFilesInDir(dir string, options IgnoreOptions) ([]string, error) {
+	ignoredFiles := []string{}
+
+	fullDirPath, err := filepath.Abs(dir)
+	if err != nil {
+		return ignoredFiles, err
+	}
+
+	err = appfiles.walkAppFiles(fullDirPath, options, func(_, _ string) error {
+		return nil
+	}, func(fileRelativeUnixPath string) {
+		ignoredFiles = append(ignoredFiles, fileRelativeUnixPath)
+	})
+
+	return ignoredFiles, err
+}
+
 func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string, string) error) error {
-	cfIgnore := loadIgnoreFile(dir)
+	return appfiles.walkAppFiles(dir, IgnoreOptions{}, onEachFile, nil)
+}
+
+func (appfiles ApplicationFiles) walkAppFiles(dir string, options IgnoreOptions, onEachFile func(string, string) error, onIgnoredFile func(string)) error {
+	cfIgnore := &cfIgnore{}
+	cfIgnore.addRules("", defaultIgnoreLines)
+	cfIgnore.loadDir(dir, "", options)
+
 	walkFunc := func(fullPath string, f os.FileInfo, err error) error {
 		fileRelativePath, _ := filepath.Rel(dir, fullPath)
 		fileRelativeUnixPath := filepath.ToSlash(fileRelativePath)
@@ -180,8 +258,16 @@ func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string
 			return nil
 		}
 
-		if cfIgnore.FileShouldBeIgnored(fileRelativeUnixPath) {
-			if err == nil && f.IsDir() {
+		isDir := err == nil && f.IsDir()
+		if cfIgnore.matches(fileRelativeUnixPath, isDir) {
+			if isDir {
+				fileRelativeUnixPath += "/"
+			}
+			if onIgnoredFile != nil {
+				onIgnoredFile(fileRelativeUnixPath)
+			}
+
+			if isDir {
 				return filepath.SkipDir
 			}
 			return nil
@@ -195,17 +281,12 @@ func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string
 			return nil
 		}
 
+		if isDir {
+			cfIgnore.loadDir(dir, fileRelativeUnixPath, options)
+		}
+
 		return onEachFile(fileRelativePath, fullPath)
 	}
 
 	return filepath.Walk(dir, walkFunc)
 }
-
-func loadIgnoreFile(dir string) CfIgnore {
-	fileContents, err := ioutil.ReadFile(filepath.Join(dir, ".cfignore"))
-	if err != nil {
-		return NewCfIgnore("")
-	}
-
-	return NewCfIgnore(string(fileContents))
-}
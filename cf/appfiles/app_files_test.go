@@ -1,11 +1,14 @@
 package appfiles_test
 
 import (
+	"crypto/sha1"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/appfiles"
 	"github.com/nu7hatch/gouuid"
@@ -46,7 +49,7 @@ var _ = Describe("AppFiles", func() {
 
 	Describe("AppFilesInDir", func() {
 		It("all files have '/' path separators", func() {
-			files, err := appFiles.AppFilesInDir(fixturePath)
+			files, err := appFiles.AppFilesInDir(fixturePath, appfiles.IgnoreOptions{})
 			Expect(err).NotTo(HaveOccurred())
 
 			for _, afile := range files {
@@ -59,7 +62,7 @@ var _ = Describe("AppFiles", func() {
 
 			BeforeEach(func() {
 				appPath := filepath.Join(fixturePath, "app-with-cfignore")
-				files, err := appFiles.AppFilesInDir(appPath)
+				files, err := appFiles.AppFilesInDir(appPath, appfiles.IgnoreOptions{})
 				Expect(err).NotTo(HaveOccurred())
 
 				paths = []string{}
@@ -75,10 +78,6 @@ var _ = Describe("AppFiles", func() {
 					"dir1/child-dir/file3.txt",
 					"dir1/file1.txt",
 					"dir2",
-
-					// TODO: this should be excluded.
-					// .cfignore doesn't handle ** patterns right now
-					"dir2/child-dir2",
 				}))
 			})
 		})
@@ -93,7 +92,7 @@ var _ = Describe("AppFiles", func() {
 				err = os.Mkdir(filepath.Join(tempdir, "nothing"), 0600)
 				Expect(err).ToNot(HaveOccurred())
 
-				files, err := appFiles.AppFilesInDir(tempdir)
+				files, err := appFiles.AppFilesInDir(tempdir, appfiles.IgnoreOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
 				sizes := []int64{}
@@ -104,6 +103,145 @@ var _ = Describe("AppFiles", func() {
 				Expect(sizes).To(Equal([]int64{0}))
 			})
 		})
+
+		It("computes the sha1 of every file in walk order", func() {
+			fileutils.TempDir("something", func(tempdir string, err error) {
+				Expect(err).ToNot(HaveOccurred())
+
+				err = os.Mkdir(filepath.Join(tempdir, "dir"), 0700)
+				Expect(err).ToNot(HaveOccurred())
+				for i := 0; i < 20; i++ {
+					err = ioutil.WriteFile(filepath.Join(tempdir, "dir", fmt.Sprintf("file-%02d", i)), []byte(fmt.Sprintf("contents %d", i)), 0600)
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				files, err := appFiles.AppFilesInDir(tempdir, appfiles.IgnoreOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(files).To(HaveLen(21))
+
+				Expect(files[0].Path).To(Equal("dir"))
+				Expect(files[0].Sha1).To(Equal("0"))
+				for i, file := range files[1:] {
+					Expect(file.Path).To(Equal(fmt.Sprintf("dir/file-%02d", i)))
+					Expect(file.Sha1).To(Equal(fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("contents %d", i))))))
+				}
+			})
+		})
+
+		Context("when subdirectories have ignore files", func() {
+			var appDir string
+
+			BeforeEach(func() {
+				var err error
+				appDir, err = ioutil.TempDir("", "nested-ignore")
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, file := range []string{"app.rb", "debug.log", "lib/util.rb", "lib/util.log", "lib/keep.log", "lib/tmp/cache.bin", "spec/fixtures/secret.pem"} {
+					fullPath := filepath.Join(appDir, filepath.FromSlash(file))
+					Expect(os.MkdirAll(filepath.Dir(fullPath), 0700)).To(Succeed())
+					Expect(ioutil.WriteFile(fullPath, []byte(file), 0600)).To(Succeed())
+				}
+
+				Expect(ioutil.WriteFile(filepath.Join(appDir, ".cfignore"), []byte("*.log\n"), 0600)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(appDir, "lib", ".cfignore"), []byte("!keep.log\ntmp/\n"), 0600)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(appDir, ".gitignore"), []byte("spec/\n"), 0600)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(appDir)
+			})
+
+			paths := func(files []models.AppFileFields) []string {
+				paths := []string{}
+				for _, file := range files {
+					paths = append(paths, file.Path)
+				}
+				return paths
+			}
+
+			It("applies the rules of each .cfignore to its own directory", func() {
+				files, err := appFiles.AppFilesInDir(appDir, appfiles.IgnoreOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(paths(files)).To(Equal([]string{
+					"app.rb",
+					"lib",
+					"lib/keep.log",
+					"lib/util.rb",
+					"spec",
+					"spec/fixtures",
+					"spec/fixtures/secret.pem",
+				}))
+			})
+
+			It("also applies .gitignore files when asked to", func() {
+				files, err := appFiles.AppFilesInDir(appDir, appfiles.IgnoreOptions{GitIgnore: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(paths(files)).To(Equal([]string{
+					"app.rb",
+					"lib",
+					"lib/keep.log",
+					"lib/util.rb",
+				}))
+			})
+
+			It("lists the ignored files", func() {
+				ignoredFiles, err := appFiles.IgnoredFilesInDir(appDir, appfiles.IgnoreOptions{GitIgnore: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ignoredFiles).To(Equal([]string{
+					".cfignore",
+					".gitignore",
+					"debug.log",
+					"lib/.cfignore",
+					"lib/tmp/",
+					"lib/util.log",
+					"spec/",
+				}))
+			})
+		})
+
+		Context("when a resource cache is provided", func() {
+			It("reuses the cached sha1 of unchanged files", func() {
+				fileutils.TempDir("something", func(tempdir string, err error) {
+					Expect(err).ToNot(HaveOccurred())
+
+					filePath := filepath.Join(tempdir, "app.rb")
+					err = ioutil.WriteFile(filePath, []byte("puts 'hello'"), 0600)
+					Expect(err).ToNot(HaveOccurred())
+					yesterday := time.Now().Add(-24 * time.Hour)
+					Expect(os.Chtimes(filePath, yesterday, yesterday)).To(Succeed())
+
+					fileInfo, err := os.Stat(filePath)
+					Expect(err).ToNot(HaveOccurred())
+
+					cache := appfiles.NewResourceCache(filepath.Join(tempdir, "cache", "resource_cache.json"))
+					cache.SetFileSha1(filePath, fileInfo, "cached-sha")
+
+					appFiles.Cache = cache
+					files, err := appFiles.AppFilesInDir(tempdir, appfiles.IgnoreOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					var appFile models.AppFileFields
+					for _, file := range files {
+						if file.Path == "app.rb" {
+							appFile = file
+						}
+					}
+					Expect(appFile.Sha1).To(Equal("cached-sha"))
+
+					err = ioutil.WriteFile(filePath, []byte("puts 'goodbye'"), 0600)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(os.Chtimes(filePath, yesterday, yesterday)).To(Succeed())
+
+					files, err = appFiles.AppFilesInDir(tempdir, appfiles.IgnoreOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					for _, file := range files {
+						if file.Path == "app.rb" {
+							Expect(file.Sha1).To(Equal(fmt.Sprintf("%x", sha1.Sum([]byte("puts 'goodbye'")))))
+						}
+					}
+				})
+			})
+		})
 	})
 
 	Describe("CopyFiles", func() {
@@ -9,15 +9,26 @@ import (
 )
 
 type FakeAppFiles struct {
-	AppFilesInDirStub        func(dir string) (appFiles []models.AppFileFields, err error)
+	AppFilesInDirStub        func(dir string, options appfiles.IgnoreOptions) (appFiles []models.AppFileFields, err error)
 	appFilesInDirMutex       sync.RWMutex
 	appFilesInDirArgsForCall []struct {
-		dir string
+		dir     string
+		options appfiles.IgnoreOptions
 	}
 	appFilesInDirReturns struct {
 		result1 []models.AppFileFields
 		result2 error
 	}
+	IgnoredFilesInDirStub        func(dir string, options appfiles.IgnoreOptions) (ignoredFiles []string, err error)
+	ignoredFilesInDirMutex       sync.RWMutex
+	ignoredFilesInDirArgsForCall []struct {
+		dir     string
+		options appfiles.IgnoreOptions
+	}
+	ignoredFilesInDirReturns struct {
+		result1 []string
+		result2 error
+	}
 	CopyFilesStub        func(appFiles []models.AppFileFields, fromDir, toDir string) (err error)
 	copyFilesMutex       sync.RWMutex
 	copyFilesArgsForCall []struct {
@@ -49,15 +60,16 @@ type FakeAppFiles struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeAppFiles) AppFilesInDir(dir string) (appFiles []models.AppFileFields, err error) {
+func (fake *FakeAppFiles) AppFilesInDir(dir string, options appfiles.IgnoreOptions) (appFiles []models.AppFileFields, err error) {
 	fake.appFilesInDirMutex.Lock()
 	fake.appFilesInDirArgsForCall = append(fake.appFilesInDirArgsForCall, struct {
-		dir string
-	}{dir})
-	fake.recordInvocation("AppFilesInDir", []interface{}{dir})
+		dir     string
+		options appfiles.IgnoreOptions
+	}{dir, options})
+	fake.recordInvocation("AppFilesInDir", []interface{}{dir, options})
 	fake.appFilesInDirMutex.Unlock()
 	if fake.AppFilesInDirStub != nil {
-		return fake.AppFilesInDirStub(dir)
+		return fake.AppFilesInDirStub(dir, options)
 	} else {
 		return fake.appFilesInDirReturns.result1, fake.appFilesInDirReturns.result2
 	}
@@ -69,10 +81,10 @@ func (fake *FakeAppFiles) AppFilesInDirCallCount() int {
 	return len(fake.appFilesInDirArgsForCall)
 }
 
-func (fake *FakeAppFiles) AppFilesInDirArgsForCall(i int) string {
+func (fake *FakeAppFiles) AppFilesInDirArgsForCall(i int) (string, appfiles.IgnoreOptions) {
 	fake.appFilesInDirMutex.RLock()
 	defer fake.appFilesInDirMutex.RUnlock()
-	return fake.appFilesInDirArgsForCall[i].dir
+	return fake.appFilesInDirArgsForCall[i].dir, fake.appFilesInDirArgsForCall[i].options
 }
 
 func (fake *FakeAppFiles) AppFilesInDirReturns(result1 []models.AppFileFields, result2 error) {
@@ -83,6 +95,41 @@ func (fake *FakeAppFiles) AppFilesInDirReturns(result1 []models.AppFileFields, r
 	}{result1, result2}
 }
 
+func (fake *FakeAppFiles) IgnoredFilesInDir(dir string, options appfiles.IgnoreOptions) (ignoredFiles []string, err error) {
+	fake.ignoredFilesInDirMutex.Lock()
+	fake.ignoredFilesInDirArgsForCall = append(fake.ignoredFilesInDirArgsForCall, struct {
+		dir     string
+		options appfiles.IgnoreOptions
+	}{dir, options})
+	fake.recordInvocation("IgnoredFilesInDir", []interface{}{dir, options})
+	fake.ignoredFilesInDirMutex.Unlock()
+	if fake.IgnoredFilesInDirStub != nil {
+		return fake.IgnoredFilesInDirStub(dir, options)
+	} else {
+		return fake.ignoredFilesInDirReturns.result1, fake.ignoredFilesInDirReturns.result2
+	}
+}
+
+func (fake *FakeAppFiles) IgnoredFilesInDirCallCount() int {
+	fake.ignoredFilesInDirMutex.RLock()
+	defer fake.ignoredFilesInDirMutex.RUnlock()
+	return len(fake.ignoredFilesInDirArgsForCall)
+}
+
+func (fake *FakeAppFiles) IgnoredFilesInDirArgsForCall(i int) (string, appfiles.IgnoreOptions) {
+	fake.ignoredFilesInDirMutex.RLock()
+	defer fake.ignoredFilesInDirMutex.RUnlock()
+	return fake.ignoredFilesInDirArgsForCall[i].dir, fake.ignoredFilesInDirArgsForCall[i].options
+}
+
+func (fake *FakeAppFiles) IgnoredFilesInDirReturns(result1 []string, result2 error) {
+	fake.IgnoredFilesInDirStub = nil
+	fake.ignoredFilesInDirReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAppFiles) CopyFiles(appFiles []models.AppFileFields, fromDir string, toDir string) (err error) {
 	var appFilesCopy []models.AppFileFields
 	if appFiles != nil {
@@ -195,6 +242,8 @@ func (fake *FakeAppFiles) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.appFilesInDirMutex.RLock()
 	defer fake.appFilesInDirMutex.RUnlock()
+	fake.ignoredFilesInDirMutex.RLock()
+	defer fake.ignoredFilesInDirMutex.RUnlock()
 	fake.copyFilesMutex.RLock()
 	defer fake.copyFilesMutex.RUnlock()
 	fake.countFilesMutex.RLock()
@@ -1,79 +1,216 @@
 package appfiles
 
 import (
+	"io/ioutil"
 	"path"
+	"path/filepath"
 	"strings"
+)
 
-	"code.cloudfoundry.org/cli/utils/glob"
+const (
+	cfIgnoreFilename  = ".cfignore"
+	gitIgnoreFilename = ".gitignore"
 )
 
 //go:generate counterfeiter . CfIgnore
 
+// CfIgnore decides which app files are left out of an upload. The rules
+// follow the .gitignore format. A path ending in a slash is matched as a
+// directory.
 type CfIgnore interface {
 	FileShouldBeIgnored(path string) bool
 }
 
+// IgnoreOptions controls which ignore files are read while walking an app
+// directory.
+type IgnoreOptions struct {
+	// GitIgnore makes .gitignore files count as well as .cfignore files. A
+	// .cfignore file takes precedence over the .gitignore file next to it.
+	GitIgnore bool
+}
+
 func NewCfIgnore(text string) CfIgnore {
-	patterns := []ignorePattern{}
-	lines := strings.Split(text, "\n")
-	lines = append(defaultIgnoreLines, lines...)
+	ignore := &cfIgnore{}
+	ignore.addRules("", defaultIgnoreLines)
+	ignore.addRules("", strings.Split(text, "\n"))
+	return ignore
+}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
+func (ignore *cfIgnore) FileShouldBeIgnored(filePath string) bool {
+	isDir := strings.HasSuffix(filePath, "/")
+	filePath = strings.Trim(path.Clean("/"+filePath), "/")
+	if filePath == "" {
+		return false
+	}
 
-		ignore := true
-		if strings.HasPrefix(line, "!") {
-			line = line[1:]
-			ignore = false
+	parts := strings.Split(filePath, "/")
+	for i := 1; i < len(parts); i++ {
+		if ignore.matches(strings.Join(parts[:i], "/"), true) {
+			return true
 		}
+	}
 
-		for _, p := range globsForPattern(path.Clean(line)) {
-			patterns = append(patterns, ignorePattern{ignore, p})
-		}
+	return ignore.matches(filePath, isDir)
+}
+
+// loadDir adds the rules of the ignore files found in the directory relDir
+// of the app in root. Directories must be loaded parents first, so that the
+// rules of deeper ignore files take precedence.
+func (ignore *cfIgnore) loadDir(root string, relDir string, options IgnoreOptions) {
+	filenames := []string{cfIgnoreFilename}
+	if options.GitIgnore {
+		filenames = []string{gitIgnoreFilename, cfIgnoreFilename}
 	}
 
-	return cfIgnore(patterns)
+	for _, filename := range filenames {
+		contents, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(relDir), filename))
+		if err != nil {
+			continue
+		}
+		ignore.addRules(relDir, strings.Split(string(contents), "\n"))
+	}
 }
 
-func (ignore cfIgnore) FileShouldBeIgnored(path string) bool {
+// matches reports whether the last rule matching filePath excludes it. It
+// does not look at the parent directories of filePath.
+func (ignore *cfIgnore) matches(filePath string, isDir bool) bool {
 	result := false
 
-	for _, pattern := range ignore {
-		if strings.HasPrefix(pattern.glob.String(), "/") && !strings.HasPrefix(path, "/") {
-			path = "/" + path
+	for _, rule := range ignore.rules {
+		if rule.matches(filePath, isDir) {
+			result = !rule.negate
 		}
+	}
 
-		if pattern.glob.Match(path) {
-			result = pattern.exclude
+	return result
+}
+
+func (ignore *cfIgnore) addRules(base string, lines []string) {
+	for _, line := range lines {
+		rule, ok := parseIgnoreRule(base, line)
+		if ok {
+			ignore.rules = append(ignore.rules, rule)
 		}
 	}
+}
 
-	return result
+type cfIgnore struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	// base is the directory of the ignore file, relative to the app root
+	base     string
+	segments []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
 }
 
-func globsForPattern(pattern string) (globs []glob.Glob) {
-	globs = append(globs, glob.MustCompileGlob(pattern))
-	globs = append(globs, glob.MustCompileGlob(path.Join(pattern, "*")))
-	globs = append(globs, glob.MustCompileGlob(path.Join(pattern, "**", "*")))
+func parseIgnoreRule(base string, line string) (ignoreRule, bool) {
+	rule := ignoreRule{base: base}
+
+	line = trimTrailingSpaces(strings.TrimSuffix(line, "\r"))
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule, false
+	}
+
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
 
-	if !strings.HasPrefix(pattern, "/") {
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern)))
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern, "*")))
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern, "**", "*")))
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
 	}
 
-	return
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+		line = strings.TrimLeft(line, "/")
+	}
+
+	if line == "" {
+		return rule, false
+	}
+
+	rule.segments = strings.Split(line, "/")
+	for i, segment := range rule.segments {
+		rule.segments[i] = toMatchPattern(segment)
+	}
+
+	return rule, true
+}
+
+// trimTrailingSpaces removes trailing spaces unless they are escaped with a
+// backslash.
+func trimTrailingSpaces(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+	return line
 }
 
-type ignorePattern struct {
-	exclude bool
-	glob    glob.Glob
+// toMatchPattern converts a gitignore pattern segment to the syntax of
+// path.Match, which negates character classes with '^' instead of '!'.
+func toMatchPattern(segment string) string {
+	return strings.Replace(segment, "[!", "[^", -1)
 }
 
-type cfIgnore []ignorePattern
+func (rule ignoreRule) matches(filePath string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
+
+	if rule.base != "" {
+		if !strings.HasPrefix(filePath, rule.base+"/") {
+			return false
+		}
+		filePath = strings.TrimPrefix(filePath, rule.base+"/")
+	}
+
+	parts := strings.Split(filePath, "/")
+	if !rule.anchored {
+		return matchSegment(rule.segments[0], parts[len(parts)-1])
+	}
+
+	return matchSegments(rule.segments, parts)
+}
+
+// matchSegments matches path segments against pattern segments, where a "**"
+// segment matches zero or more whole path segments.
+func matchSegments(patterns []string, parts []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			if len(patterns) == 1 {
+				return len(parts) > 0
+			}
+
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(patterns[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(parts) == 0 || !matchSegment(patterns[0], parts[0]) {
+			return false
+		}
+
+		patterns = patterns[1:]
+		parts = parts[1:]
+	}
+
+	return len(parts) == 0
+}
+
+func matchSegment(pattern string, part string) bool {
+	matched, err := path.Match(pattern, part)
+	return err == nil && matched
+}
 
 var defaultIgnoreLines = []string{
 	".cfignore",
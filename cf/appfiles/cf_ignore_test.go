@@ -67,6 +67,57 @@ stuff/exclude.c`)
 		Expect(ignore.FileShouldBeIgnored(".git/objects")).To(BeFalse())
 	})
 
+	It("only matches directories with patterns ending in a slash", func() {
+		ignore := NewCfIgnore(`build/`)
+		Expect(ignore.FileShouldBeIgnored("build/")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("src/build/")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("build/output.jar")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("build")).To(BeFalse())
+	})
+
+	It("anchors patterns that contain a slash to the top of the app", func() {
+		ignore := NewCfIgnore(`/todo.txt
+docs/*.md`)
+		Expect(ignore.FileShouldBeIgnored("todo.txt")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("lib/todo.txt")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("docs/readme.md")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("lib/docs/readme.md")).To(BeFalse())
+	})
+
+	It("matches any number of directories with **", func() {
+		ignore := NewCfIgnore(`**/fixtures
+logs/**`)
+		Expect(ignore.FileShouldBeIgnored("fixtures/data.json")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("spec/unit/fixtures/data.json")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("logs/2016/app.log")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("logs/")).To(BeFalse())
+	})
+
+	It("skips comments and blank lines and honors escapes", func() {
+		ignore := NewCfIgnore(`# secrets
+\#notes
+\!important
+trailing   
+escaped\ `)
+		Expect(ignore.FileShouldBeIgnored("# secrets")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("#notes")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("!important")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("trailing")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("escaped ")).To(BeTrue())
+	})
+
+	It("does not re-include files inside an excluded directory", func() {
+		ignore := NewCfIgnore(`secrets/
+!secrets/public.pem`)
+		Expect(ignore.FileShouldBeIgnored("secrets/public.pem")).To(BeTrue())
+	})
+
+	It("supports negated character classes", func() {
+		ignore := NewCfIgnore(`*.py[!c]`)
+		Expect(ignore.FileShouldBeIgnored("app.pyo")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("app.pyc")).To(BeFalse())
+	})
+
 	Describe("files named manifest.yml", func() {
 		var (
 			ignore CfIgnore
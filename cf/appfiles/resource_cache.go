@@ -0,0 +1,266 @@
+package appfiles
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	resourceCacheVersion = 1
+
+	// ResourceTTL is how long a SHA the Cloud Controller reported as present in
+	// its resource pool is trusted without asking again. The pool is pruned on
+	// the server side, so the answer cannot be kept forever.
+	ResourceTTL = 24 * time.Hour
+
+	// FileEntryTTL is how long the SHA of a local file is kept after it was
+	// last used.
+	FileEntryTTL = 30 * 24 * time.Hour
+
+	// racyInterval guards against files that are modified again within the
+	// mtime granularity of the file system right after they were hashed.
+	racyInterval = 2 * time.Second
+)
+
+// ResourceCache is a persistent cache of the SHA1s of local app files and of
+// the SHA1s a Cloud Controller already has in its resource pool. It is safe
+// for concurrent use, and a nil *ResourceCache behaves as an empty cache that
+// does not record anything.
+type ResourceCache struct {
+	path   string
+	bypass bool
+
+	mutex    sync.Mutex
+	loaded   bool
+	dirty    bool
+	contents resourceCacheContents
+}
+
+type resourceCacheContents struct {
+	Version   int                         `json:"version"`
+	Files     map[string]cachedFile       `json:"files"`
+	Resources map[string]map[string]int64 `json:"resources"`
+}
+
+type cachedFile struct {
+	Size     int64  `json:"size"`
+	ModTime  int64  `json:"mod_time"`
+	Sha1     string `json:"sha1"`
+	LastUsed int64  `json:"last_used"`
+}
+
+func NewResourceCache(path string) *ResourceCache {
+	return &ResourceCache{
+		path: path,
+	}
+}
+
+// Bypass makes the cache ignore every entry it already holds. Fresh results
+// are still recorded, so a bypassed run repairs a stale cache.
+func (cache *ResourceCache) Bypass() {
+	if cache == nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.bypass = true
+}
+
+// FileSha1 returns the cached SHA1 of the file at fullPath, provided its size
+// and modification time still match the ones it had when it was hashed.
+func (cache *ResourceCache) FileSha1(fullPath string, fileInfo os.FileInfo) (string, bool) {
+	if cache == nil {
+		return "", false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if cache.bypass {
+		return "", false
+	}
+
+	cache.load()
+	entry, found := cache.contents.Files[fullPath]
+	if !found || entry.Size != fileInfo.Size() || entry.ModTime != fileInfo.ModTime().UnixNano() {
+		return "", false
+	}
+
+	entry.LastUsed = time.Now().Unix()
+	cache.contents.Files[fullPath] = entry
+	cache.dirty = true
+	return entry.Sha1, true
+}
+
+func (cache *ResourceCache) SetFileSha1(fullPath string, fileInfo os.FileInfo, sha1 string) {
+	if cache == nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(fileInfo.ModTime()) < racyInterval {
+		return
+	}
+
+	cache.load()
+	cache.contents.Files[fullPath] = cachedFile{
+		Size:     fileInfo.Size(),
+		ModTime:  fileInfo.ModTime().UnixNano(),
+		Sha1:     sha1,
+		LastUsed: now.Unix(),
+	}
+	cache.dirty = true
+}
+
+// HasResource reports whether the Cloud Controller at endpoint recently said
+// it has a resource with the given SHA1.
+func (cache *ResourceCache) HasResource(endpoint string, sha1 string) bool {
+	if cache == nil {
+		return false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if cache.bypass {
+		return false
+	}
+
+	cache.load()
+	seen, found := cache.contents.Resources[endpoint][sha1]
+	return found && time.Now().Sub(time.Unix(seen, 0)) < ResourceTTL
+}
+
+func (cache *ResourceCache) AddResources(endpoint string, sha1s []string) {
+	if cache == nil || len(sha1s) == 0 {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.load()
+	resources, found := cache.contents.Resources[endpoint]
+	if !found {
+		resources = map[string]int64{}
+		cache.contents.Resources[endpoint] = resources
+	}
+
+	now := time.Now().Unix()
+	for _, sha1 := range sha1s {
+		resources[sha1] = now
+	}
+	cache.dirty = true
+}
+
+// ForgetResources drops every resource recorded for endpoint, e.g. after an
+// upload that relied on them was rejected.
+func (cache *ResourceCache) ForgetResources(endpoint string) {
+	if cache == nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.load()
+	if _, found := cache.contents.Resources[endpoint]; found {
+		delete(cache.contents.Resources, endpoint)
+		cache.dirty = true
+	}
+}
+
+// Save writes the cache to disk if it changed, dropping expired entries.
+func (cache *ResourceCache) Save() error {
+	if cache == nil {
+		return nil
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if !cache.dirty {
+		return nil
+	}
+
+	cache.prune()
+
+	data, err := json.Marshal(cache.contents)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(cache.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(filepath.Dir(cache.path), filepath.Base(cache.path))
+	if err != nil {
+		return err
+	}
+
+	_, err = tempFile.Write(data)
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempFile.Name(), cache.path)
+	}
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+		return err
+	}
+
+	cache.dirty = false
+	return nil
+}
+
+// load reads the cache from disk the first time it is needed. A missing,
+// unreadable or outdated cache file is treated as an empty cache.
+func (cache *ResourceCache) load() {
+	if cache.loaded {
+		return
+	}
+	cache.loaded = true
+
+	data, err := ioutil.ReadFile(cache.path)
+	if err != nil || json.Unmarshal(data, &cache.contents) != nil || cache.contents.Version != resourceCacheVersion {
+		cache.contents = resourceCacheContents{}
+	}
+
+	cache.contents.Version = resourceCacheVersion
+	if cache.contents.Files == nil {
+		cache.contents.Files = map[string]cachedFile{}
+	}
+	if cache.contents.Resources == nil {
+		cache.contents.Resources = map[string]map[string]int64{}
+	}
+}
+
+func (cache *ResourceCache) prune() {
+	now := time.Now()
+
+	for path, entry := range cache.contents.Files {
+		if now.Sub(time.Unix(entry.LastUsed, 0)) >= FileEntryTTL {
+			delete(cache.contents.Files, path)
+		}
+	}
+
+	for endpoint, resources := range cache.contents.Resources {
+		for sha1, seen := range resources {
+			if now.Sub(time.Unix(seen, 0)) >= ResourceTTL {
+				delete(resources, sha1)
+			}
+		}
+		if len(resources) == 0 {
+			delete(cache.contents.Resources, endpoint)
+		}
+	}
+}
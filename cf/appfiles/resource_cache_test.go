@@ -0,0 +1,157 @@
+package appfiles_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/appfiles"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceCache", func() {
+	var (
+		tempDir   string
+		cachePath string
+		filePath  string
+		fileInfo  os.FileInfo
+		cache     *appfiles.ResourceCache
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "resource-cache")
+		Expect(err).NotTo(HaveOccurred())
+
+		cachePath = filepath.Join(tempDir, ".cf", "resource_cache.json")
+
+		filePath = filepath.Join(tempDir, "app.rb")
+		err = ioutil.WriteFile(filePath, []byte("puts 'hello'"), 0600)
+		Expect(err).NotTo(HaveOccurred())
+		lastWeek := time.Now().Add(-7 * 24 * time.Hour)
+		err = os.Chtimes(filePath, lastWeek, lastWeek)
+		Expect(err).NotTo(HaveOccurred())
+
+		fileInfo, err = os.Stat(filePath)
+		Expect(err).NotTo(HaveOccurred())
+
+		cache = appfiles.NewResourceCache(cachePath)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	reload := func() *appfiles.ResourceCache {
+		Expect(cache.Save()).To(Succeed())
+		return appfiles.NewResourceCache(cachePath)
+	}
+
+	Describe("file hashes", func() {
+		It("persists the SHA1 of a file", func() {
+			cache.SetFileSha1(filePath, fileInfo, "some-sha")
+
+			sha, found := reload().FileSha1(filePath, fileInfo)
+			Expect(found).To(BeTrue())
+			Expect(sha).To(Equal("some-sha"))
+		})
+
+		It("misses when the file changed since it was hashed", func() {
+			cache.SetFileSha1(filePath, fileInfo, "some-sha")
+			cache = reload()
+
+			err := ioutil.WriteFile(filePath, []byte("puts 'goodbye'"), 0600)
+			Expect(err).NotTo(HaveOccurred())
+			changedInfo, err := os.Stat(filePath)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found := cache.FileSha1(filePath, changedInfo)
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not record files modified moments ago", func() {
+			now := time.Now()
+			Expect(os.Chtimes(filePath, now, now)).To(Succeed())
+			freshInfo, err := os.Stat(filePath)
+			Expect(err).NotTo(HaveOccurred())
+
+			cache.SetFileSha1(filePath, freshInfo, "some-sha")
+
+			_, found := cache.FileSha1(filePath, freshInfo)
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("resources", func() {
+		It("remembers the resources a Cloud Controller has", func() {
+			cache.AddResources("https://api.example.com", []string{"sha-1", "sha-2"})
+			cache = reload()
+
+			Expect(cache.HasResource("https://api.example.com", "sha-1")).To(BeTrue())
+			Expect(cache.HasResource("https://api.example.com", "sha-3")).To(BeFalse())
+			Expect(cache.HasResource("https://api.other.com", "sha-1")).To(BeFalse())
+		})
+
+		It("forgets the resources of an endpoint", func() {
+			cache.AddResources("https://api.example.com", []string{"sha-1"})
+			cache.AddResources("https://api.other.com", []string{"sha-1"})
+			cache.ForgetResources("https://api.example.com")
+			cache = reload()
+
+			Expect(cache.HasResource("https://api.example.com", "sha-1")).To(BeFalse())
+			Expect(cache.HasResource("https://api.other.com", "sha-1")).To(BeTrue())
+		})
+
+		It("expires resources that were reported too long ago", func() {
+			expired := time.Now().Add(-appfiles.ResourceTTL).Unix()
+			recent := time.Now().Add(-time.Hour).Unix()
+			Expect(os.MkdirAll(filepath.Dir(cachePath), 0700)).To(Succeed())
+			err := ioutil.WriteFile(cachePath, []byte(fmt.Sprintf(
+				`{"version":1,"resources":{"https://api.example.com":{"old-sha":%d,"new-sha":%d}}}`, expired, recent)), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cache.HasResource("https://api.example.com", "old-sha")).To(BeFalse())
+			Expect(cache.HasResource("https://api.example.com", "new-sha")).To(BeTrue())
+		})
+	})
+
+	It("ignores its entries once bypassed but still records new ones", func() {
+		cache.SetFileSha1(filePath, fileInfo, "some-sha")
+		cache.AddResources("https://api.example.com", []string{"sha-1"})
+		cache = reload()
+
+		cache.Bypass()
+		_, found := cache.FileSha1(filePath, fileInfo)
+		Expect(found).To(BeFalse())
+		Expect(cache.HasResource("https://api.example.com", "sha-1")).To(BeFalse())
+
+		cache.SetFileSha1(filePath, fileInfo, "new-sha")
+		sha, found := reload().FileSha1(filePath, fileInfo)
+		Expect(found).To(BeTrue())
+		Expect(sha).To(Equal("new-sha"))
+	})
+
+	It("treats a corrupt cache file as empty", func() {
+		Expect(os.MkdirAll(filepath.Dir(cachePath), 0700)).To(Succeed())
+		Expect(ioutil.WriteFile(cachePath, []byte("not json"), 0600)).To(Succeed())
+
+		Expect(cache.HasResource("https://api.example.com", "sha-1")).To(BeFalse())
+		cache.AddResources("https://api.example.com", []string{"sha-1"})
+		Expect(reload().HasResource("https://api.example.com", "sha-1")).To(BeTrue())
+	})
+
+	It("is a no-op when nil", func() {
+		var nilCache *appfiles.ResourceCache
+		nilCache.SetFileSha1(filePath, fileInfo, "some-sha")
+		nilCache.AddResources("https://api.example.com", []string{"sha-1"})
+
+		_, found := nilCache.FileSha1(filePath, fileInfo)
+		Expect(found).To(BeFalse())
+		Expect(nilCache.HasResource("https://api.example.com", "sha-1")).To(BeFalse())
+		Expect(nilCache.Save()).To(Succeed())
+	})
+})
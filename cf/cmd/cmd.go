@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -46,7 +47,7 @@ func Main(traceEnv string, args []string) {
 	args = append([]string{args[0]}, handleHelp(args[1:])...)
 
 	newArgs, isVerbose := handleVerbose(args)
-	args = newArgs
+	args = handleLeadingOutputFormat(newArgs)
 
 	errFunc := func(err error) {
 		if err != nil {
@@ -95,6 +96,19 @@ func Main(traceEnv string, args []string) {
 		flagContext.SkipFlagParsing(meta.SkipFlagParsing)
 
 		cmdArgs := args[2:]
+
+		//handles the global `--output FORMAT`, unless the command has an `--output` flag of its own
+		if _, ok := meta.Flags["output"]; !ok {
+			var outputFormat terminal.OutputFormat
+			cmdArgs, outputFormat, err = handleOutputFormat(cmdArgs)
+			if err != nil {
+				usage := cmdRegistry.CommandUsage(cmdName)
+				deps.UI.Failed(T("Incorrect Usage") + "\n\n" + err.Error() + "\n\n" + usage)
+				os.Exit(1)
+			}
+			terminal.UserAskedForOutputFormat = outputFormat
+		}
+
 		err = flagContext.Parse(cmdArgs...)
 		if err != nil {
 			usage := cmdRegistry.CommandUsage(cmdName)
@@ -236,3 +250,45 @@ func handleVerbose(args []string) ([]string, bool) {
 
 	return args, verbose
 }
+
+func handleLeadingOutputFormat(args []string) []string {
+	if len(args) > 3 && args[1] == "--output" {
+		return append([]string{args[0], args[3], args[1], args[2]}, args[4:]...)
+	}
+
+	if len(args) > 2 && strings.HasPrefix(args[1], "--output=") {
+		return append([]string{args[0], args[2], args[1]}, args[3:]...)
+	}
+
+	return args
+}
+
+func handleOutputFormat(args []string) ([]string, terminal.OutputFormat, error) {
+	format := terminal.TextOutput
+	remaining := []string{}
+
+	for i := 0; i < len(args); i++ {
+		var value string
+		switch {
+		case args[i] == "--output":
+			if i+1 >= len(args) {
+				return nil, format, errors.New(T("No value given for the --output flag"))
+			}
+			i++
+			value = args[i]
+		case strings.HasPrefix(args[i], "--output="):
+			value = strings.TrimPrefix(args[i], "--output=")
+		default:
+			remaining = append(remaining, args[i])
+			continue
+		}
+
+		var err error
+		format, err = terminal.ParseOutputFormat(value)
+		if err != nil {
+			return nil, format, errors.New(T("Output format must be either 'json' or 'yaml'"))
+		}
+	}
+
+	return remaining, format, nil
+}
@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
 	"code.cloudfoundry.org/cli/cf/actors/servicebuilder"
 	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits"
 	"code.cloudfoundry.org/cli/cf/appfiles"
 	"code.cloudfoundry.org/cli/cf/configuration"
 	"code.cloudfoundry.org/cli/cf/configuration/confighelpers"
@@ -47,9 +48,11 @@ type Dependency struct {
 	WordGenerator      generator.WordGenerator
 	AppZipper          appfiles.Zipper
 	AppFiles           appfiles.AppFiles
+	ResourceCache      *appfiles.ResourceCache
 	PushActor          actors.PushActor
 	RouteActor         actors.RouteActor
 	ChecksumUtil       utils.Sha1Checksum
+	SignatureVerifier  utils.SignatureVerifier
 	WildcardDependency interface{} //use for injecting fakes
 	Logger             trace.Printer
 }
@@ -105,6 +108,13 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 	}
 	deps.RepoLocator = api.NewRepositoryLocator(deps.Config, deps.Gateways, logger)
 
+	if configPath != "" {
+		deps.ResourceCache = appfiles.NewResourceCache(filepath.Join(filepath.Dir(configPath), "resource_cache.json"))
+	}
+	deps.RepoLocator = deps.RepoLocator.SetApplicationBitsRepository(
+		applicationbits.NewCloudControllerApplicationBitsRepository(deps.Config, deps.Gateways["cloud-controller"]).WithResourceCache(deps.ResourceCache),
+	)
+
 	deps.PluginModels = &PluginModels{Application: nil}
 
 	deps.PlanBuilder = planbuilder.NewBuilder(
@@ -142,13 +152,19 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 	deps.WordGenerator = generator.NewWordGenerator()
 
 	deps.AppZipper = appfiles.ApplicationZipper{}
-	deps.AppFiles = appfiles.ApplicationFiles{}
+	deps.AppFiles = appfiles.ApplicationFiles{Cache: deps.ResourceCache}
 
 	deps.RouteActor = actors.NewRouteActor(deps.UI, deps.RepoLocator.GetRouteRepository(), deps.RepoLocator.GetDomainRepository())
 	deps.PushActor = actors.NewPushActor(deps.RepoLocator.GetApplicationBitsRepository(), deps.AppZipper, deps.AppFiles, deps.RouteActor)
 
 	deps.ChecksumUtil = utils.NewSha1Checksum("")
 
+	var trustedKeysPath string
+	if configPath != "" {
+		trustedKeysPath = filepath.Join(filepath.Dir(configPath), "trusted_plugin_keys")
+	}
+	deps.SignatureVerifier = utils.NewSignatureVerifier(trustedKeysPath)
+
 	deps.Logger = logger
 
 	return deps
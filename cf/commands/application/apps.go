@@ -99,6 +99,7 @@ func (cmd *ListApps) Execute(c flags.FlagContext) error {
 		// T("app ports"),
 		T("urls"),
 	})
+	table.SetFieldNames("name", "requested_state", "instances", "memory", "disk", "urls")
 
 	for _, application := range apps {
 		var urls []string
@@ -0,0 +1,282 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/appinstances"
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+const (
+	DefaultAutoscaleInterval = 30 * time.Second
+	DefaultAutoscaleCooldown = 5 * time.Minute
+)
+
+type Autoscale struct {
+	ui               terminal.UI
+	config           coreconfig.Reader
+	appReq           requirements.ApplicationRequirement
+	appRepo          applications.Repository
+	appInstancesRepo appinstances.Repository
+	scaler           Scaler
+
+	stop     chan struct{}
+	stopOnce *sync.Once
+}
+
+// autoscalePolicy holds the limits the instance count of an app is kept
+// within. cpuThreshold is a fraction of a CPU, as in the instance stats.
+type autoscalePolicy struct {
+	min          int
+	max          int
+	cpuThreshold float64
+}
+
+func init() {
+	commandregistry.Register(&Autoscale{})
+}
+
+func (cmd *Autoscale) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["min"] = &flags.IntFlag{Name: "min", Usage: T("Minimum number of instances")}
+	fs["max"] = &flags.IntFlag{Name: "max", Usage: T("Maximum number of instances")}
+	fs["cpu-threshold"] = &flags.IntFlag{Name: "cpu-threshold", Usage: T("Average CPU usage of the instances, in percent, to scale the app to")}
+	fs["interval"] = &flags.StringFlag{Name: "interval", Usage: T("Time between two polls of the instance stats, such as 30s or 1m (Default: 30s)")}
+	fs["cooldown"] = &flags.StringFlag{Name: "cooldown", Usage: T("Time to wait after scaling the app before scaling it again, such as 5m (Default: 5m)")}
+	fs["once"] = &flags.BoolFlag{Name: "once", Usage: T("Poll the instance stats and scale the app once, then exit")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "autoscale",
+		Description: T("Scale the instance count of an app to its CPU usage"),
+		Usage: []string{
+			T("CF_NAME autoscale APP_NAME --min MIN_INSTANCES --max MAX_INSTANCES --cpu-threshold PERCENT [--interval DURATION] [--cooldown DURATION] [--once]"),
+			"\n\n",
+			T("TIP:\n   The instance count is set so that the average CPU usage of the running instances is at most the threshold.\n   Without --once, the app is polled and scaled until the command is interrupted. With --once, --interval and --cooldown do not apply: schedule the command, for example with cron, at intervals no shorter than the cooldown you want."),
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *Autoscale) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("autoscale"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	if !fc.IsSet("min") || !fc.IsSet("max") || !fc.IsSet("cpu-threshold") {
+		cmd.ui.Failed(T("Incorrect Usage. Requires --min, --max and --cpu-threshold\n\n") + commandregistry.Commands.CommandUsage("autoscale"))
+		return nil, fmt.Errorf("Incorrect usage: --min, --max and --cpu-threshold are required")
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *Autoscale) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
+	cmd.stop = make(chan struct{})
+	cmd.stopOnce = new(sync.Once)
+
+	//get command from registry for dependency
+	commandDep := commandregistry.Commands.FindCommand("scale")
+	commandDep = commandDep.SetDependency(deps, false)
+	cmd.scaler = commandDep.(Scaler)
+
+	return cmd
+}
+
+// Stop ends the control loop once the current poll is done.
+func (cmd *Autoscale) Stop() {
+	cmd.stopOnce.Do(func() {
+		close(cmd.stop)
+	})
+}
+
+func (cmd *Autoscale) Execute(c flags.FlagContext) error {
+	policy := autoscalePolicy{
+		min:          c.Int("min"),
+		max:          c.Int("max"),
+		cpuThreshold: float64(c.Int("cpu-threshold")) / 100,
+	}
+
+	if policy.min < 1 {
+		return errors.New(T("Option '--min' must be at least 1"))
+	}
+	if policy.max < policy.min {
+		return errors.New(T("Option '--max' must not be less than '--min'"))
+	}
+	if policy.cpuThreshold <= 0 {
+		return errors.New(T("Option '--cpu-threshold' must be greater than 0"))
+	}
+
+	interval, err := durationFlag(c, "interval", DefaultAutoscaleInterval)
+	if err != nil {
+		return err
+	}
+	cooldown, err := durationFlag(c, "cooldown", DefaultAutoscaleCooldown)
+	if err != nil {
+		return err
+	}
+	if c.Bool("once") && c.IsSet("cooldown") {
+		return errors.New(T("Option '--cooldown' cannot be used with '--once'"))
+	}
+
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say("%s", T("Autoscaling app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}} between {{.Min}} and {{.Max}} instances at {{.Threshold}}% CPU...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(app.Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+			"Min":       policy.min,
+			"Max":       policy.max,
+			"Threshold": c.Int("cpu-threshold"),
+		}))
+
+	if c.Bool("once") {
+		_, err = cmd.autoscale(app, policy, true)
+		if err != nil {
+			return err
+		}
+		cmd.ui.Ok()
+		return nil
+	}
+
+	appName, appGUID := app.Name, app.GUID
+	var lastScaled time.Time
+	for poll := 1; ; poll++ {
+		var err error
+		if poll > 1 {
+			// the app may have been scaled by others since the last poll
+			app, err = cmd.appRepo.GetApp(appGUID)
+		}
+		if err == nil {
+			var scaled bool
+			canScale := lastScaled.IsZero() || time.Since(lastScaled) >= cooldown
+			scaled, err = cmd.autoscale(app, policy, canScale)
+			if scaled {
+				lastScaled = time.Now()
+			}
+		}
+		if err != nil {
+			cmd.ui.Warn(T("Could not autoscale app {{.AppName}}: {{.Error}}",
+				map[string]interface{}{"AppName": appName, "Error": err.Error()}))
+		}
+
+		select {
+		case <-cmd.stop:
+			cmd.ui.Say(T("Autoscaling stopped"))
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// autoscale polls the instance stats of app and scales it to the instance
+// count the policy asks for, unless canScale is false. It reports whether
+// the app was scaled.
+func (cmd *Autoscale) autoscale(app models.Application, policy autoscalePolicy, canScale bool) (bool, error) {
+	instances, err := cmd.appInstancesRepo.GetInstances(app.GUID)
+	if err != nil {
+		return false, err
+	}
+
+	current := app.InstanceCount
+	desired, cpu, running := policy.desiredInstances(current, instances)
+
+	decision := T("{{.CPU}}% CPU on {{.RunningCount}} running instances",
+		map[string]interface{}{"CPU": fmt.Sprintf("%.1f", cpu*100), "RunningCount": running})
+
+	switch {
+	case desired == current:
+		cmd.logDecision(decision, T("keeping {{.InstanceCount}} instances",
+			map[string]interface{}{"InstanceCount": current}))
+		return false, nil
+	case !canScale:
+		cmd.logDecision(decision, T("not scaling to {{.InstanceCount}} instances during the cooldown",
+			map[string]interface{}{"InstanceCount": desired}))
+		return false, nil
+	}
+
+	cmd.logDecision(decision, T("scaling from {{.CurrentCount}} to {{.InstanceCount}} instances",
+		map[string]interface{}{"CurrentCount": current, "InstanceCount": desired}))
+
+	_, err = cmd.scaler.ScaleInstances(app, desired)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (cmd *Autoscale) logDecision(measurement, decision string) {
+	cmd.ui.Say("%s %s: %s", time.Now().Format(time.RFC3339), measurement, decision)
+}
+
+// desiredInstances returns the fewest instances within the policy limits
+// that can take the load of the running instances without averaging more
+// than the CPU threshold, along with their current average CPU usage and
+// how many are running. The current count is kept when no instance is
+// running, as there is no load to measure.
+func (p autoscalePolicy) desiredInstances(current int, instances []models.AppInstanceFields) (int, float64, int) {
+	var total float64
+	running := 0
+	for _, instance := range instances {
+		if instance.State == models.InstanceRunning {
+			total += instance.CPUUsage
+			running++
+		}
+	}
+
+	desired := current
+	var average float64
+	if running > 0 {
+		average = total / float64(running)
+		// the tolerance keeps rounding errors from adding an instance
+		desired = int(math.Ceil(total/p.cpuThreshold - 1e-9))
+	}
+
+	if desired < p.min {
+		desired = p.min
+	}
+	if desired > p.max {
+		desired = p.max
+	}
+
+	return desired, average, running
+}
+
+func durationFlag(c flags.FlagContext, name string, defaultDuration time.Duration) (time.Duration, error) {
+	if c.String(name) == "" {
+		return defaultDuration, nil
+	}
+
+	duration, err := time.ParseDuration(c.String(name))
+	if err != nil || duration <= 0 {
+		return 0, errors.New(T("Invalid duration for '--{{.Flag}}': {{.Value}}",
+			map[string]interface{}{"Flag": name, "Value": c.String(name)}))
+	}
+	return duration, nil
+}
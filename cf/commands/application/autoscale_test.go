@@ -0,0 +1,313 @@
+package application_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/api/appinstances/appinstancesfakes"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application"
+	"code.cloudfoundry.org/cli/cf/commands/application/applicationfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("autoscale command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		config              coreconfig.Repository
+		scaler              *applicationfakes.FakeScaler
+		appRepo             *applicationsfakes.FakeRepository
+		appInstancesRepo    *appinstancesfakes.FakeAppInstancesRepository
+		originalScale       commandregistry.Command
+		deps                commandregistry.Dependency
+		app                 models.Application
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+		deps.RepoLocator = deps.RepoLocator.SetAppInstancesRepository(appInstancesRepo)
+
+		//inject fake 'scale' into registry
+		commandregistry.Register(scaler)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("autoscale").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("autoscale", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	instancesAt := func(cpuUsages ...float64) []models.AppInstanceFields {
+		instances := []models.AppInstanceFields{}
+		for _, cpu := range cpuUsages {
+			instances = append(instances, models.AppInstanceFields{State: models.InstanceRunning, CPUUsage: cpu})
+		}
+		return instances
+	}
+
+	BeforeEach(func() {
+		ui = new(testterm.FakeUI)
+		config = testconfig.NewRepositoryWithDefaults()
+		appRepo = new(applicationsfakes.FakeRepository)
+		appInstancesRepo = new(appinstancesfakes.FakeAppInstancesRepository)
+
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+		app = models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		app.InstanceCount = 2
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+		//save original command and restore later
+		originalScale = commandregistry.Commands.FindCommand("scale")
+
+		scaler = new(applicationfakes.FakeScaler)
+		//setup fakes to correctly interact with commandregistry
+		scaler.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return scaler
+		}
+		scaler.MetaDataReturns(commandregistry.CommandMetadata{Name: "scale"})
+		scaler.ScaleInstancesStub = func(scaledApp models.Application, instances int) (models.Application, error) {
+			app.InstanceCount = instances
+			return app, nil
+		}
+		appRepo.GetAppStub = func(_ string) (models.Application, error) {
+			return app, nil
+		}
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalScale)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not provided exactly one arg", func() {
+			Expect(runCommand("--min", "1", "--max", "3", "--cpu-threshold", "60")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires an argument"}))
+		})
+
+		It("fails with usage when a limit is missing", func() {
+			Expect(runCommand("my-app", "--min", "1", "--max", "3")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires --min, --max and --cpu-threshold"}))
+		})
+
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-app", "--min", "1", "--max", "3", "--cpu-threshold", "60")).To(BeFalse())
+		})
+	})
+
+	DescribeTable("invalid options",
+		func(message string, args ...string) {
+			Expect(runCommand(append([]string{"my-app", "--once"}, args...)...)).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{message}))
+			Expect(appInstancesRepo.GetInstancesCallCount()).To(Equal(0))
+		},
+		Entry("--min of 0", "Option '--min' must be at least 1", "--min", "0", "--max", "3", "--cpu-threshold", "60"),
+		Entry("--max below --min", "Option '--max' must not be less than '--min'", "--min", "3", "--max", "2", "--cpu-threshold", "60"),
+		Entry("--cpu-threshold of 0", "Option '--cpu-threshold' must be greater than 0", "--min", "1", "--max", "3", "--cpu-threshold", "0"),
+		Entry("invalid --interval", "Invalid duration for '--interval': soon", "--min", "1", "--max", "3", "--cpu-threshold", "60", "--interval", "soon"),
+		Entry("invalid --cooldown", "Invalid duration for '--cooldown': -1m", "--min", "1", "--max", "3", "--cpu-threshold", "60", "--cooldown", "-1m"),
+		Entry("--cooldown with --once", "Option '--cooldown' cannot be used with '--once'", "--min", "1", "--max", "3", "--cpu-threshold", "60", "--cooldown", "5m"),
+	)
+
+	Context("with --once", func() {
+		runOnce := func(min, max string) bool {
+			return runCommand("my-app", "--once", "--min", min, "--max", max, "--cpu-threshold", "60")
+		}
+
+		It("scales the app up until the average CPU usage is under the threshold", func() {
+			appInstancesRepo.GetInstancesReturns(instancesAt(0.9, 0.9), nil)
+
+			Expect(runOnce("1", "10")).To(BeTrue())
+
+			Expect(appInstancesRepo.GetInstancesArgsForCall(0)).To(Equal("my-app-guid"))
+			Expect(scaler.ScaleInstancesCallCount()).To(Equal(1))
+			scaledApp, instances := scaler.ScaleInstancesArgsForCall(0)
+			Expect(scaledApp.GUID).To(Equal("my-app-guid"))
+			Expect(instances).To(Equal(3))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Autoscaling app", "my-app", "between 1 and 10 instances at 60% CPU"},
+				[]string{"90.0% CPU on 2 running instances: scaling from 2 to 3 instances"},
+				[]string{"OK"},
+			))
+		})
+
+		It("keeps the instance count when the CPU usage is around the threshold", func() {
+			appInstancesRepo.GetInstancesReturns(instancesAt(0.5, 0.6), nil)
+
+			Expect(runOnce("1", "10")).To(BeTrue())
+
+			Expect(scaler.ScaleInstancesCallCount()).To(Equal(0))
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"55.0% CPU on 2 running instances: keeping 2 instances"}))
+		})
+
+		It("scales an idle app down to --min", func() {
+			app.InstanceCount = 4
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.GetApplicationReturns(app)
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+			appInstancesRepo.GetInstancesReturns(instancesAt(0.01, 0.01, 0, 0.02), nil)
+
+			Expect(runOnce("2", "10")).To(BeTrue())
+
+			_, instances := scaler.ScaleInstancesArgsForCall(0)
+			Expect(instances).To(Equal(2))
+		})
+
+		It("does not scale the app beyond --max", func() {
+			appInstancesRepo.GetInstancesReturns(instancesAt(4, 4), nil)
+
+			Expect(runOnce("1", "5")).To(BeTrue())
+
+			_, instances := scaler.ScaleInstancesArgsForCall(0)
+			Expect(instances).To(Equal(5))
+		})
+
+		It("only measures the running instances", func() {
+			instances := instancesAt(0.9)
+			instances = append(instances, models.AppInstanceFields{State: models.InstanceCrashed})
+			appInstancesRepo.GetInstancesReturns(instances, nil)
+
+			Expect(runOnce("1", "10")).To(BeTrue())
+
+			Expect(scaler.ScaleInstancesCallCount()).To(Equal(0))
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"90.0% CPU on 1 running instances: keeping 2 instances"}))
+		})
+
+		It("keeps the instance count when no instance is running", func() {
+			appInstancesRepo.GetInstancesReturns([]models.AppInstanceFields{{State: models.InstanceStarting}}, nil)
+
+			Expect(runOnce("1", "10")).To(BeTrue())
+
+			Expect(scaler.ScaleInstancesCallCount()).To(Equal(0))
+		})
+
+		It("fails when the instance stats cannot be fetched", func() {
+			appInstancesRepo.GetInstancesReturns(nil, errors.New("stats-error"))
+
+			Expect(runOnce("1", "10")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"stats-error"}))
+		})
+
+		It("fails when the app cannot be scaled", func() {
+			appInstancesRepo.GetInstancesReturns(instancesAt(0.9, 0.9), nil)
+			scaler.ScaleInstancesReturns(models.Application{}, errors.New("scale-error"))
+
+			Expect(runOnce("1", "10")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"scale-error"}))
+		})
+	})
+
+	Context("without --once", func() {
+		stopAfterPolls := func(polls int, stub func(poll int) ([]models.AppInstanceFields, error)) {
+			appInstancesRepo.GetInstancesStub = func(_ string) ([]models.AppInstanceFields, error) {
+				poll := appInstancesRepo.GetInstancesCallCount()
+				if poll == polls {
+					commandregistry.Commands.FindCommand("autoscale").(*application.Autoscale).Stop()
+				}
+				return stub(poll)
+			}
+		}
+
+		It("does not scale the app again during the cooldown", func() {
+			stopAfterPolls(3, func(poll int) ([]models.AppInstanceFields, error) {
+				if poll == 1 {
+					return instancesAt(0.9, 0.9), nil
+				}
+				return instancesAt(0.9, 0.9, 0.9), nil
+			})
+
+			Expect(runCommand("my-app", "--min", "1", "--max", "10", "--cpu-threshold", "60", "--interval", "1ms", "--cooldown", "1h")).To(BeTrue())
+
+			Expect(appInstancesRepo.GetInstancesCallCount()).To(Equal(3))
+			Expect(scaler.ScaleInstancesCallCount()).To(Equal(1))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"90.0% CPU on 2 running instances: scaling from 2 to 3 instances"},
+				[]string{"90.0% CPU on 3 running instances: not scaling to 5 instances during the cooldown"},
+				[]string{"Autoscaling stopped"},
+			))
+		})
+
+		It("scales the app again after the cooldown", func() {
+			stopAfterPolls(2, func(poll int) ([]models.AppInstanceFields, error) {
+				if poll == 1 {
+					return instancesAt(0.9, 0.9), nil
+				}
+				return instancesAt(0.9, 0.9, 0.9), nil
+			})
+
+			Expect(runCommand("my-app", "--min", "1", "--max", "10", "--cpu-threshold", "60", "--interval", "1ms", "--cooldown", "1ns")).To(BeTrue())
+
+			Expect(scaler.ScaleInstancesCallCount()).To(Equal(2))
+			_, instances := scaler.ScaleInstancesArgsForCall(1)
+			Expect(instances).To(Equal(5))
+		})
+
+		It("reads the app again before each poll to see scaling done by others", func() {
+			stopAfterPolls(2, func(poll int) ([]models.AppInstanceFields, error) {
+				if poll == 1 {
+					// someone else scales the app before the next poll
+					app.InstanceCount = 6
+					return instancesAt(0.5, 0.6), nil
+				}
+				return instancesAt(0.9, 0.9, 0.9, 0.9, 0.9, 0.9), nil
+			})
+
+			Expect(runCommand("my-app", "--min", "1", "--max", "10", "--cpu-threshold", "60", "--interval", "1ms")).To(BeTrue())
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			Expect(appRepo.GetAppArgsForCall(0)).To(Equal("my-app-guid"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"55.0% CPU on 2 running instances: keeping 2 instances"},
+				[]string{"90.0% CPU on 6 running instances: scaling from 6 to 9 instances"},
+			))
+		})
+
+		It("keeps polling when the app cannot be read", func() {
+			appInstancesRepo.GetInstancesReturns(instancesAt(0.5, 0.6), nil)
+			appRepo.GetAppStub = func(_ string) (models.Application, error) {
+				commandregistry.Commands.FindCommand("autoscale").(*application.Autoscale).Stop()
+				return models.Application{}, errors.New("app-error")
+			}
+
+			Expect(runCommand("my-app", "--min", "1", "--max", "10", "--cpu-threshold", "60", "--interval", "1ms")).To(BeTrue())
+
+			Expect(appInstancesRepo.GetInstancesCallCount()).To(Equal(1))
+			Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"Could not autoscale app my-app: app-error"}))
+		})
+
+		It("keeps polling when a poll fails", func() {
+			stopAfterPolls(2, func(poll int) ([]models.AppInstanceFields, error) {
+				if poll == 1 {
+					return nil, errors.New("stats-error")
+				}
+				return instancesAt(0.9, 0.9), nil
+			})
+
+			Expect(runCommand("my-app", "--min", "1", "--max", "10", "--cpu-threshold", "60", "--interval", "1ms")).To(BeTrue())
+
+			Expect(scaler.ScaleInstancesCallCount()).To(Equal(1))
+			Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"Could not autoscale app my-app: stats-error"}))
+		})
+	})
+})
@@ -70,6 +70,7 @@ func (cmd *Events) Execute(c flags.FlagContext) error {
 			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
 
 	table := cmd.ui.Table([]string{T("time"), T("event"), T("actor"), T("description")})
+	table.SetFieldNames("time", "event", "actor", "description")
 
 	events, err := cmd.eventsRepo.RecentEvents(app.GUID, 50)
 	if err != nil {
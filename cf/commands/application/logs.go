@@ -1,9 +1,14 @@
 package application
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/api/logs"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -13,13 +18,21 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	"gopkg.in/yaml.v2"
 )
 
 type Logs struct {
-	ui       terminal.UI
-	logsRepo logs.Repository
-	config   coreconfig.Reader
-	appReq   requirements.ApplicationRequirement
+	ui             terminal.UI
+	logsRepo       logs.Repository
+	appSummaryRepo api.AppSummaryRepository
+	config         coreconfig.Reader
+	appReqs        []requirements.ApplicationRequirement
+	filter         logs.Filter
+
+	// appNames and appPrefixes are keyed by app GUID and only set when the
+	// logs of several apps are merged.
+	appNames    map[string]string
+	appPrefixes map[string]string
 }
 
 func init() {
@@ -29,29 +42,48 @@ func init() {
 func (cmd *Logs) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["recent"] = &flags.BoolFlag{Name: "recent", Usage: T("Dump recent logs instead of tailing")}
+	fs["space"] = &flags.BoolFlag{Name: "space", Usage: T("Show the logs of every app in the targeted space")}
+	fs["source"] = &flags.StringSliceFlag{Name: "source", Usage: T("Only show logs from the given source type: APP, RTR, STG or CELL (can be given multiple times)")}
+	fs["instance"] = &flags.IntFlag{Name: "instance", Usage: T("Only show logs from the app instance with the given index")}
+	fs["since"] = &flags.StringFlag{Name: "since", Usage: T("With --recent, only show logs written after this time, given as a duration such as 15m or as an RFC 3339 timestamp")}
+	fs["until"] = &flags.StringFlag{Name: "until", Usage: T("With --recent, only show logs written before this time, given as a duration such as 15m or as an RFC 3339 timestamp")}
+	fs["grep"] = &flags.StringFlag{Name: "grep", Usage: T("Only show logs whose message matches the given regular expression")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "logs",
-		Description: T("Tail or show recent logs for an app"),
+		Description: T("Tail or show recent logs for one or more apps"),
 		Usage: []string{
-			T("CF_NAME logs APP_NAME"),
+			T("CF_NAME logs APP_NAME [APP_NAME...] [--recent] [--source SOURCE_TYPE] [--instance INDEX] [--since TIME] [--until TIME] [--grep REGEX]"),
+			"\n   ",
+			T("CF_NAME logs --space [--recent] [--source SOURCE_TYPE] [--instance INDEX] [--since TIME] [--until TIME] [--grep REGEX]"),
+			"\n\n",
+			T("TIP:\n   Use the global --output json flag to print each log message as a JSON object on a line of its own"),
 		},
 		Flags: fs,
 	}
 }
 
 func (cmd *Logs) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
-	if len(fc.Args()) != 1 {
+	if fc.Bool("space") && len(fc.Args()) != 0 {
+		cmd.ui.Failed(T("Incorrect Usage. The --space flag cannot be combined with app names\n\n") + commandregistry.Commands.CommandUsage("logs"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 0)
+	}
+
+	if !fc.Bool("space") && len(fc.Args()) == 0 {
 		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("logs"))
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
 	}
 
-	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
-
 	reqs := []requirements.Requirement{
 		requirementsFactory.NewLoginRequirement(),
 		requirementsFactory.NewTargetedSpaceRequirement(),
-		cmd.appReq,
+	}
+
+	cmd.appReqs = nil
+	for _, appName := range fc.Args() {
+		appReq := requirementsFactory.NewApplicationRequirement(appName)
+		cmd.appReqs = append(cmd.appReqs, appReq)
+		reqs = append(reqs, appReq)
 	}
 
 	return reqs, nil
@@ -61,22 +93,46 @@ func (cmd *Logs) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.logsRepo = deps.RepoLocator.GetLogsRepository()
+	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
 	return cmd
 }
 
 func (cmd *Logs) Execute(c flags.FlagContext) error {
-	app := cmd.appReq.GetApplication()
-
 	var err error
-	if c.Bool("recent") {
-		err = cmd.recentLogsFor(app)
-	} else {
-		err = cmd.tailLogsFor(app)
-	}
+	cmd.filter, err = newLogFilter(c, time.Now())
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if !c.Bool("space") && len(cmd.appReqs) == 1 {
+		app := cmd.appReqs[0].GetApplication()
+		if c.Bool("recent") {
+			return cmd.recentLogsFor(app)
+		}
+		return cmd.tailLogsFor(app)
+	}
+
+	var apps []models.Application
+	if c.Bool("space") {
+		apps, err = cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+		if err != nil {
+			return err
+		}
+		if len(apps) == 0 {
+			cmd.ui.Say(T("No apps found"))
+			return nil
+		}
+	} else {
+		for _, appReq := range cmd.appReqs {
+			apps = append(apps, appReq.GetApplication())
+		}
+	}
+
+	cmd.setAppPrefixes(apps)
+	if c.Bool("recent") {
+		return cmd.recentLogsForApps(apps)
+	}
+	return cmd.tailLogsForApps(apps)
 }
 
 func (cmd *Logs) recentLogsFor(app models.Application) error {
@@ -93,7 +149,10 @@ func (cmd *Logs) recentLogsFor(app models.Application) error {
 	}
 
 	for _, msg := range messages {
-		cmd.ui.Say("%s", msg.ToLog(time.Local))
+		err = cmd.printLog(msg)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -113,19 +172,207 @@ func (cmd *Logs) tailLogsFor(app models.Application) error {
 
 	go cmd.logsRepo.TailLogsFor(app.GUID, onConnect, c, e)
 
+	return cmd.printTailedLogs(c, e)
+}
+
+// recentLogsForApps merges the recent logs of the apps, ordered by
+// timestamp.
+func (cmd *Logs) recentLogsForApps(apps []models.Application) error {
+	cmd.ui.Say(T("Connected, dumping recent logs for apps {{.AppNames}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+		map[string]interface{}{
+			"AppNames":  coloredAppNames(apps),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	var messages []logs.Loggable
+	for _, app := range apps {
+		appMessages, err := cmd.logsRepo.RecentLogsFor(app.GUID)
+		if err != nil {
+			return cmd.handleError(err)
+		}
+		messages = append(messages, appMessages...)
+	}
+
+	logs.SortByTimestamp(messages)
+
+	for _, msg := range messages {
+		err := cmd.printLog(msg)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *Logs) tailLogsForApps(apps []models.Application) error {
+	onConnect := func() {
+		cmd.ui.Say(T("Connected, tailing logs for apps {{.AppNames}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+			map[string]interface{}{
+				"AppNames":  coloredAppNames(apps),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+	}
+
+	appGUIDs := make([]string, len(apps))
+	for i, app := range apps {
+		appGUIDs[i] = app.GUID
+	}
+
+	c := make(chan logs.Loggable)
+	e := make(chan error)
+
+	go cmd.logsRepo.TailLogsForApps(appGUIDs, onConnect, c, e)
+
+	return cmd.printTailedLogs(c, e)
+}
+
+func (cmd *Logs) printTailedLogs(c <-chan logs.Loggable, e <-chan error) error {
 	for {
 		select {
 		case msg, ok := <-c:
 			if !ok {
 				return nil
 			}
-			cmd.ui.Say("%s", msg.ToLog(time.Local))
+			err := cmd.printLog(msg)
+			if err != nil {
+				return err
+			}
 		case err := <-e:
 			return cmd.handleError(err)
 		}
 	}
 }
 
+// printLog prints msg unless it is excluded by the filter. With structured
+// output every message is printed to stdout as a record of its own, so that
+// the output can be consumed while the logs are still being tailed.
+func (cmd *Logs) printLog(msg logs.Loggable) error {
+	if !cmd.filter.Matches(msg) {
+		return nil
+	}
+
+	record := logs.NewRecord(msg)
+	record.App = cmd.appNames[msg.GetAppGUID()]
+
+	switch terminal.UserAskedForOutputFormat {
+	case terminal.JSONOutput:
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		cmd.ui.PrintCapturingNoOutput("%s\n", string(data))
+	case terminal.YAMLOutput:
+		data, err := yaml.Marshal(record)
+		if err != nil {
+			return err
+		}
+		cmd.ui.PrintCapturingNoOutput("---\n%s", string(data))
+	default:
+		cmd.ui.Say("%s%s", cmd.appPrefixes[msg.GetAppGUID()], msg.ToLog(time.Local))
+	}
+	return nil
+}
+
+// setAppPrefixes prepares the prefixes that tell the logs of the apps apart.
+// The names are padded to the same width so that the logs stay aligned.
+func (cmd *Logs) setAppPrefixes(apps []models.Application) {
+	width := 0
+	for _, app := range apps {
+		if len(app.Name) > width {
+			width = len(app.Name)
+		}
+	}
+
+	cmd.appNames = map[string]string{}
+	cmd.appPrefixes = map[string]string{}
+	for i, app := range apps {
+		cmd.appNames[app.GUID] = app.Name
+		padding := strings.Repeat(" ", width-len(app.Name))
+		cmd.appPrefixes[app.GUID] = terminal.LogAppNameColor("["+app.Name+"]", i) + padding + " "
+	}
+}
+
+func coloredAppNames(apps []models.Application) string {
+	names := make([]string, len(apps))
+	for i, app := range apps {
+		names[i] = terminal.LogAppNameColor(app.Name, i)
+	}
+	return strings.Join(names, ", ")
+}
+
+func newLogFilter(c flags.FlagContext, now time.Time) (logs.Filter, error) {
+	filter := logs.Filter{}
+
+	for _, value := range c.StringSlice("source") {
+		for _, sourceType := range strings.Split(value, ",") {
+			sourceType = strings.TrimSpace(sourceType)
+			if !logs.IsSourceType(sourceType) {
+				return logs.Filter{}, errors.New(T("Invalid source type {{.SourceType}}. Source type must be one of {{.SourceTypes}}",
+					map[string]interface{}{
+						"SourceType":  sourceType,
+						"SourceTypes": strings.Join(logs.SourceTypes, ", "),
+					}))
+			}
+			filter.SourceTypes = append(filter.SourceTypes, sourceType)
+		}
+	}
+
+	if c.IsSet("instance") {
+		if c.Int("instance") < 0 {
+			return logs.Filter{}, errors.New(T("Instance index must be a non-negative integer"))
+		}
+		filter.Instance = strconv.Itoa(c.Int("instance"))
+	}
+
+	if (c.IsSet("since") || c.IsSet("until")) && !c.Bool("recent") {
+		return logs.Filter{}, errors.New(T("The --since and --until flags can only be used with --recent"))
+	}
+
+	var err error
+	if c.IsSet("since") {
+		filter.Since, err = parseLogTime(c.String("since"), now)
+		if err != nil {
+			return logs.Filter{}, err
+		}
+	}
+	if c.IsSet("until") {
+		filter.Until, err = parseLogTime(c.String("until"), now)
+		if err != nil {
+			return logs.Filter{}, err
+		}
+	}
+
+	if c.IsSet("grep") {
+		filter.Pattern, err = regexp.Compile(c.String("grep"))
+		if err != nil {
+			return logs.Filter{}, errors.New(T("Invalid regular expression {{.Pattern}}: {{.Err}}",
+				map[string]interface{}{
+					"Pattern": c.String("grep"),
+					"Err":     err.Error(),
+				}))
+		}
+	}
+
+	return filter, nil
+}
+
+// parseLogTime accepts either a duration, counted back from now, or an RFC
+// 3339 timestamp.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New(T("Invalid time {{.Time}}. Use a duration such as 15m or an RFC 3339 timestamp such as 2006-01-02T15:04:05Z",
+			map[string]interface{}{"Time": value}))
+	}
+	return t, nil
+}
+
 func (cmd *Logs) handleError(err error) error {
 	switch err.(type) {
 	case nil:
@@ -1,8 +1,10 @@
 package application_test
 
 import (
+	"encoding/json"
 	"time"
 
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/api/logs"
 	"code.cloudfoundry.org/cli/cf/api/logs/logsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -10,6 +12,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testlogs "code.cloudfoundry.org/cli/testhelpers/logs"
@@ -26,6 +29,7 @@ var _ = Describe("logs command", func() {
 	var (
 		ui                  *testterm.FakeUI
 		logsRepo            *logsfakes.FakeRepository
+		appSummaryRepo      *apifakes.FakeAppSummaryRepository
 		requirementsFactory *requirementsfakes.FakeFactory
 		configRepo          coreconfig.Repository
 		deps                commandregistry.Dependency
@@ -34,6 +38,7 @@ var _ = Describe("logs command", func() {
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.RepoLocator = deps.RepoLocator.SetLogsRepository(logsRepo)
+		deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
 		deps.Config = configRepo
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("logs").SetDependency(deps, pluginCall))
 	}
@@ -42,6 +47,7 @@ var _ = Describe("logs command", func() {
 		ui = &testterm.FakeUI{}
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		logsRepo = new(logsfakes.FakeRepository)
+		appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 	})
 
@@ -59,6 +65,15 @@ var _ = Describe("logs command", func() {
 			))
 		})
 
+		It("fails with usage when called with app names and --space", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+
+			runCommand("--space", "my-app")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "--space flag cannot be combined with app names"},
+			))
+		})
+
 		It("fails requirements when not logged in", func() {
 			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{})
 
@@ -174,6 +189,245 @@ var _ = Describe("logs command", func() {
 			})
 		})
 
+		Describe("filters", func() {
+			var now time.Time
+
+			BeforeEach(func() {
+				now = time.Now()
+				logsRepo.RecentLogsForReturns([]logs.Loggable{
+					testlogs.NewLogMessage("app line 0", app.GUID, "APP/PROC/WEB", "0", logmessage.LogMessage_OUT, now.Add(-2*time.Hour)),
+					testlogs.NewLogMessage("app line 1", app.GUID, "APP/PROC/WEB", "1", logmessage.LogMessage_ERR, now.Add(-30*time.Minute)),
+					testlogs.NewLogMessage("router line", app.GUID, "RTR", "0", logmessage.LogMessage_OUT, now.Add(-10*time.Minute)),
+					testlogs.NewLogMessage("staging line", app.GUID, "STG", "0", logmessage.LogMessage_OUT, now.Add(-5*time.Minute)),
+				}, nil)
+			})
+
+			It("only shows the logs of the given source types", func() {
+				runCommand("--recent", "--source", "app", "--source", "STG", "my-app")
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"app line 0"},
+					[]string{"app line 1"},
+					[]string{"staging line"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"router line"}))
+			})
+
+			It("accepts a comma separated list of source types", func() {
+				runCommand("--recent", "--source", "RTR,STG", "my-app")
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"router line"}, []string{"staging line"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"app line"}))
+			})
+
+			It("fails when given an unknown source type", func() {
+				Expect(runCommand("--recent", "--source", "DEA", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Invalid source type DEA", "APP, RTR, STG, CELL"}))
+			})
+
+			It("only shows the logs of the given instance", func() {
+				runCommand("--recent", "--source", "APP", "--instance", "1", "my-app")
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"app line 1"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"app line 0"}))
+			})
+
+			It("only shows the logs written within the time window", func() {
+				runCommand("--recent", "--since", "1h", "--until", now.Add(-7*time.Minute).Format(time.RFC3339), "my-app")
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"app line 1"}, []string{"router line"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"app line 0"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"staging line"}))
+			})
+
+			It("fails when the time window is not a duration or timestamp", func() {
+				Expect(runCommand("--recent", "--since", "yesterday", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Invalid time yesterday"}))
+			})
+
+			It("fails when a time window is given without --recent", func() {
+				Expect(runCommand("--since", "1h", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"--since and --until flags can only be used with --recent"}))
+				Expect(logsRepo.TailLogsForCallCount()).To(Equal(0))
+			})
+
+			It("only shows the logs matching the regular expression", func() {
+				runCommand("--recent", "--grep", "^(router|staging)", "my-app")
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"router line"}, []string{"staging line"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"app line"}))
+			})
+
+			It("fails when the regular expression is invalid", func() {
+				Expect(runCommand("--recent", "--grep", "(", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Invalid regular expression ("}))
+			})
+
+			It("filters the tailed logs", func() {
+				runCommand("--grep", "nothing matches", "my-app")
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Connected, tailing logs for app"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Log Line 1"}))
+			})
+		})
+
+		Context("when JSON output is requested", func() {
+			var timestamp time.Time
+
+			BeforeEach(func() {
+				terminal.UserAskedForOutputFormat = terminal.JSONOutput
+				timestamp = time.Date(2016, 11, 2, 10, 30, 0, 500, time.UTC)
+				logsRepo.RecentLogsForReturns([]logs.Loggable{
+					testlogs.NewLogMessage("Log Line 1\n", app.GUID, "APP/PROC/WEB", "2", logmessage.LogMessage_ERR, timestamp),
+					testlogs.NewLogMessage("Log Line 2", app.GUID, "RTR", "0", logmessage.LogMessage_OUT, timestamp),
+				}, nil)
+			})
+
+			AfterEach(func() {
+				terminal.UserAskedForOutputFormat = terminal.TextOutput
+			})
+
+			It("prints one JSON object per log message", func() {
+				runCommand("--recent", "--source", "APP", "my-app")
+
+				var lines []string
+				for _, line := range ui.UncapturedOutput() {
+					if line != "" {
+						lines = append(lines, line)
+					}
+				}
+				Expect(lines).To(HaveLen(1))
+
+				var record map[string]string
+				Expect(json.Unmarshal([]byte(lines[0]), &record)).To(Succeed())
+				Expect(record).To(Equal(map[string]string{
+					"timestamp": "2016-11-02T10:30:00.0000005Z",
+					"source":    "APP/PROC/WEB",
+					"instance":  "2",
+					"stream":    "ERR",
+					"message":   "Log Line 1",
+				}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Log Line"}))
+			})
+		})
+
+		Context("when several apps are given", func() {
+			var now time.Time
+
+			BeforeEach(func() {
+				now = time.Now()
+				requirementsFactory.NewApplicationRequirementStub = func(name string) requirements.ApplicationRequirement {
+					applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+					applicationReq.GetApplicationReturns(models.Application{
+						ApplicationFields: models.ApplicationFields{Name: name, GUID: name + "-guid"},
+					})
+					return applicationReq
+				}
+
+				logsRepo.RecentLogsForStub = func(appGUID string) ([]logs.Loggable, error) {
+					switch appGUID {
+					case "app-a-guid":
+						return []logs.Loggable{
+							testlogs.NewLogMessage("a first", appGUID, "APP", "0", logmessage.LogMessage_OUT, now.Add(-3*time.Minute)),
+							testlogs.NewLogMessage("a third", appGUID, "APP", "0", logmessage.LogMessage_OUT, now.Add(-1*time.Minute)),
+						}, nil
+					default:
+						return []logs.Loggable{
+							testlogs.NewLogMessage("b second", appGUID, "APP", "0", logmessage.LogMessage_OUT, now.Add(-2*time.Minute)),
+						}, nil
+					}
+				}
+
+				logsRepo.TailLogsForAppsStub = func(appGUIDs []string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+					onConnect()
+					go func() {
+						for _, appGUID := range appGUIDs {
+							logChan <- testlogs.NewLogMessage("tailed line", appGUID, "APP", "0", logmessage.LogMessage_OUT, now)
+						}
+						close(logChan)
+						close(errChan)
+					}()
+				}
+			})
+
+			It("tails the logs of all the apps", func() {
+				runCommand("app-a", "app-bb")
+
+				Expect(logsRepo.TailLogsForCallCount()).To(Equal(0))
+				appGUIDs, _, _, _ := logsRepo.TailLogsForAppsArgsForCall(0)
+				Expect(appGUIDs).To(Equal([]string{"app-a-guid", "app-bb-guid"}))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Connected, tailing logs for apps", "app-a, app-bb", "my-org", "my-space", "my-user"},
+					[]string{"[app-a]  ", "tailed line"},
+					[]string{"[app-bb] ", "tailed line"},
+				))
+			})
+
+			It("merges the recent logs of all the apps by timestamp", func() {
+				runCommand("--recent", "app-a", "app-bb")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Connected, dumping recent logs for apps", "app-a, app-bb"},
+					[]string{"[app-a]", "a first"},
+					[]string{"[app-bb]", "b second"},
+					[]string{"[app-a]", "a third"},
+				))
+			})
+
+			It("includes the app name in structured output", func() {
+				terminal.UserAskedForOutputFormat = terminal.JSONOutput
+				defer func() { terminal.UserAskedForOutputFormat = terminal.TextOutput }()
+
+				runCommand("--recent", "app-a", "app-bb")
+
+				var apps []string
+				for _, line := range ui.UncapturedOutput() {
+					if line == "" {
+						continue
+					}
+					var record map[string]string
+					Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+					apps = append(apps, record["app"])
+				}
+				Expect(apps).To(Equal([]string{"app-a", "app-bb", "app-a"}))
+			})
+		})
+
+		Context("when --space is given", func() {
+			BeforeEach(func() {
+				appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{
+					{ApplicationFields: models.ApplicationFields{Name: "app-a", GUID: "app-a-guid"}},
+					{ApplicationFields: models.ApplicationFields{Name: "app-b", GUID: "app-b-guid"}},
+				}, nil)
+
+				logsRepo.TailLogsForAppsStub = func(appGUIDs []string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+					onConnect()
+					close(logChan)
+					close(errChan)
+				}
+			})
+
+			It("tails the logs of every app in the space", func() {
+				runCommand("--space")
+
+				Expect(requirementsFactory.NewApplicationRequirementCallCount()).To(Equal(0))
+				appGUIDs, _, _, _ := logsRepo.TailLogsForAppsArgsForCall(0)
+				Expect(appGUIDs).To(Equal([]string{"app-a-guid", "app-b-guid"}))
+			})
+
+			It("tails the logs of a space with a single app", func() {
+				appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{
+					{ApplicationFields: models.ApplicationFields{Name: "app-a", GUID: "app-a-guid"}},
+				}, nil)
+				runCommand("--space")
+
+				appGUIDs, _, _, _ := logsRepo.TailLogsForAppsArgsForCall(0)
+				Expect(appGUIDs).To(Equal([]string{"app-a-guid"}))
+			})
+
+			It("says so when the space has no apps", func() {
+				appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{}, nil)
+				runCommand("--space")
+
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"No apps found"}))
+				Expect(logsRepo.TailLogsForAppsCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when the loggregator server has a valid cert", func() {
 			It("tails logs", func() {
 				runCommand("my-app")
@@ -1,12 +1,17 @@
 package application
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -14,6 +19,7 @@ import (
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits"
 	"code.cloudfoundry.org/cli/cf/api/applications"
 	"code.cloudfoundry.org/cli/cf/api/authentication"
 	"code.cloudfoundry.org/cli/cf/api/stacks"
@@ -31,25 +37,33 @@ import (
 )
 
 type Push struct {
-	ui            terminal.UI
-	config        coreconfig.Reader
-	manifestRepo  manifest.Repository
-	appStarter    Starter
-	appStopper    Stopper
-	serviceBinder service.Binder
-	appRepo       applications.Repository
-	domainRepo    api.DomainRepository
-	routeRepo     api.RouteRepository
-	serviceRepo   api.ServiceRepository
-	stackRepo     stacks.StackRepository
-	authRepo      authentication.Repository
-	wordGenerator generator.WordGenerator
-	actor         actors.PushActor
-	routeActor    actors.RouteActor
-	zipper        appfiles.Zipper
-	appfiles      appfiles.AppFiles
+	ui             terminal.UI
+	config         coreconfig.Reader
+	manifestRepo   manifest.Repository
+	appStarter     Starter
+	appStopper     Stopper
+	serviceBinder  service.Binder
+	appRepo        applications.Repository
+	appSummaryRepo api.AppSummaryRepository
+	domainRepo     api.DomainRepository
+	routeRepo      api.RouteRepository
+	serviceRepo    api.ServiceRepository
+	stackRepo      stacks.StackRepository
+	authRepo       authentication.Repository
+	wordGenerator  generator.WordGenerator
+	actor          actors.PushActor
+	routeActor     actors.RouteActor
+	zipper         appfiles.Zipper
+	appfiles       appfiles.AppFiles
+	resourceCache  *appfiles.ResourceCache
+	deps           commandregistry.Dependency
 }
 
+const (
+	BlueGreenStrategy = "blue-green"
+	venerableSuffix   = "-venerable"
+)
+
 func init() {
 	commandregistry.Register(&Push{})
 }
@@ -73,8 +87,16 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 	fs["no-manifest"] = &flags.BoolFlag{Name: "no-manifest", Usage: T("Ignore manifest file")}
 	fs["no-route"] = &flags.BoolFlag{Name: "no-route", Usage: T("Do not map a route to this app and remove routes from previous pushes of this app")}
 	fs["no-start"] = &flags.BoolFlag{Name: "no-start", Usage: T("Do not start an app after pushing")}
+	fs["honor-gitignore"] = &flags.BoolFlag{Name: "honor-gitignore", Usage: T("Also exclude the files matched by .gitignore files from the upload")}
+	fs["show-ignored"] = &flags.BoolFlag{Name: "show-ignored", Usage: T("List the files excluded from the upload by .cfignore and .gitignore files")}
+	fs["no-cache"] = &flags.BoolFlag{Name: "no-cache", Usage: T("Ignore the local cache of file hashes and known resources, rechecking every app file")}
+	fs["dry-run"] = &flags.BoolFlag{Name: "dry-run", Usage: T("Show the changes the push would make without making them")}
 	fs["random-route"] = &flags.BoolFlag{Name: "random-route", Usage: T("Create a random route for this app")}
 	fs["route-path"] = &flags.StringFlag{Name: "route-path", Usage: T("Path for the route")}
+	fs["var"] = &flags.StringSliceFlag{Name: "var", Usage: T("Variable key value pair for variable substitution in the manifest (e.g. name=app1), flag can be specified multiple times")}
+	fs["vars-file"] = &flags.StringSliceFlag{Name: "vars-file", Usage: T("Path to a YAML file with variables for substitution in the manifest, flag can be specified multiple times")}
+	fs["parallel"] = &flags.IntFlag{Name: "parallel", Usage: T("Number of apps from the manifest to upload and stage at the same time")}
+	fs["strategy"] = &flags.StringFlag{Name: "strategy", Usage: T("Deployment strategy for existing apps, 'blue-green' replaces the app without downtime")}
 	// Hidden:true to hide app-ports for release #117189491
 	fs["app-ports"] = &flags.StringFlag{Name: "app-ports", Usage: T("Comma delimited list of ports the application may listen on"), Hidden: true}
 
@@ -102,14 +124,23 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 			fmt.Sprintf("[-u %s] ", T("HEALTH_CHECK_TYPE")),
 			fmt.Sprintf("[--route-path %s] ", T("ROUTE_PATH")),
 			"\n   ",
+			fmt.Sprintf("[--strategy %s] ", T("STRATEGY")),
+			fmt.Sprintf("[--var %s] ", T("KEY=VALUE")),
+			fmt.Sprintf("[--vars-file %s] ", T("VARS_FILE_PATH")),
+			"\n   ",
 			// Commented to hide app-ports for release #117189491
 			// fmt.Sprintf("[--app-ports %s] ", T("APP_PORTS")),
-			"[--no-hostname] [--no-manifest] [--no-route] [--no-start] [--random-route]\n",
+			"[--no-hostname] [--no-manifest] [--no-route] [--no-start] [--no-cache] [--random-route] [--dry-run]",
+			"\n   ",
+			"[--honor-gitignore] [--show-ignored]\n",
 			"\n   ",
 			T("Push multiple apps with a manifest"),
 			":\n   ",
 			"CF_NAME push ",
 			fmt.Sprintf("[-f %s] ", T("MANIFEST_PATH")),
+			fmt.Sprintf("[--var %s] ", T("KEY=VALUE")),
+			fmt.Sprintf("[--vars-file %s] ", T("VARS_FILE_PATH")),
+			fmt.Sprintf("[--parallel %s]", T("NUM_APPS")),
 		},
 		Flags: fs,
 	}
@@ -163,6 +194,7 @@ func (cmd *Push) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.serviceBinder = appCommand.(service.Binder)
 
 	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
 	cmd.domainRepo = deps.RepoLocator.GetDomainRepository()
 	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
@@ -173,11 +205,32 @@ func (cmd *Push) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.routeActor = deps.RouteActor
 	cmd.zipper = deps.AppZipper
 	cmd.appfiles = deps.AppFiles
+	cmd.resourceCache = deps.ResourceCache
+	cmd.deps = deps
 
 	return cmd
 }
 
 func (cmd *Push) Execute(c flags.FlagContext) error {
+	if strategy := c.String("strategy"); strategy != "" {
+		if strategy != BlueGreenStrategy {
+			return errors.New(T("Invalid strategy: {{.Strategy}}\nThe only supported strategy is '{{.BlueGreen}}'",
+				map[string]interface{}{"Strategy": strategy, "BlueGreen": BlueGreenStrategy}))
+		}
+
+		if c.Bool("no-start") {
+			return errors.New(T("Option '--strategy' cannot be used with '--no-start'"))
+		}
+	}
+
+	if c.IsSet("parallel") && c.Int("parallel") < 1 {
+		return errors.New(T("Option '--parallel' must be at least 1"))
+	}
+
+	if c.Bool("no-cache") {
+		cmd.resourceCache.Bypass()
+	}
+
 	appsFromManifest, err := cmd.getAppParamsFromManifest(c)
 	if err != nil {
 		return err
@@ -214,106 +267,484 @@ func (cmd *Push) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if c.Bool("dry-run") {
+		return cmd.planPush(appSet, c)
+	}
+
 	for _, appParams := range appSet {
 		if appParams.Name == nil {
 			return errors.New(T("Error: No name found for app"))
 		}
+	}
 
-		err = cmd.fetchStackGUID(&appParams)
+	if parallel := c.Int("parallel"); parallel > 1 && len(appSet) > 1 {
+		return cmd.pushInParallel(appSet, appFromContext, c, parallel)
+	}
+
+	for _, appParams := range appSet {
+		err = cmd.pushApp(appParams, appFromContext, c)
 		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
+
+// pushApp creates or updates a single app of the app set and deploys it.
+func (cmd *Push) pushApp(appParams models.AppParams, appFromContext models.AppParams, c flags.FlagContext) error {
+	err := cmd.fetchStackGUID(&appParams)
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("docker-image") {
+		diego := true
+		appParams.Diego = &diego
+	}
 
-		if c.IsSet("docker-image") {
-			diego := true
-			appParams.Diego = &diego
+	var app, existingApp models.Application
+	existingApp, err = cmd.appRepo.Read(*appParams.Name)
+	switch err.(type) {
+	case nil:
+		if c.String("strategy") == BlueGreenStrategy {
+			return cmd.blueGreenPush(existingApp, appParams, appFromContext, c)
 		}
 
-		var app, existingApp models.Application
-		existingApp, err = cmd.appRepo.Read(*appParams.Name)
-		switch err.(type) {
-		case nil:
-			cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
-				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(existingApp.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
-
-			if appParams.EnvironmentVars != nil {
-				for key, val := range existingApp.EnvironmentVars {
-					if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
-						(*appParams.EnvironmentVars)[key] = val
-					}
+		cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(existingApp.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+		if appParams.EnvironmentVars != nil {
+			for key, val := range existingApp.EnvironmentVars {
+				if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
+					(*appParams.EnvironmentVars)[key] = val
 				}
 			}
+		}
 
-			app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
-			if err != nil {
-				return err
-			}
-		case *errors.ModelNotFoundError:
-			spaceGUID := cmd.config.SpaceFields().GUID
-			appParams.SpaceGUID = &spaceGUID
-
-			cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
-				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(*appParams.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
-
-			app, err = cmd.appRepo.Create(appParams)
-			if err != nil {
-				return err
-			}
-		default:
+		app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
+		if err != nil {
 			return err
 		}
+	case *errors.ModelNotFoundError:
+		spaceGUID := cmd.config.SpaceFields().GUID
+		appParams.SpaceGUID = &spaceGUID
 
-		cmd.ui.Ok()
-		cmd.ui.Say("")
+		cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(*appParams.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
 
-		err = cmd.updateRoutes(app, appParams, appFromContext)
+		app, err = cmd.appRepo.Create(appParams)
 		if err != nil {
 			return err
 		}
+	default:
+		return err
+	}
 
-		if c.String("docker-image") == "" {
-			err = cmd.actor.ProcessPath(*appParams.Path, cmd.processPathCallback(*appParams.Path, app))
-			if err != nil {
-				return errors.New(
-					T("Error processing app files: {{.Error}}",
-						map[string]interface{}{
-							"Error": err.Error(),
-						}),
-				)
-			}
-		}
+	cmd.ui.Ok()
+	cmd.ui.Say("")
 
-		if appParams.ServicesToBind != nil {
-			err = cmd.bindAppToServices(appParams.ServicesToBind, app)
-			if err != nil {
-				return err
-			}
+	return cmd.deployApp(app, appParams, appFromContext, c)
+}
+
+// pushInParallel pushes up to parallel apps of the app set at a time. Each
+// app is pushed by a worker of its own, whose output is printed in one block
+// prefixed with the app name once the app is done. A failing app does not
+// stop the others; the summary at the end lists the apps that failed.
+func (cmd *Push) pushInParallel(appSet []models.AppParams, appFromContext models.AppParams, c flags.FlagContext, parallel int) error {
+	cmd.ui.Say(T("Pushing {{.AppCount}} apps, up to {{.Parallel}} at a time...",
+		map[string]interface{}{"AppCount": len(appSet), "Parallel": parallel}))
+	cmd.ui.Say("")
+
+	// the workers copy the registered commands, so they are all created
+	// before any of them starts using its copies
+	workers := make([]*Push, len(appSet))
+	outputs := make([]*bytes.Buffer, len(appSet))
+	for i := range appSet {
+		outputs[i] = new(bytes.Buffer)
+		workers[i] = cmd.newParallelWorker(outputs[i])
+	}
+
+	errs := make([]error, len(appSet))
+	slots := make(chan struct{}, parallel)
+	outputMutex := new(sync.Mutex)
+	wg := new(sync.WaitGroup)
+
+	for i, appParams := range appSet {
+		wg.Add(1)
+		go func(i int, appParams models.AppParams) {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			errs[i] = workers[i].pushApp(appParams, appFromContext, c)
+			<-slots
+
+			outputMutex.Lock()
+			defer outputMutex.Unlock()
+			cmd.printParallelOutput(*appParams.Name, outputs[i].String(), errs[i])
+		}(i, appParams)
+	}
+	wg.Wait()
+
+	return cmd.printParallelPushSummary(appSet, errs)
+}
+
+// newParallelWorker returns a copy of cmd that writes its output to output.
+// The start, stop and bind-service commands and the logs repository keep
+// state while an app is pushed, and the actors write to the UI they were
+// built with, so the worker gets copies of its own.
+func (cmd *Push) newParallelWorker(output io.Writer) *Push {
+	deps := cmd.deps
+	deps.UI = terminal.NewUI(os.Stdin, output, terminal.NewTeePrinter(output), deps.Logger)
+	deps.RepoLocator = deps.RepoLocator.SetLogsRepository(api.NewLogsRepository(deps.Config, deps.RepoLocator.GetAuthenticationRepository(), deps.Logger))
+	deps.RouteActor = actors.NewRouteActor(deps.UI, deps.RepoLocator.GetRouteRepository(), deps.RepoLocator.GetDomainRepository())
+	deps.PushActor = actors.NewPushActor(deps.RepoLocator.GetApplicationBitsRepository(), deps.AppZipper, deps.AppFiles, deps.RouteActor)
+
+	worker := *cmd
+	worker.ui = deps.UI
+	worker.actor = deps.PushActor
+	worker.routeActor = deps.RouteActor
+	worker.appStopper = copyCommand(cmd.appStopper).SetDependency(deps, false).(Stopper)
+	worker.serviceBinder = copyCommand(cmd.serviceBinder.(commandregistry.Command)).SetDependency(deps, false).(service.Binder)
+
+	worker.appStarter = copyCommand(cmd.appStarter).SetDependency(deps, false).(Starter)
+	if starter, ok := worker.appStarter.(*Start); ok {
+		starter.appDisplayer = copyCommand(commandregistry.Commands.FindCommand("app")).SetDependency(deps, false).(Displayer)
+	}
+
+	return &worker
+}
+
+// copyCommand returns a copy of a registered command, which can be given
+// dependencies without changing the registered command.
+func copyCommand(cmd commandregistry.Command) commandregistry.Command {
+	value := reflect.ValueOf(cmd)
+	if value.Kind() != reflect.Ptr {
+		return cmd
+	}
+
+	cmdCopy := reflect.New(value.Elem().Type())
+	cmdCopy.Elem().Set(value.Elem())
+	return cmdCopy.Interface().(commandregistry.Command)
+}
+
+func (cmd *Push) printParallelOutput(appName string, output string, err error) {
+	prefix := fmt.Sprintf("[%s] ", appName)
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if err != nil {
+		lines = append(lines, terminal.FailureColor(T("FAILED")))
+		lines = append(lines, strings.Split(err.Error(), "\n")...)
+	}
+
+	for _, line := range lines {
+		cmd.ui.Say(prefix + line)
+	}
+	cmd.ui.Say("")
+}
+
+func (cmd *Push) printParallelPushSummary(appSet []models.AppParams, errs []error) error {
+	cmd.ui.Say(T("Push summary:"))
+	cmd.ui.Say("")
+
+	failedApps := []string{}
+	table := cmd.ui.Table([]string{T("app"), T("status")})
+	for i, appParams := range appSet {
+		status := terminal.SuccessColor(T("pushed"))
+		if errs[i] != nil {
+			status = terminal.FailureColor(T("failed"))
+			failedApps = append(failedApps, *appParams.Name)
 		}
+		table.Add(*appParams.Name, status)
+	}
+
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+
+	if len(failedApps) > 0 {
+		cmd.ui.Say("")
+		return errors.New(T("{{.FailedCount}} of {{.AppCount}} apps failed to push: {{.AppNames}}",
+			map[string]interface{}{
+				"FailedCount": len(failedApps),
+				"AppCount":    len(appSet),
+				"AppNames":    strings.Join(failedApps, ", "),
+			}))
+	}
+
+	return nil
+}
 
-		err = cmd.restart(app, appParams, c)
+// deployApp maps the routes of a created or updated app, uploads its bits,
+// binds its services and (re)starts it.
+func (cmd *Push) deployApp(app models.Application, appParams models.AppParams, appFromContext models.AppParams, c flags.FlagContext) error {
+	err := cmd.updateRoutes(app, appParams, appFromContext)
+	if err != nil {
+		return err
+	}
+
+	if c.String("docker-image") == "" {
+		err = cmd.actor.ProcessPath(*appParams.Path, cmd.processPathCallback(*appParams.Path, app, c))
 		if err != nil {
 			return errors.New(
-				T("Error restarting application: {{.Error}}",
+				T("Error processing app files: {{.Error}}",
 					map[string]interface{}{
 						"Error": err.Error(),
 					}),
 			)
 		}
 	}
+
+	if appParams.ServicesToBind != nil {
+		err = cmd.bindAppToServices(appParams.ServicesToBind, app)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cmd.restart(app, appParams, c)
+	if err != nil {
+		return errors.New(
+			T("Error restarting application: {{.Error}}",
+				map[string]interface{}{
+					"Error": err.Error(),
+				}),
+		)
+	}
+
 	return nil
 }
 
-func (cmd *Push) processPathCallback(path string, app models.Application) func(string) error {
+// blueGreenPush replaces an existing app without downtime. The existing app
+// is renamed to its venerable name and keeps serving its routes while the new
+// version is pushed under the original name. Once the new version runs, the
+// routes are moved over and the venerable app is deleted. Any failure before
+// that point rolls back to the existing app.
+func (cmd *Push) blueGreenPush(existingApp models.Application, appParams models.AppParams, appFromContext models.AppParams, c flags.FlagContext) error {
+	venerableName := existingApp.Name + venerableSuffix
+
+	_, err := cmd.appRepo.Read(venerableName)
+	switch err.(type) {
+	case nil:
+		return errors.New(T("App {{.AppName}} already exists. Delete it before pushing with the '{{.BlueGreen}}' strategy.",
+			map[string]interface{}{"AppName": venerableName, "BlueGreen": BlueGreenStrategy}))
+	case *errors.ModelNotFoundError:
+	default:
+		return err
+	}
+
+	newParams, err := cmd.inheritAppParams(existingApp, appParams)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Renaming app {{.AppName}} to {{.NewName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(existingApp.Name),
+			"NewName":   terminal.EntityNameColor(venerableName),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	_, err = cmd.appRepo.Update(existingApp.GUID, models.AppParams{Name: &venerableName})
+	if err != nil {
+		return err
+	}
+	venerableApp := existingApp
+	venerableApp.Name = venerableName
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(*newParams.Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	app, err := cmd.appRepo.Create(newParams)
+	if err != nil {
+		return cmd.rollBackBlueGreenPush(venerableApp, existingApp.Name, nil, err)
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	err = cmd.deployApp(app, newParams, appFromContext, c)
+	if err != nil {
+		return cmd.rollBackBlueGreenPush(venerableApp, existingApp.Name, &app, err)
+	}
+
+	err = cmd.moveRoutes(venerableApp, app)
+	if err != nil {
+		return cmd.rollBackBlueGreenPush(venerableApp, existingApp.Name, &app, err)
+	}
+
+	cmd.ui.Say(T("Deleting app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(venerableName),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	err = cmd.appRepo.Delete(venerableApp.GUID)
+	if err != nil {
+		cmd.ui.Warn(T("Could not delete app {{.AppName}}: {{.Err}}\nThe new version of {{.NewAppName}} is running, delete {{.AppName}} once it is no longer needed.",
+			map[string]interface{}{"AppName": venerableName, "NewAppName": app.Name, "Err": err.Error()}))
+		return nil
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
+// inheritAppParams fills in the settings of the existing app that the push
+// does not specify, so the replacing app keeps them the way an in-place
+// update would.
+func (cmd *Push) inheritAppParams(existingApp models.Application, appParams models.AppParams) (models.AppParams, error) {
+	if appParams.BuildpackURL == nil && existingApp.BuildpackURL != "" {
+		appParams.BuildpackURL = &existingApp.BuildpackURL
+	}
+	if appParams.Command == nil && existingApp.Command != "" {
+		appParams.Command = &existingApp.Command
+	}
+	if appParams.DiskQuota == nil && existingApp.DiskQuota != 0 {
+		appParams.DiskQuota = &existingApp.DiskQuota
+	}
+	if appParams.DockerImage == nil && existingApp.DockerImage != "" {
+		appParams.DockerImage = &existingApp.DockerImage
+	}
+	if appParams.HealthCheckType == nil && existingApp.HealthCheckType != "" {
+		appParams.HealthCheckType = &existingApp.HealthCheckType
+	}
+	if appParams.InstanceCount == nil && existingApp.InstanceCount != 0 {
+		appParams.InstanceCount = &existingApp.InstanceCount
+	}
+	if appParams.Memory == nil && existingApp.Memory != 0 {
+		appParams.Memory = &existingApp.Memory
+	}
+	if appParams.StackGUID == nil && existingApp.Stack != nil {
+		appParams.StackGUID = &existingApp.Stack.GUID
+	}
+
+	envVars := map[string]interface{}{}
+	for key, val := range existingApp.EnvironmentVars {
+		envVars[key] = val
+	}
+	if appParams.EnvironmentVars != nil {
+		for key, val := range *appParams.EnvironmentVars {
+			envVars[key] = val
+		}
+	}
+	appParams.EnvironmentVars = &envVars
+
+	summary, err := cmd.appSummaryRepo.GetSummary(existingApp.GUID)
+	if err != nil {
+		return models.AppParams{}, err
+	}
+	for _, boundService := range summary.Services {
+		if !stringInSlice(boundService.Name, appParams.ServicesToBind) {
+			appParams.ServicesToBind = append(appParams.ServicesToBind, boundService.Name)
+		}
+	}
+
+	spaceGUID := cmd.config.SpaceFields().GUID
+	appParams.SpaceGUID = &spaceGUID
+	appParams.GUID = nil
+	appParams.State = nil
+
+	return appParams, nil
+}
+
+// moveRoutes binds every route of the old app to the new app and then
+// removes them from the old app.
+func (cmd *Push) moveRoutes(oldApp models.Application, newApp models.Application) error {
+	newApp, err := cmd.appRepo.Read(newApp.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, route := range oldApp.Routes {
+		err = cmd.routeActor.BindRoute(newApp, routeFromSummary(route))
+		if err != nil {
+			return err
+		}
+	}
+
+	return cmd.routeActor.UnbindAll(oldApp)
+}
+
+// rollBackBlueGreenPush deletes the new app, if it was created, and restores
+// the venerable app with its name and routes.
+func (cmd *Push) rollBackBlueGreenPush(venerableApp models.Application, originalName string, newApp *models.Application, cause error) error {
+	cmd.ui.Warn(T("Push of {{.AppName}} failed, rolling back to the previous version...",
+		map[string]interface{}{"AppName": originalName}))
+
+	if newApp != nil {
+		err := cmd.appRepo.Delete(newApp.GUID)
+		if err != nil {
+			return cmd.rollBackFailed(venerableApp.Name, cause, err)
+		}
+	}
+
+	_, err := cmd.appRepo.Update(venerableApp.GUID, models.AppParams{Name: &originalName})
+	if err != nil {
+		return cmd.rollBackFailed(venerableApp.Name, cause, err)
+	}
+
+	restoredApp, err := cmd.appRepo.Read(originalName)
+	if err != nil {
+		return cmd.rollBackFailed(venerableApp.Name, cause, err)
+	}
+
+	for _, route := range venerableApp.Routes {
+		err = cmd.routeActor.BindRoute(restoredApp, routeFromSummary(route))
+		if err != nil {
+			return cmd.rollBackFailed(venerableApp.Name, cause, err)
+		}
+	}
+
+	return errors.New(T("{{.Err}}\n\nApp {{.AppName}} was rolled back to the previous version.",
+		map[string]interface{}{"Err": cause.Error(), "AppName": originalName}))
+}
+
+func (cmd *Push) rollBackFailed(venerableName string, cause error, err error) error {
+	return errors.New(T("{{.Err}}\n\nRolling back failed: {{.RollbackErr}}\nThe previous version is still available as app {{.AppName}}.",
+		map[string]interface{}{"Err": cause.Error(), "RollbackErr": err.Error(), "AppName": venerableName}))
+}
+
+func routeFromSummary(route models.RouteSummary) models.Route {
+	return models.Route{
+		GUID:   route.GUID,
+		Host:   route.Host,
+		Domain: route.Domain,
+		Path:   route.Path,
+		Port:   route.Port,
+	}
+}
+
+func stringInSlice(value string, slice []string) bool {
+	for _, s := range slice {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (cmd *Push) processPathCallback(path string, app models.Application, c flags.FlagContext) func(string) error {
 	return func(appDir string) error {
-		localFiles, err := cmd.appfiles.AppFilesInDir(appDir)
+		localFiles, err := cmd.appfiles.AppFilesInDir(appDir, ignoreOptions(c))
 		if err != nil {
 			return errors.New(
 				T("Error processing app files in '{{.Path}}': {{.Error}}",
@@ -331,6 +762,13 @@ func (cmd *Push) processPathCallback(path string, app models.Application) func(s
 					}))
 		}
 
+		if c.Bool("show-ignored") {
+			err = cmd.showIgnoredFiles(path, appDir, c)
+			if err != nil {
+				return err
+			}
+		}
+
 		cmd.ui.Say(T("Uploading {{.AppName}}...",
 			map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
 
@@ -344,6 +782,35 @@ func (cmd *Push) processPathCallback(path string, app models.Application) func(s
 	}
 }
 
+func ignoreOptions(c flags.FlagContext) appfiles.IgnoreOptions {
+	return appfiles.IgnoreOptions{GitIgnore: c.Bool("honor-gitignore")}
+}
+
+// showIgnoredFiles lists the files of appDir that are not uploaded because of
+// its ignore files.
+func (cmd *Push) showIgnoredFiles(path string, appDir string, c flags.FlagContext) error {
+	ignoredFiles, err := cmd.appfiles.IgnoredFilesInDir(appDir, ignoreOptions(c))
+	if err != nil {
+		return errors.New(
+			T("Error processing app files in '{{.Path}}': {{.Error}}",
+				map[string]interface{}{
+					"Path":  path,
+					"Error": err.Error(),
+				}))
+	}
+
+	if len(ignoredFiles) == 0 {
+		cmd.ui.Say(T("No files ignored in '{{.Path}}'", map[string]interface{}{"Path": path}))
+		return nil
+	}
+
+	cmd.ui.Say(T("Files ignored in '{{.Path}}':", map[string]interface{}{"Path": path}))
+	for _, ignoredFile := range ignoredFiles {
+		cmd.ui.Say("  " + ignoredFile)
+	}
+	return nil
+}
+
 func (cmd *Push) updateRoutes(app models.Application, appParams models.AppParams, appParamsFromContext models.AppParams) error {
 	defaultRouteAcceptable := len(app.Routes) == 0
 	routeDefined := appParams.Domains != nil || !appParams.IsHostEmpty() || appParams.IsNoHostnameTrue()
@@ -444,19 +911,7 @@ func (cmd *Push) createAndBindRoute(
 	domain models.DomainFields,
 	routePath *string,
 ) error {
-	var hostname string
-	if !noHostName {
-		switch {
-		case host != nil:
-			hostname = *host
-		case UseRandomPort:
-			//do nothing
-		case UseRandomRoute:
-			hostname = hostNameForString(app.Name) + "-" + cmd.wordGenerator.Babble()
-		default:
-			hostname = hostNameForString(app.Name)
-		}
-	}
+	hostname := cmd.routeHostname(host, UseRandomRoute, UseRandomPort, app.Name, noHostName)
 
 	var route models.Route
 	var err error
@@ -471,6 +926,23 @@ func (cmd *Push) createAndBindRoute(
 	return cmd.routeActor.BindRoute(app, route)
 }
 
+func (cmd *Push) routeHostname(host *string, useRandomRoute bool, useRandomPort bool, appName string, noHostName bool) string {
+	var hostname string
+	if !noHostName {
+		switch {
+		case host != nil:
+			hostname = *host
+		case useRandomPort:
+			//do nothing
+		case useRandomRoute:
+			hostname = hostNameForString(appName) + "-" + cmd.wordGenerator.Babble()
+		default:
+			hostname = hostNameForString(appName)
+		}
+	}
+	return hostname
+}
+
 var forbiddenHostCharRegex = regexp.MustCompile("[^a-z0-9-]")
 var whitespaceRegex = regexp.MustCompile(`[\s_]+`)
 
@@ -569,6 +1041,270 @@ func (cmd *Push) restart(app models.Application, params models.AppParams, c flag
 	return nil
 }
 
+// planPush shows what pushing the app set would change, comparing the
+// desired state with the current state of each app without modifying
+// anything.
+func (cmd *Push) planPush(appSet []models.AppParams, c flags.FlagContext) error {
+	cmd.ui.Say(T("Planning push in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	table := cmd.ui.Table([]string{T("app"), T("action"), T("resource"), T("current"), T("desired")})
+	table.SetFieldNames("app", "action", "resource", "current", "desired")
+
+	for _, appParams := range appSet {
+		if appParams.Name == nil {
+			return errors.New(T("Error: No name found for app"))
+		}
+
+		err := cmd.fetchStackGUID(&appParams)
+		if err != nil {
+			return err
+		}
+
+		err = cmd.planApp(table, appParams, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("show-ignored") {
+		for _, appParams := range appSet {
+			if appParams.Path == nil || appParams.DockerImage != nil {
+				continue
+			}
+
+			path := *appParams.Path
+			cmd.ui.Say("")
+			err = cmd.actor.ProcessPath(path, func(appDir string) error {
+				return cmd.showIgnoredFiles(path, appDir, c)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Dry run complete, no changes were made."))
+	return nil
+}
+
+func (cmd *Push) planApp(table *terminal.UITable, appParams models.AppParams, c flags.FlagContext) error {
+	name := *appParams.Name
+
+	existingApp, err := cmd.appRepo.Read(name)
+	exists := true
+	switch err.(type) {
+	case nil:
+	case *errors.ModelNotFoundError:
+		exists = false
+		existingApp = models.Application{}
+		table.Add(name, T("create"), T("app"), "", "")
+	default:
+		return err
+	}
+
+	addChange := func(resource string, current string, desired string) {
+		if !exists {
+			current = ""
+		} else if current == desired {
+			return
+		}
+		table.Add(name, T("change"), resource, current, desired)
+	}
+
+	if appParams.Memory != nil {
+		addChange(T("memory"), formatters.ByteSize(existingApp.Memory*formatters.MEGABYTE), formatters.ByteSize(*appParams.Memory*formatters.MEGABYTE))
+	}
+	if appParams.InstanceCount != nil {
+		addChange(T("instances"), strconv.Itoa(existingApp.InstanceCount), strconv.Itoa(*appParams.InstanceCount))
+	}
+	if appParams.DiskQuota != nil {
+		addChange(T("disk"), formatters.ByteSize(existingApp.DiskQuota*formatters.MEGABYTE), formatters.ByteSize(*appParams.DiskQuota*formatters.MEGABYTE))
+	}
+	if appParams.BuildpackURL != nil {
+		addChange(T("buildpack"), existingApp.BuildpackURL, *appParams.BuildpackURL)
+	}
+	if appParams.Command != nil {
+		addChange(T("command"), existingApp.Command, *appParams.Command)
+	}
+	if appParams.HealthCheckType != nil {
+		addChange(T("health check type"), existingApp.HealthCheckType, *appParams.HealthCheckType)
+	}
+	if appParams.StackName != nil {
+		var currentStack string
+		if existingApp.Stack != nil {
+			currentStack = existingApp.Stack.Name
+		}
+		addChange(T("stack"), currentStack, *appParams.StackName)
+	}
+	if appParams.DockerImage != nil {
+		addChange(T("docker image"), existingApp.DockerImage, *appParams.DockerImage)
+	}
+
+	if appParams.EnvironmentVars != nil {
+		var keys []string
+		for key := range *appParams.EnvironmentVars {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			currentValue, ok := existingApp.EnvironmentVars[key]
+			if ok && fmt.Sprint(currentValue) == fmt.Sprint((*appParams.EnvironmentVars)[key]) {
+				continue
+			}
+
+			current := ""
+			if ok {
+				current = T("(hidden)")
+			}
+			table.Add(name, T("change"), T("env {{.Name}}", map[string]interface{}{"Name": key}), current, T("(hidden)"))
+		}
+	}
+
+	err = cmd.planRoutes(table, existingApp, appParams)
+	if err != nil {
+		return err
+	}
+
+	boundServices := map[string]bool{}
+	if exists {
+		summary, err := cmd.appSummaryRepo.GetSummary(existingApp.GUID)
+		if err != nil {
+			return err
+		}
+		for _, boundService := range summary.Services {
+			boundServices[boundService.Name] = true
+		}
+	}
+	for _, serviceName := range appParams.ServicesToBind {
+		if !boundServices[serviceName] {
+			table.Add(name, T("bind"), T("service"), "", serviceName)
+		}
+	}
+
+	if appParams.DockerImage == nil && appParams.Path != nil {
+		table.Add(name, T("upload"), T("app files"), "", *appParams.Path)
+	}
+
+	if !c.Bool("no-start") {
+		if exists {
+			table.Add(name, T("restart"), T("app"), "", "")
+		} else {
+			table.Add(name, T("start"), T("app"), "", "")
+		}
+	}
+
+	return nil
+}
+
+// planRoutes mirrors updateRoutes, adding the routes it would create, map or
+// unmap to the plan.
+func (cmd *Push) planRoutes(table *terminal.UITable, app models.Application, appParams models.AppParams) error {
+	name := *appParams.Name
+
+	mappedRoutes := map[string]bool{}
+	for _, route := range app.Routes {
+		mappedRoutes[route.URL()] = true
+	}
+
+	defaultRouteAcceptable := len(app.Routes) == 0
+	routeDefined := appParams.Domains != nil || !appParams.IsHostEmpty() || appParams.IsNoHostnameTrue()
+
+	switch {
+	case appParams.NoRoute:
+		for _, route := range app.Routes {
+			table.Add(name, T("unmap"), T("route"), route.URL(), "")
+		}
+		return nil
+	case len(appParams.Routes) > 0:
+		for _, manifestRoute := range appParams.Routes {
+			if !mappedRoutes[manifestRoute.Route] {
+				table.Add(name, T("map"), T("route"), "", manifestRoute.Route)
+			}
+		}
+		return nil
+	case !routeDefined && !defaultRouteAcceptable:
+		return nil
+	}
+
+	var domains []models.DomainFields
+	if appParams.Domains == nil {
+		domain, err := cmd.findDomain(nil)
+		if err != nil {
+			return err
+		}
+		domains = append(domains, domain)
+	} else {
+		for _, domainName := range appParams.Domains {
+			domain, err := cmd.findDomain(&domainName)
+			if err != nil {
+				return err
+			}
+			domains = append(domains, domain)
+		}
+	}
+
+	hosts := []*string{nil}
+	if !appParams.IsHostEmpty() {
+		hosts = nil
+		for i := range appParams.Hosts {
+			hosts = append(hosts, &appParams.Hosts[i])
+		}
+	}
+
+	var path string
+	if appParams.RoutePath != nil {
+		path = *appParams.RoutePath
+	}
+
+	for _, domain := range domains {
+		if isTCP(domain) {
+			table.Add(name, T("create and map"), T("route"), "", T("{{.Domain}}:(random port)", map[string]interface{}{"Domain": domain.Name}))
+			continue
+		}
+
+		for _, host := range hosts {
+			// The hostname of a random route is only chosen by the real push.
+			if host == nil && appParams.UseRandomRoute && !appParams.IsNoHostnameTrue() {
+				url := (&models.RoutePresenter{Host: T("<random-route>"), Domain: domain.Name, Path: path}).URL()
+				table.Add(name, T("create and map"), T("route"), "", url)
+				continue
+			}
+
+			hostname := cmd.routeHostname(host, appParams.UseRandomRoute, false, name, appParams.IsNoHostnameTrue())
+			url := (&models.RoutePresenter{Host: hostname, Domain: domain.Name, Path: path}).URL()
+			if mappedRoutes[url] {
+				continue
+			}
+
+			_, err := cmd.routeRepo.Find(hostname, domain, path, 0)
+			switch err.(type) {
+			case nil:
+				table.Add(name, T("map"), T("route"), "", url)
+			case *errors.ModelNotFoundError:
+				table.Add(name, T("create and map"), T("route"), "", url)
+			default:
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppParams, error) {
 	if c.Bool("no-manifest") {
 		return []models.AppParams{}, nil
@@ -594,6 +1330,11 @@ func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppPara
 		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
 	}
 
+	m.Variables, err = manifestVariables(c)
+	if err != nil {
+		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
 	apps, err := m.Applications()
 	if err != nil {
 		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
@@ -604,6 +1345,22 @@ func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppPara
 	return apps, nil
 }
 
+// manifestVariables collects the variables given with --vars-file and --var.
+// Variables given with --var take precedence over those read from files.
+func manifestVariables(c flags.FlagContext) (manifest.Variables, error) {
+	fileVars, err := manifest.ReadVariablesFiles(c.StringSlice("vars-file"))
+	if err != nil {
+		return nil, err
+	}
+
+	flagVars, err := manifest.ParseVariables(c.StringSlice("var"))
+	if err != nil {
+		return nil, err
+	}
+
+	return fileVars.Merge(flagVars), nil
+}
+
 func (cmd *Push) createAppSetFromContextAndManifest(contextApp models.AppParams, manifestApps []models.AppParams) ([]models.AppParams, error) {
 	var err error
 	var apps []models.AppParams
@@ -808,6 +1565,17 @@ func (cmd Push) ValidateContextAndAppParams(appsFromManifest []models.AppParams,
 }
 
 func (cmd *Push) uploadApp(appGUID, appDir, appDirOrZipFile string, localFiles []models.AppFileFields) error {
+	err := cmd.gatherAndUploadApp(appGUID, appDir, localFiles)
+	if _, ok := err.(*applicationbits.StaleResourcesError); ok {
+		// the rejected upload cleared the cached resources, so gathering the
+		// files again matches every one of them against the Cloud Controller
+		cmd.ui.Say(T("Some cached resources are no longer on the server, uploading again..."))
+		err = cmd.gatherAndUploadApp(appGUID, appDir, localFiles)
+	}
+	return err
+}
+
+func (cmd *Push) gatherAndUploadApp(appGUID, appDir string, localFiles []models.AppFileFields) error {
 	uploadDir, err := ioutil.TempDir("", "apps")
 	if err != nil {
 		return err
@@ -1,17 +1,23 @@
 package application_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits/applicationbitsfakes"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits"
 	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/api/stacks/stacksfakes"
+	cfappfiles "code.cloudfoundry.org/cli/cf/appfiles"
 	"code.cloudfoundry.org/cli/cf/appfiles/appfilesfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands/application"
@@ -286,6 +292,129 @@ var _ = Describe("Push Command", func() {
 			executeErr = cmd.Execute(flagContext)
 		})
 
+		Context("when pushing apps in parallel", func() {
+			var (
+				running    int32
+				maxRunning int32
+			)
+
+			BeforeEach(func() {
+				deps.UI = uiWithContents
+				deps.RepoLocator = deps.RepoLocator.SetApplicationBitsRepository(new(applicationbitsfakes.FakeApplicationBitsRepository))
+				running = 0
+				maxRunning = 0
+
+				apps := []interface{}{}
+				for _, name := range []string{"app1", "app2", "app3", "app4"} {
+					apps = append(apps, generic.NewMap(map[interface{}]interface{}{
+						"name":     name,
+						"no-route": true,
+					}))
+				}
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{"applications": apps}),
+				}, nil)
+
+				appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "the-app"))
+				appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+					current := atomic.AddInt32(&running, 1)
+					defer atomic.AddInt32(&running, -1)
+					for {
+						max := atomic.LoadInt32(&maxRunning)
+						if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+
+					if *params.Name == "app2" {
+						return models.Application{}, errors.New("app2 could not be created")
+					}
+
+					a := models.Application{}
+					a.GUID = *params.Name + "-guid"
+					a.Name = *params.Name
+					a.State = "stopped"
+					return a, nil
+				}
+
+				args = []string{"--parallel", "2"}
+			})
+
+			It("pushes every app, at most the given number at a time", func() {
+				Expect(appRepo.CreateCallCount()).To(Equal(4))
+				Expect(atomic.LoadInt32(&maxRunning)).To(BeNumerically("<=", 2))
+				Expect(starter.ApplicationStartCallCount()).To(Equal(3))
+			})
+
+			It("prints the output of each app as one block prefixed with the app name", func() {
+				totalOutputs := terminal.Decolorize(string(output.Contents()))
+				Expect(totalOutputs).To(ContainSubstring("Pushing 4 apps, up to 2 at a time..."))
+				Expect(totalOutputs).To(ContainSubstring("[app1] Creating app app1 in org my-org / space my-space as my-user...\n[app1] OK\n"))
+				Expect(totalOutputs).To(ContainSubstring("[app2] Creating app app2 in org my-org / space my-space as my-user...\n[app2] FAILED\n[app2] app2 could not be created\n"))
+				Expect(totalOutputs).To(ContainSubstring("[app4] Creating app app4 in org my-org / space my-space as my-user...\n[app4] OK\n"))
+			})
+
+			It("shows a summary and returns an error naming the failed apps", func() {
+				totalOutputs := terminal.Decolorize(string(output.Contents()))
+				Expect(totalOutputs).To(MatchRegexp(`Push summary:\n\napp\s+status\napp1\s+pushed\napp2\s+failed\napp3\s+pushed\napp4\s+pushed\n`))
+
+				Expect(executeErr).To(MatchError("1 of 4 apps failed to push: app2"))
+			})
+
+			Context("when the manifest has routes", func() {
+				BeforeEach(func() {
+					apps := []interface{}{}
+					for _, name := range []string{"app1", "app2", "app3", "app4"} {
+						apps = append(apps, generic.NewMap(map[interface{}]interface{}{
+							"name": name,
+							"routes": []interface{}{
+								map[interface{}]interface{}{"route": name + ".foo.cf-app.com"},
+							},
+						}))
+					}
+					manifestRepo.ReadManifestReturns(&manifest.Manifest{
+						Path: "manifest.yml",
+						Data: generic.NewMap(map[interface{}]interface{}{"applications": apps}),
+					}, nil)
+
+					domainRepo.FindPrivateByNameReturns(models.DomainFields{}, errors.NewModelNotFoundError("Domain", "foo.cf-app.com"))
+					domainRepo.FindSharedByNameStub = func(name string) (models.DomainFields, error) {
+						if name != "foo.cf-app.com" {
+							return models.DomainFields{}, errors.NewModelNotFoundError("Domain", name)
+						}
+						return models.DomainFields{Name: "foo.cf-app.com", GUID: "foo-domain-guid", Shared: true}, nil
+					}
+					routeRepo.FindStub = func(host string, domain models.DomainFields, path string, port int) (models.Route, error) {
+						return models.Route{GUID: host + "-route-guid", Host: host, Domain: domain}, nil
+					}
+				})
+
+				It("prints the route output of each app in the block of that app", func() {
+					Expect(routeRepo.BindCallCount()).To(Equal(3))
+
+					totalOutputs := terminal.Decolorize(string(output.Contents()))
+					for _, name := range []string{"app1", "app3", "app4"} {
+						Expect(totalOutputs).To(ContainSubstring("[%s] Using route %s.foo.cf-app.com\n", name, name))
+						Expect(totalOutputs).To(ContainSubstring("[%s] Binding %s.foo.cf-app.com to %s...\n", name, name, name))
+					}
+					Expect(totalOutputs).NotTo(MatchRegexp(`(?m)^(Using route|Binding)`))
+				})
+			})
+
+			Context("when --parallel is less than 1", func() {
+				BeforeEach(func() {
+					args = []string{"--parallel", "0"}
+				})
+
+				It("returns an error", func() {
+					Expect(executeErr).To(MatchError("Option '--parallel' must be at least 1"))
+					Expect(appRepo.CreateCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when pushing a new app", func() {
 			BeforeEach(func() {
 				m := &manifest.Manifest{
@@ -325,6 +454,50 @@ var _ = Describe("Push Command", func() {
 				args = []string{"app-name"}
 			})
 
+			Context("when the manifest contains variables", func() {
+				BeforeEach(func() {
+					m := &manifest.Manifest{
+						Path: "manifest.yml",
+						Data: generic.NewMap(map[interface{}]interface{}{
+							"applications": []interface{}{
+								generic.NewMap(map[interface{}]interface{}{
+									"name":   "((name))",
+									"memory": "((memory))",
+								}),
+							},
+						}),
+					}
+					manifestRepo.ReadManifestReturns(m, nil)
+				})
+
+				Context("when the variables are given with --var", func() {
+					BeforeEach(func() {
+						args = []string{"--var", "name=var-app", "--var", "memory=256M"}
+					})
+
+					It("pushes the app with the variables substituted", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						Expect(appRepo.CreateCallCount()).To(Equal(1))
+						params := appRepo.CreateArgsForCall(0)
+						Expect(*params.Name).To(Equal("var-app"))
+						Expect(*params.Memory).To(Equal(int64(256)))
+					})
+				})
+
+				Context("when a variable is missing", func() {
+					BeforeEach(func() {
+						args = []string{"--var", "name=var-app"}
+					})
+
+					It("returns an error naming the missing variable", func() {
+						Expect(executeErr).To(HaveOccurred())
+						Expect(executeErr.Error()).To(ContainSubstring("Expected to find variables: memory"))
+						Expect(appRepo.CreateCallCount()).To(Equal(0))
+					})
+				})
+			})
+
 			Context("validating a manifest", func() {
 				BeforeEach(func() {
 					actor.ValidateAppParamsReturns([]error{
@@ -1019,6 +1192,64 @@ var _ = Describe("Push Command", func() {
 					})
 				})
 
+				Context("when --honor-gitignore is provided", func() {
+					BeforeEach(func() {
+						args = []string{"-p", "../some/path-to/an-app/file.zip", "--honor-gitignore", "app-with-path"}
+					})
+
+					It("also applies .gitignore files", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						_, options := appfiles.AppFilesInDirArgsForCall(0)
+						Expect(options).To(Equal(cfappfiles.IgnoreOptions{GitIgnore: true}))
+					})
+				})
+
+				Context("when --show-ignored is provided", func() {
+					BeforeEach(func() {
+						deps.UI = uiWithContents
+						args = []string{"-p", "../some/path-to/an-app/file.zip", "--show-ignored", "app-with-path"}
+					})
+
+					Context("when files are ignored", func() {
+						BeforeEach(func() {
+							appfiles.IgnoredFilesInDirReturns([]string{".cfignore", "spec/", "debug.log"}, nil)
+						})
+
+						It("lists the ignored files before uploading", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+
+							appDir, _ := appfiles.AppFilesInDirArgsForCall(0)
+							dir, options := appfiles.IgnoredFilesInDirArgsForCall(0)
+							Expect(dir).To(Equal(appDir))
+							Expect(options).To(Equal(cfappfiles.IgnoreOptions{}))
+
+							totalOutputs := terminal.Decolorize(string(output.Contents()))
+							Expect(totalOutputs).To(ContainSubstring("Files ignored in '../some/path-to/an-app/file.zip':\n  .cfignore\n  spec/\n  debug.log\nUploading app-with-path..."))
+						})
+					})
+
+					Context("when no files are ignored", func() {
+						It("says so", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+
+							totalOutputs := terminal.Decolorize(string(output.Contents()))
+							Expect(totalOutputs).To(ContainSubstring("No files ignored in '../some/path-to/an-app/file.zip'"))
+						})
+					})
+
+					Context("when listing the ignored files fails", func() {
+						BeforeEach(func() {
+							appfiles.IgnoredFilesInDirReturns(nil, errors.New("some error"))
+						})
+
+						It("returns an error", func() {
+							Expect(executeErr).To(HaveOccurred())
+							Expect(executeErr.Error()).To(ContainSubstring("Error processing app files in '../some/path-to/an-app/file.zip': some error"))
+						})
+					})
+				})
+
 				Context("when an app path is specified with the -p flag", func() {
 					BeforeEach(func() {
 						args = []string{"-p", "../some/path-to/an-app/file.zip", "app-with-path"}
@@ -1411,6 +1642,142 @@ var _ = Describe("Push Command", func() {
 				Expect(totalOutputs).To(ContainSubstring("Uploading existing-app...\nOK"))
 			})
 
+			Context("when --no-cache is provided", func() {
+				var (
+					cacheDir string
+					cache    *cfappfiles.ResourceCache
+				)
+
+				BeforeEach(func() {
+					var err error
+					cacheDir, err = ioutil.TempDir("", "resource-cache")
+					Expect(err).NotTo(HaveOccurred())
+
+					cache = cfappfiles.NewResourceCache(filepath.Join(cacheDir, "resource_cache.json"))
+					cache.AddResources("https://api.example.com", []string{"some-sha"})
+					deps.ResourceCache = cache
+
+					args = append(args, "--no-cache")
+				})
+
+				AfterEach(func() {
+					os.RemoveAll(cacheDir)
+				})
+
+				It("bypasses the resource cache and re-uploads the app", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(cache.HasResource("https://api.example.com", "some-sha")).To(BeFalse())
+					Expect(actor.UploadAppCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when --dry-run is provided", func() {
+				BeforeEach(func() {
+					appSummaryRepo := new(apifakes.FakeAppSummaryRepository)
+					appSummaryRepo.GetSummaryReturns(models.Application{
+						Services: []models.ServicePlanSummary{{Name: "bound-service"}},
+					}, nil)
+					deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+
+					m := &manifest.Manifest{
+						Path: "manifest.yml",
+						Data: generic.NewMap(map[interface{}]interface{}{
+							"applications": []interface{}{
+								generic.NewMap(map[interface{}]interface{}{
+									"name":     "existing-app",
+									"memory":   "512M",
+									"command":  "unicorn -c config/unicorn.rb -D",
+									"services": []interface{}{"bound-service", "new-service"},
+									"env": generic.NewMap(map[interface{}]interface{}{
+										"crazy": "pants",
+										"NEW":   "value",
+									}),
+								}),
+							},
+						}),
+					}
+					manifestRepo.ReadManifestReturns(m, nil)
+					routeRepo.FindReturns(models.Route{}, errors.NewModelNotFoundError("Route", "existing-app"))
+
+					args = []string{"--dry-run"}
+				})
+
+				It("shows the changes without making them", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(appRepo.UpdateCallCount()).To(Equal(0))
+					Expect(appRepo.CreateCallCount()).To(Equal(0))
+					Expect(routeActor.FindOrCreateRouteCallCount()).To(Equal(0))
+					Expect(routeActor.BindRouteCallCount()).To(Equal(0))
+					Expect(actor.ProcessPathCallCount()).To(Equal(0))
+					Expect(stopper.ApplicationStopCallCount()).To(Equal(0))
+					Expect(starter.ApplicationStartCallCount()).To(Equal(0))
+
+					totalOutputs := terminal.Decolorize(string(output.Contents()))
+					Expect(totalOutputs).To(MatchRegexp(`existing-app\s+change\s+memory\s+0\s+512M`))
+					Expect(totalOutputs).To(MatchRegexp(`existing-app\s+change\s+env NEW\s+\(hidden\)`))
+					Expect(totalOutputs).To(MatchRegexp(`existing-app\s+create and map\s+route\s+existing-app.foo.cf-app.com`))
+					Expect(totalOutputs).To(MatchRegexp(`existing-app\s+bind\s+service\s+new-service`))
+					Expect(totalOutputs).To(MatchRegexp(`existing-app\s+restart\s+app`))
+					Expect(totalOutputs).NotTo(MatchRegexp(`change\s+command`))
+					Expect(totalOutputs).NotTo(ContainSubstring("env crazy"))
+					Expect(totalOutputs).NotTo(MatchRegexp(`bind\s+service\s+bound-service`))
+					Expect(totalOutputs).To(ContainSubstring("Dry run complete, no changes were made."))
+				})
+
+				Context("when the app does not exist", func() {
+					BeforeEach(func() {
+						appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "existing-app"))
+						routeRepo.FindReturns(models.Route{GUID: "route-guid"}, nil)
+					})
+
+					It("plans to create the app", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(appRepo.CreateCallCount()).To(Equal(0))
+
+						totalOutputs := terminal.Decolorize(string(output.Contents()))
+						Expect(totalOutputs).To(MatchRegexp(`existing-app\s+create\s+app`))
+						Expect(totalOutputs).To(MatchRegexp(`existing-app\s+change\s+memory\s+512M`))
+						Expect(totalOutputs).To(MatchRegexp(`existing-app\s+map\s+route\s+existing-app.foo.cf-app.com`))
+						Expect(totalOutputs).To(MatchRegexp(`existing-app\s+bind\s+service\s+bound-service`))
+						Expect(totalOutputs).To(MatchRegexp(`existing-app\s+start\s+app`))
+					})
+				})
+
+				Context("when --random-route is provided", func() {
+					BeforeEach(func() {
+						args = append(args, "--random-route")
+					})
+
+					It("shows a placeholder for the hostname the push would pick", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(wordGenerator.BabbleCallCount()).To(Equal(0))
+						Expect(routeRepo.FindCallCount()).To(Equal(0))
+
+						totalOutputs := terminal.Decolorize(string(output.Contents()))
+						Expect(totalOutputs).To(MatchRegexp(`existing-app\s+create and map\s+route\s+<random-route>.foo.cf-app.com`))
+						Expect(totalOutputs).NotTo(ContainSubstring("random-host"))
+					})
+				})
+
+				Context("when --show-ignored is provided", func() {
+					BeforeEach(func() {
+						appfiles.IgnoredFilesInDirReturns([]string{"spec/"}, nil)
+						args = append(args, "--show-ignored")
+					})
+
+					It("lists the ignored files without uploading anything", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(actor.ProcessPathCallCount()).To(Equal(1))
+						Expect(actor.UploadAppCallCount()).To(Equal(0))
+
+						totalOutputs := terminal.Decolorize(string(output.Contents()))
+						Expect(totalOutputs).To(ContainSubstring("\n  spec/\n"))
+						Expect(totalOutputs).To(ContainSubstring("Dry run complete, no changes were made."))
+					})
+				})
+			})
+
 			Context("when the -b flag is provided as 'default'", func() {
 				BeforeEach(func() {
 					args = []string{"-b", "default", "existing-app"}
@@ -1762,6 +2129,41 @@ var _ = Describe("Push Command", func() {
 				})
 			})
 
+			Context("when the upload relied on stale cached resources", func() {
+				BeforeEach(func() {
+					actor.UploadAppStub = func(string, *os.File, []resources.AppFileResource) error {
+						if actor.UploadAppCallCount() == 1 {
+							return &applicationbits.StaleResourcesError{Err: errors.New("resource not found")}
+						}
+						return nil
+					}
+					args = []string{"app"}
+				})
+
+				It("gathers the files again and uploads once more", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(actor.GatherFilesCallCount()).To(Equal(2))
+					Expect(actor.UploadAppCallCount()).To(Equal(2))
+
+					totalOutputs := terminal.Decolorize(string(output.Contents()))
+					Expect(totalOutputs).To(ContainSubstring("Some cached resources are no longer on the server, uploading again..."))
+				})
+
+				Context("when the second upload fails as well", func() {
+					BeforeEach(func() {
+						actor.UploadAppStub = func(string, *os.File, []resources.AppFileResource) error {
+							return &applicationbits.StaleResourcesError{Err: errors.New("resource not found")}
+						}
+					})
+
+					It("gives up after one more attempt", func() {
+						Expect(executeErr).To(HaveOccurred())
+						Expect(executeErr.Error()).To(ContainSubstring("resource not found"))
+						Expect(actor.UploadAppCallCount()).To(Equal(2))
+					})
+				})
+			})
+
 			Context("when no name and no manifest is given", func() {
 				BeforeEach(func() {
 					manifestRepo.ReadManifestReturns(manifest.NewEmptyManifest(), errors.New("No such manifest"))
@@ -1773,6 +2175,153 @@ var _ = Describe("Push Command", func() {
 					Expect(executeErr.Error()).To(ContainSubstring("Incorrect Usage. The push command requires an app name. The app name can be supplied as an argument or with a manifest.yml file."))
 				})
 			})
+
+			Context("when --strategy blue-green is provided", func() {
+				var (
+					appSummaryRepo *apifakes.FakeAppSummaryRepository
+					created        bool
+				)
+
+				BeforeEach(func() {
+					created = false
+					existingApp.Memory = 256
+					existingApp.InstanceCount = 2
+					existingApp.State = "started"
+					existingApp.Routes = []models.RouteSummary{
+						{GUID: "route-guid", Host: "existing-app", Domain: models.DomainFields{Name: "example.com"}},
+					}
+
+					appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+					appSummaryRepo.GetSummaryReturns(models.Application{
+						Services: []models.ServicePlanSummary{{Name: "bound-service"}},
+					}, nil)
+					deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+
+					appRepo.ReadStub = func(name string) (models.Application, error) {
+						switch {
+						case name == "existing-app-venerable":
+							return models.Application{}, errors.NewModelNotFoundError("App", name)
+						case created:
+							newApp := models.Application{}
+							newApp.Name = "existing-app"
+							newApp.GUID = "new-app-guid"
+							return newApp, nil
+						default:
+							return existingApp, nil
+						}
+					}
+					appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+						created = true
+						newApp := models.Application{}
+						newApp.Name = *params.Name
+						newApp.GUID = "new-app-guid"
+						newApp.State = "stopped"
+						return newApp, nil
+					}
+
+					args = []string{"--strategy", "blue-green", "existing-app"}
+				})
+
+				It("renames the existing app and creates the new version with its settings", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					guid, params := appRepo.UpdateArgsForCall(0)
+					Expect(guid).To(Equal("existing-app-guid"))
+					Expect(*params.Name).To(Equal("existing-app-venerable"))
+
+					Expect(appRepo.CreateCallCount()).To(Equal(1))
+					params = appRepo.CreateArgsForCall(0)
+					Expect(*params.Name).To(Equal("existing-app"))
+					Expect(*params.Memory).To(Equal(int64(256)))
+					Expect(*params.InstanceCount).To(Equal(2))
+					Expect(*params.Command).To(Equal("unicorn -c config/unicorn.rb -D"))
+					Expect(*params.EnvironmentVars).To(HaveKeyWithValue("crazy", "pants"))
+					Expect(params.ServicesToBind).To(ContainElement("bound-service"))
+				})
+
+				It("starts the new version without stopping the existing app", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(stopper.ApplicationStopCallCount()).To(Equal(0))
+					Expect(starter.ApplicationStartCallCount()).To(Equal(1))
+					app, _, _ := starter.ApplicationStartArgsForCall(0)
+					Expect(app.GUID).To(Equal("new-app-guid"))
+				})
+
+				It("moves the routes to the new version and deletes the existing app", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(routeActor.BindRouteCallCount()).To(BeNumerically(">=", 1))
+					app, route := routeActor.BindRouteArgsForCall(routeActor.BindRouteCallCount() - 1)
+					Expect(app.GUID).To(Equal("new-app-guid"))
+					Expect(route.GUID).To(Equal("route-guid"))
+
+					Expect(routeActor.UnbindAllCallCount()).To(Equal(1))
+					Expect(routeActor.UnbindAllArgsForCall(0).GUID).To(Equal("existing-app-guid"))
+
+					Expect(appRepo.DeleteCallCount()).To(Equal(1))
+					Expect(appRepo.DeleteArgsForCall(0)).To(Equal("existing-app-guid"))
+				})
+
+				Context("when the new version fails to start", func() {
+					BeforeEach(func() {
+						starter.ApplicationStartReturns(models.Application{}, errors.New("start failed"))
+					})
+
+					It("deletes the new version and restores the existing app", func() {
+						Expect(executeErr).To(HaveOccurred())
+						Expect(executeErr.Error()).To(ContainSubstring("start failed"))
+						Expect(executeErr.Error()).To(ContainSubstring("rolled back"))
+
+						Expect(appRepo.DeleteCallCount()).To(Equal(1))
+						Expect(appRepo.DeleteArgsForCall(0)).To(Equal("new-app-guid"))
+
+						Expect(appRepo.UpdateCallCount()).To(Equal(2))
+						guid, params := appRepo.UpdateArgsForCall(1)
+						Expect(guid).To(Equal("existing-app-guid"))
+						Expect(*params.Name).To(Equal("existing-app"))
+
+						Expect(routeActor.UnbindAllCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the venerable app already exists", func() {
+					BeforeEach(func() {
+						appRepo.ReadReturns(existingApp, nil)
+						appRepo.ReadStub = nil
+					})
+
+					It("fails without touching the existing app", func() {
+						Expect(executeErr).To(HaveOccurred())
+						Expect(executeErr.Error()).To(ContainSubstring("App existing-app-venerable already exists"))
+						Expect(appRepo.UpdateCallCount()).To(Equal(0))
+						Expect(appRepo.CreateCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when combined with --no-start", func() {
+					BeforeEach(func() {
+						args = []string{"--strategy", "blue-green", "--no-start", "existing-app"}
+					})
+
+					It("fails", func() {
+						Expect(executeErr).To(HaveOccurred())
+						Expect(executeErr.Error()).To(ContainSubstring("cannot be used with '--no-start'"))
+					})
+				})
+			})
+
+			Context("when an unknown --strategy is provided", func() {
+				BeforeEach(func() {
+					args = []string{"--strategy", "rolling", "existing-app"}
+				})
+
+				It("fails", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Invalid strategy: rolling"))
+					Expect(appRepo.UpdateCallCount()).To(Equal(0))
+				})
+			})
 		})
 
 		Context("when routes are specified in the manifest", func() {
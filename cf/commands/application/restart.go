@@ -1,8 +1,15 @@
 package application
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/appinstances"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
@@ -20,11 +27,15 @@ type Restarter interface {
 }
 
 type Restart struct {
-	ui      terminal.UI
-	config  coreconfig.Reader
-	starter Starter
-	stopper Stopper
-	appReq  requirements.ApplicationRequirement
+	ui               terminal.UI
+	config           coreconfig.Reader
+	starter          Starter
+	stopper          Stopper
+	appReq           requirements.ApplicationRequirement
+	appInstancesRepo appinstances.Repository
+
+	StartupTimeout time.Duration
+	PingerThrottle time.Duration
 }
 
 func init() {
@@ -32,13 +43,18 @@ func init() {
 }
 
 func (cmd *Restart) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["rolling"] = &flags.BoolFlag{Name: "rolling", Usage: T("Restart the instances in batches, waiting for each batch to be running again before restarting the next")}
+	fs["batch-size"] = &flags.IntFlag{Name: "batch-size", Usage: T("With --rolling, the number of instances to restart at a time (Default: 1)")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "restart",
 		ShortName:   "rs",
 		Description: T("Restart an app"),
 		Usage: []string{
-			T("CF_NAME restart APP_NAME"),
+			T("CF_NAME restart APP_NAME [--rolling [--batch-size NUM_INSTANCES]]"),
 		},
+		Flags: fs,
 	}
 }
 
@@ -48,6 +64,11 @@ func (cmd *Restart) Requirements(requirementsFactory requirements.Factory, fc fl
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
 	}
 
+	if fc.IsSet("batch-size") && !fc.Bool("rolling") {
+		cmd.ui.Failed(T("Incorrect Usage: --batch-size can only be used with --rolling\n\n") + commandregistry.Commands.CommandUsage("restart"))
+		return nil, fmt.Errorf("Incorrect usage: --batch-size without --rolling")
+	}
+
 	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
 
 	reqs := []requirements.Requirement{
@@ -62,6 +83,14 @@ func (cmd *Restart) Requirements(requirementsFactory requirements.Factory, fc fl
 func (cmd *Restart) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
+	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
+	cmd.PingerThrottle = DefaultPingerThrottle
+
+	// an invalid value is reported by the start command
+	cmd.StartupTimeout = DefaultStartupTimeout
+	if duration, err := strconv.ParseInt(os.Getenv("CF_STARTUP_TIMEOUT"), 10, 64); err == nil {
+		cmd.StartupTimeout = time.Duration(duration) * time.Minute
+	}
 
 	//get start for dependency
 	starter := commandregistry.Commands.FindCommand("start")
@@ -78,6 +107,18 @@ func (cmd *Restart) SetDependency(deps commandregistry.Dependency, pluginCall bo
 
 func (cmd *Restart) Execute(c flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
+
+	if c.Bool("rolling") {
+		batchSize := 1
+		if c.IsSet("batch-size") {
+			batchSize = c.Int("batch-size")
+		}
+		if batchSize < 1 {
+			return errors.New(T("Option '--batch-size' must be at least 1"))
+		}
+		return cmd.rollingRestart(app, batchSize)
+	}
+
 	return cmd.ApplicationRestart(app, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
 }
 
@@ -95,3 +136,124 @@ func (cmd *Restart) ApplicationRestart(app models.Application, orgName, spaceNam
 	}
 	return nil
 }
+
+// rollingRestart replaces the instances of a started app batchSize at a time.
+// The next batch is only restarted once every instance of the current batch
+// is running again, so the app keeps serving requests throughout.
+func (cmd *Restart) rollingRestart(app models.Application, batchSize int) error {
+	if app.State != models.ApplicationStateStarted {
+		return errors.New(T("App {{.AppName}} is not started. Restart it without --rolling.",
+			map[string]interface{}{"AppName": app.Name}))
+	}
+
+	instances, err := cmd.appInstancesRepo.GetInstances(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Restarting app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}} in batches of {{.BatchSize}} instances...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(app.Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+			"BatchSize": batchSize,
+		}))
+
+	if batchSize >= len(instances) {
+		cmd.ui.Warn(T("The batch size is not smaller than the {{.InstanceCount}} instances of the app, which will be unavailable while they restart.",
+			map[string]interface{}{"InstanceCount": len(instances)}))
+	}
+
+	for first := 0; first < len(instances); first += batchSize {
+		last := first + batchSize
+		if last > len(instances) {
+			last = len(instances)
+		}
+
+		// a replacement is told apart from the instance it replaces by
+		// the time it entered its state
+		replaced := map[int]time.Time{}
+		indexes := []string{}
+		for index := first; index < last; index++ {
+			replaced[index] = instances[index].Since
+			indexes = append(indexes, strconv.Itoa(index))
+		}
+
+		cmd.ui.Say("")
+		cmd.ui.Say(T("Restarting instances {{.Indexes}} of {{.InstanceCount}}...",
+			map[string]interface{}{"Indexes": strings.Join(indexes, ", "), "InstanceCount": len(instances)}))
+
+		for index := first; index < last; index++ {
+			err = cmd.appInstancesRepo.DeleteInstance(app.GUID, index)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = cmd.waitForReplacements(app, replaced)
+		if err != nil {
+			return errors.New(err.Error() + "\n\n" + T("Rolling restart aborted after {{.RestartedCount}} of {{.InstanceCount}} instances were restarted.",
+				map[string]interface{}{"RestartedCount": first, "InstanceCount": len(instances)}))
+		}
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Ok()
+	return nil
+}
+
+// waitForReplacements polls the instances of app until every index in
+// replaced is running an instance that started after the one it replaced.
+// It fails as soon as a replacement crashes.
+func (cmd *Restart) waitForReplacements(app models.Application, replaced map[int]time.Time) error {
+	timer := time.NewTimer(cmd.StartupTimeout)
+	defer timer.Stop()
+
+	tip := T("TIP: use '{{.Command}}' for more information",
+		map[string]interface{}{"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))})
+
+	for {
+		select {
+		case <-timer.C:
+			return errors.New(T("Restarted instances of {{.AppName}} did not start within {{.Timeout}}", map[string]interface{}{
+				"AppName": app.Name,
+				"Timeout": cmd.StartupTimeout,
+			}) + "\n\n" + tip)
+		default:
+		}
+
+		instances, err := cmd.appInstancesRepo.GetInstances(app.GUID)
+		if err != nil {
+			cmd.ui.Warn(T("Could not fetch instances: {{.Error}}", map[string]interface{}{"Error": err.Error()}))
+			time.Sleep(cmd.PingerThrottle)
+			continue
+		}
+
+		running := 0
+		for index, since := range replaced {
+			if index >= len(instances) || instances[index].Since.Equal(since) {
+				continue
+			}
+
+			switch instances[index].State {
+			case models.InstanceRunning:
+				running++
+			case models.InstanceCrashed, models.InstanceFlapping:
+				return errors.New(T("Instance {{.Index}} of {{.AppName}} crashed after restarting", map[string]interface{}{
+					"Index":   index,
+					"AppName": app.Name,
+				}) + "\n\n" + tip)
+			}
+		}
+
+		cmd.ui.Say(T("{{.RunningCount}} of {{.RestartedCount}} restarted instances running",
+			map[string]interface{}{"RunningCount": running, "RestartedCount": len(replaced)}))
+
+		if running == len(replaced) {
+			return nil
+		}
+
+		time.Sleep(cmd.PingerThrottle)
+	}
+}
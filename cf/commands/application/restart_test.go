@@ -1,8 +1,12 @@
 package application_test
 
 import (
+	"errors"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/cli/cf/api/appinstances/appinstancesfakes"
+	"code.cloudfoundry.org/cli/cf/commands/application"
 	"code.cloudfoundry.org/cli/cf/commands/application/applicationfakes"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
@@ -31,17 +35,21 @@ var _ = Describe("restart command", func() {
 		originalStart       commandregistry.Command
 		deps                commandregistry.Dependency
 		applicationReq      *requirementsfakes.FakeApplicationRequirement
+		appInstancesRepo    *appinstancesfakes.FakeAppInstancesRepository
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetAppInstancesRepository(appInstancesRepo)
 
 		//inject fake 'stopper and starter' into registry
 		commandregistry.Register(starter)
 		commandregistry.Register(stopper)
 
-		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("restart").SetDependency(deps, pluginCall))
+		cmd := commandregistry.Commands.FindCommand("restart").SetDependency(deps, pluginCall).(*application.Restart)
+		cmd.PingerThrottle = time.Millisecond
+		commandregistry.Commands.SetCommand(cmd)
 	}
 
 	runCommand := func(args ...string) bool {
@@ -55,10 +63,12 @@ var _ = Describe("restart command", func() {
 		starter = new(applicationfakes.FakeStarter)
 		stopper = new(applicationfakes.FakeStopper)
 		config = testconfig.NewRepositoryWithDefaults()
+		appInstancesRepo = new(appinstancesfakes.FakeAppInstancesRepository)
 
 		app = models.Application{}
 		app.Name = "my-app"
 		app.GUID = "my-app-guid"
+		app.State = models.ApplicationStateStarted
 
 		applicationReq = new(requirementsfakes.FakeApplicationRequirement)
 		applicationReq.GetApplicationReturns(app)
@@ -93,6 +103,14 @@ var _ = Describe("restart command", func() {
 			))
 		})
 
+		It("fails with usage when --batch-size is provided without --rolling", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			Expect(runCommand("my-app", "--batch-size", "2")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "--batch-size can only be used with --rolling"},
+			))
+		})
+
 		It("fails when not logged in", func() {
 			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
 			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
@@ -129,5 +147,150 @@ var _ = Describe("restart command", func() {
 			Expect(orgName).To(Equal(config.OrganizationFields().Name))
 			Expect(spaceName).To(Equal(config.SpaceFields().Name))
 		})
+
+		Context("with --rolling", func() {
+			var instances []models.AppInstanceFields
+
+			BeforeEach(func() {
+				started := time.Unix(1000, 0)
+				instances = []models.AppInstanceFields{
+					{State: models.InstanceRunning, Since: started},
+					{State: models.InstanceRunning, Since: started},
+					{State: models.InstanceRunning, Since: started},
+				}
+
+				// a deleted instance is replaced by one that starts on the
+				// first poll and runs on the next
+				appInstancesRepo.DeleteInstanceStub = func(_ string, index int) error {
+					instances[index] = models.AppInstanceFields{State: models.InstanceDown, Since: time.Unix(int64(2000+index), 0)}
+					return nil
+				}
+				appInstancesRepo.GetInstancesStub = func(_ string) ([]models.AppInstanceFields, error) {
+					current := make([]models.AppInstanceFields, len(instances))
+					copy(current, instances)
+					for i := range instances {
+						switch instances[i].State {
+						case models.InstanceDown:
+							instances[i].State = models.InstanceStarting
+						case models.InstanceStarting:
+							instances[i].State = models.InstanceRunning
+						}
+					}
+					return current, nil
+				}
+			})
+
+			It("replaces one instance at a time, waiting for each to be running", func() {
+				deletedWhileRunning := []int{}
+				deleteInstance := appInstancesRepo.DeleteInstanceStub
+				appInstancesRepo.DeleteInstanceStub = func(appGUID string, index int) error {
+					running := 0
+					for _, instance := range instances {
+						if instance.State == models.InstanceRunning {
+							running++
+						}
+					}
+					deletedWhileRunning = append(deletedWhileRunning, running)
+					return deleteInstance(appGUID, index)
+				}
+
+				Expect(runCommand("my-app", "--rolling")).To(BeTrue())
+
+				Expect(appInstancesRepo.DeleteInstanceCallCount()).To(Equal(3))
+				for i := 0; i < 3; i++ {
+					appGUID, index := appInstancesRepo.DeleteInstanceArgsForCall(i)
+					Expect(appGUID).To(Equal("my-app-guid"))
+					Expect(index).To(Equal(i))
+				}
+				Expect(deletedWhileRunning).To(Equal([]int{3, 3, 3}))
+
+				Expect(stopper.ApplicationStopCallCount()).To(Equal(0))
+				Expect(starter.ApplicationStartCallCount()).To(Equal(0))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Restarting app", "my-app", "in batches of 1 instances"},
+					[]string{"Restarting instances 0 of 3"},
+					[]string{"1 of 1 restarted instances running"},
+					[]string{"Restarting instances 2 of 3"},
+					[]string{"OK"},
+				))
+			})
+
+			It("restarts --batch-size instances at a time", func() {
+				Expect(runCommand("my-app", "--rolling", "--batch-size", "2")).To(BeTrue())
+
+				Expect(appInstancesRepo.DeleteInstanceCallCount()).To(Equal(3))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Restarting instances 0, 1 of 3"},
+					[]string{"2 of 2 restarted instances running"},
+					[]string{"Restarting instances 2 of 3"},
+				))
+			})
+
+			It("aborts when a restarted instance crashes", func() {
+				deleteInstance := appInstancesRepo.DeleteInstanceStub
+				appInstancesRepo.DeleteInstanceStub = func(appGUID string, index int) error {
+					err := deleteInstance(appGUID, index)
+					if index == 1 {
+						instances[index].State = models.InstanceCrashed
+					}
+					return err
+				}
+
+				Expect(runCommand("my-app", "--rolling")).To(BeFalse())
+
+				Expect(appInstancesRepo.DeleteInstanceCallCount()).To(Equal(2))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Instance 1 of my-app crashed after restarting"},
+					[]string{"Rolling restart aborted after 1 of 3 instances were restarted."},
+				))
+			})
+
+			It("keeps waiting while the old instance is still reported", func() {
+				appInstancesRepo.DeleteInstanceStub = func(_ string, index int) error {
+					return nil
+				}
+				polls := 0
+				getInstances := appInstancesRepo.GetInstancesStub
+				appInstancesRepo.GetInstancesStub = func(appGUID string) ([]models.AppInstanceFields, error) {
+					polls++
+					if polls == 3 {
+						for i := range instances {
+							instances[i] = models.AppInstanceFields{State: models.InstanceRunning, Since: time.Unix(3000, 0)}
+						}
+					}
+					return getInstances(appGUID)
+				}
+
+				Expect(runCommand("my-app", "--rolling", "--batch-size", "3")).To(BeTrue())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"0 of 3 restarted instances running"},
+					[]string{"3 of 3 restarted instances running"},
+				))
+			})
+
+			It("fails when the instances cannot be fetched", func() {
+				appInstancesRepo.GetInstancesStub = nil
+				appInstancesRepo.GetInstancesReturns(nil, errors.New("instances-error"))
+
+				Expect(runCommand("my-app", "--rolling")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"instances-error"}))
+				Expect(appInstancesRepo.DeleteInstanceCallCount()).To(Equal(0))
+			})
+
+			It("fails when the app is not started", func() {
+				app.State = models.ApplicationStateStopped
+				applicationReq.GetApplicationReturns(app)
+
+				Expect(runCommand("my-app", "--rolling")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"App my-app is not started"}))
+				Expect(appInstancesRepo.DeleteInstanceCallCount()).To(Equal(0))
+			})
+
+			It("fails when --batch-size is less than 1", func() {
+				Expect(runCommand("my-app", "--rolling", "--batch-size", "0")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Option '--batch-size' must be at least 1"}))
+			})
+		})
 	})
 })
@@ -15,6 +15,13 @@ import (
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
 
+//go:generate counterfeiter . Scaler
+
+type Scaler interface {
+	commandregistry.Command
+	ScaleInstances(app models.Application, instances int) (models.Application, error)
+}
+
 type Scale struct {
 	ui        terminal.UI
 	config    coreconfig.Reader
@@ -158,6 +165,12 @@ func (cmd *Scale) Execute(c flags.FlagContext) error {
 	return nil
 }
 
+// ScaleInstances changes the instance count of app. Unlike a change of its
+// limits, this does not restart the app.
+func (cmd *Scale) ScaleInstances(app models.Application, instances int) (models.Application, error) {
+	return cmd.appRepo.Update(app.GUID, models.AppParams{InstanceCount: &instances})
+}
+
 func (cmd *Scale) confirmRestart(context flags.FlagContext, appName string) bool {
 	if context.Bool("f") {
 		return true
@@ -3,6 +3,7 @@ package application_test
 import (
 	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application"
 	"code.cloudfoundry.org/cli/cf/commands/application/applicationfakes"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -196,4 +197,26 @@ var _ = Describe("scale command", func() {
 			})
 		})
 	})
+
+	Describe("ScaleInstances", func() {
+		It("updates the instance count of the app without restarting it", func() {
+			updateCommandDependency(false)
+			scaler := commandregistry.Commands.FindCommand("scale").(application.Scaler)
+
+			scaledApp := app
+			scaledApp.InstanceCount = 5
+			appRepo.UpdateReturns(scaledApp, nil)
+
+			updatedApp, err := scaler.ScaleInstances(app, 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedApp).To(Equal(scaledApp))
+
+			appGUID, params := appRepo.UpdateArgsForCall(0)
+			Expect(appGUID).To(Equal("my-app-guid"))
+			Expect(*params.InstanceCount).To(Equal(5))
+			Expect(params.Memory).To(BeNil())
+			Expect(params.DiskQuota).To(BeNil())
+			Expect(restarter.ApplicationRestartCallCount()).To(Equal(0))
+		})
+	})
 })
@@ -0,0 +1,101 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type ValidateManifest struct {
+	ui           terminal.UI
+	manifestRepo manifest.Repository
+	actor        actors.PushActor
+}
+
+func init() {
+	commandregistry.Register(&ValidateManifest{})
+}
+
+func (cmd *ValidateManifest) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["f"] = &flags.StringFlag{ShortName: "f", Usage: T("Path to manifest")}
+	fs["var"] = &flags.StringSliceFlag{Name: "var", Usage: T("Variable key value pair for variable substitution in the manifest (e.g. name=app1), flag can be specified multiple times")}
+	fs["vars-file"] = &flags.StringSliceFlag{Name: "vars-file", Usage: T("Path to a YAML file with variables for substitution in the manifest, flag can be specified multiple times")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "validate-manifest",
+		Description: T("Check a manifest for errors without pushing it"),
+		Usage: []string{
+			"CF_NAME validate-manifest ",
+			fmt.Sprintf("[-f %s] ", T("MANIFEST_PATH")),
+			fmt.Sprintf("[--var %s] ", T("KEY=VALUE")),
+			fmt.Sprintf("[--vars-file %s]", T("VARS_FILE_PATH")),
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *ValidateManifest) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd), "",
+		func() bool {
+			return len(fc.Args()) != 0
+		},
+	)
+
+	return []requirements.Requirement{usageReq}, nil
+}
+
+func (cmd *ValidateManifest) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.manifestRepo = deps.ManifestRepo
+	cmd.actor = deps.PushActor
+	return cmd
+}
+
+func (cmd *ValidateManifest) Execute(c flags.FlagContext) error {
+	path := c.String("f")
+	if path == "" {
+		var err error
+		path, err = os.Getwd()
+		if err != nil {
+			return errors.New(fmt.Sprint(T("Could not determine the current working directory!"), err))
+		}
+	}
+
+	m, err := cmd.manifestRepo.ReadManifest(path)
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	m.Variables, err = manifestVariables(c)
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	cmd.ui.Say(T("Validating manifest file {{.Path}}...",
+		map[string]interface{}{"Path": terminal.EntityNameColor(m.Path)}))
+
+	problems := m.Validate(func(app models.AppParams) []error {
+		return cmd.actor.ValidateAppParams([]models.AppParams{app})
+	})
+
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			cmd.ui.Say(problem.Error())
+		}
+		return errors.New(T("Found {{.Count}} problem(s) in manifest file {{.Path}}",
+			map[string]interface{}{"Count": len(problems), "Path": m.Path}))
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
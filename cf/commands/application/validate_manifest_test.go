@@ -0,0 +1,135 @@
+package application_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/manifest/manifestfakes"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/generic"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateManifest", func() {
+	var (
+		ui                  *testterm.FakeUI
+		manifestRepo        *manifestfakes.FakeRepository
+		actor               *actorsfakes.FakePushActor
+		requirementsFactory *requirementsfakes.FakeFactory
+		cmd                 commandregistry.Command
+		flagContext         flags.FlagContext
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		manifestRepo = new(manifestfakes.FakeRepository)
+		actor = new(actorsfakes.FakePushActor)
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+
+		deps := commandregistry.Dependency{
+			UI:           ui,
+			ManifestRepo: manifestRepo,
+			PushActor:    actor,
+		}
+
+		cmd = &application.ValidateManifest{}
+		cmd = cmd.SetDependency(deps, false)
+		flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+	})
+
+	Describe("Requirements", func() {
+		It("only checks the usage", func() {
+			usageReq := requirements.Passing{Type: "usage"}
+			requirementsFactory.NewUsageRequirementReturns(usageReq)
+
+			Expect(flagContext.Parse()).To(Succeed())
+			reqs, err := cmd.Requirements(requirementsFactory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reqs).To(Equal([]requirements.Requirement{usageReq}))
+			Expect(requirementsFactory.NewLoginRequirementCallCount()).To(Equal(0))
+			Expect(requirementsFactory.NewTargetedSpaceRequirementCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("Execute", func() {
+		var executeErr error
+
+		JustBeforeEach(func() {
+			executeErr = cmd.Execute(flagContext)
+		})
+
+		Context("when the manifest is valid", func() {
+			BeforeEach(func() {
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "path/to/manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							map[interface{}]interface{}{"name": "((name))", "memory": "256M"},
+						},
+					}),
+				}, nil)
+				Expect(flagContext.Parse("-f", "path/to/manifest.yml", "--var", "name=my-app")).To(Succeed())
+			})
+
+			It("reads the given manifest and validates each app", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+				Expect(manifestRepo.ReadManifestArgsForCall(0)).To(Equal("path/to/manifest.yml"))
+
+				Expect(actor.ValidateAppParamsCallCount()).To(Equal(1))
+				apps := actor.ValidateAppParamsArgsForCall(0)
+				Expect(*apps[0].Name).To(Equal("my-app"))
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Validating manifest file", "path/to/manifest.yml"},
+					[]string{"OK"},
+				))
+			})
+		})
+
+		Context("when the manifest has problems", func() {
+			BeforeEach(func() {
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "path/to/manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							map[interface{}]interface{}{"name": "my-app", "memroy": "256M"},
+						},
+					}),
+				}, nil)
+				actor.ValidateAppParamsReturns([]error{errors.New("bad routes")})
+				Expect(flagContext.Parse()).To(Succeed())
+			})
+
+			It("reports every problem and fails", func() {
+				Expect(executeErr).To(HaveOccurred())
+				Expect(executeErr.Error()).To(Equal("Found 2 problem(s) in manifest file path/to/manifest.yml"))
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"path/to/manifest.yml: my-app: Unknown key 'memroy' is ignored"},
+					[]string{"path/to/manifest.yml: my-app: bad routes"},
+				))
+			})
+		})
+
+		Context("when reading the manifest fails", func() {
+			BeforeEach(func() {
+				manifestRepo.ReadManifestReturns(manifest.NewEmptyManifest(), errors.New("no such file"))
+				Expect(flagContext.Parse("-f", "missing.yml")).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				Expect(executeErr).To(HaveOccurred())
+				Expect(executeErr.Error()).To(ContainSubstring("no such file"))
+			})
+		})
+	})
+})
@@ -25,7 +25,7 @@ func init() {
 func (cmd *ConfigCommands) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["async-timeout"] = &flags.IntFlag{Name: "async-timeout", Usage: T("Timeout for async HTTP requests")}
-	fs["trace"] = &flags.StringFlag{Name: "trace", Usage: T("Trace HTTP requests")}
+	fs["trace"] = &flags.StringFlag{Name: "trace", Usage: T("Trace HTTP requests. A path ending in .har records them in an HTTP Archive (HAR) file")}
 	fs["color"] = &flags.StringFlag{Name: "color", Usage: T("Enable or disable color")}
 	fs["locale"] = &flags.StringFlag{Name: "locale", Usage: T("Set default locale. If LOCALE is 'CLEAR', previous locale is deleted.")}
 
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"sort"
 
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -15,7 +14,6 @@ import (
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/manifest"
-	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
@@ -102,7 +100,7 @@ func (cmd *CreateAppManifest) Execute(c flags.FlagContext) error {
 	}
 	defer f.Close()
 
-	err = cmd.createManifest(application)
+	err = manifest.GenerateManifest(cmd.manifest, application)
 	if err != nil {
 		return err
 	}
@@ -116,71 +114,3 @@ func (cmd *CreateAppManifest) Execute(c flags.FlagContext) error {
 	cmd.ui.Say("")
 	return nil
 }
-
-func (cmd *CreateAppManifest) createManifest(app models.Application) error {
-	cmd.manifest.Memory(app.Name, app.Memory)
-	cmd.manifest.Instances(app.Name, app.InstanceCount)
-	cmd.manifest.Stack(app.Name, app.Stack.Name)
-
-	if len(app.AppPorts) > 0 {
-		cmd.manifest.AppPorts(app.Name, app.AppPorts)
-	}
-
-	if app.Command != "" {
-		cmd.manifest.StartCommand(app.Name, app.Command)
-	}
-
-	if app.BuildpackURL != "" {
-		cmd.manifest.BuildpackURL(app.Name, app.BuildpackURL)
-	}
-
-	if len(app.Services) > 0 {
-		for _, service := range app.Services {
-			cmd.manifest.Service(app.Name, service.Name)
-		}
-	}
-
-	if app.HealthCheckTimeout > 0 {
-		cmd.manifest.HealthCheckTimeout(app.Name, app.HealthCheckTimeout)
-	}
-
-	if len(app.EnvironmentVars) > 0 {
-		sorted := sortEnvVar(app.EnvironmentVars)
-		for _, envVarKey := range sorted {
-			switch app.EnvironmentVars[envVarKey].(type) {
-			default:
-				return errors.New(T("Failed to create manifest, unable to parse environment variable: ") + envVarKey)
-			case float64:
-				//json.Unmarshal turn all numbers to float64
-				value := int(app.EnvironmentVars[envVarKey].(float64))
-				cmd.manifest.EnvironmentVars(app.Name, envVarKey, fmt.Sprintf("%d", value))
-			case bool:
-				cmd.manifest.EnvironmentVars(app.Name, envVarKey, fmt.Sprintf("%t", app.EnvironmentVars[envVarKey].(bool)))
-			case string:
-				cmd.manifest.EnvironmentVars(app.Name, envVarKey, app.EnvironmentVars[envVarKey].(string))
-			}
-		}
-	}
-
-	if len(app.Routes) > 0 {
-		for i := 0; i < len(app.Routes); i++ {
-			cmd.manifest.Route(app.Name, app.Routes[i].Host, app.Routes[i].Domain.Name, app.Routes[i].Path, app.Routes[i].Port)
-		}
-	}
-
-	if app.DiskQuota != 0 {
-		cmd.manifest.DiskQuota(app.Name, app.DiskQuota)
-	}
-
-	return nil
-}
-
-func sortEnvVar(vars map[string]interface{}) []string {
-	var varsAry []string
-	for k := range vars {
-		varsAry = append(varsAry, k)
-	}
-	sort.Strings(varsAry)
-
-	return varsAry
-}
@@ -0,0 +1,743 @@
+package organization
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/featureflags"
+	"code.cloudfoundry.org/cli/cf/api/organizations"
+	"code.cloudfoundry.org/cli/cf/api/quotas"
+	"code.cloudfoundry.org/cli/cf/api/securitygroups"
+	securitygroupspaces "code.cloudfoundry.org/cli/cf/api/securitygroups/spaces"
+	"code.cloudfoundry.org/cli/cf/api/spacequotas"
+	"code.cloudfoundry.org/cli/cf/api/spaces"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"gopkg.in/yaml.v2"
+)
+
+type ApplyOrgConfig struct {
+	ui                  terminal.UI
+	config              coreconfig.Reader
+	orgRepo             organizations.OrganizationRepository
+	spaceRepo           spaces.SpaceRepository
+	quotaRepo           quotas.QuotaRepository
+	spaceQuotaRepo      spacequotas.SpaceQuotaRepository
+	userRepo            api.UserRepository
+	flagRepo            featureflags.FeatureFlagRepository
+	securityGroupRepo   securitygroups.SecurityGroupRepo
+	securityGroupBinder securitygroupspaces.SecurityGroupSpaceBinder
+}
+
+// orgConfigChange is one step of the plan that brings the current state
+// in line with an org config. The GUIDs it needs are read when it is
+// applied, as they may belong to an org or space created by an earlier
+// step.
+type orgConfigChange struct {
+	symbol      string
+	description string
+	apply       func() error
+}
+
+const (
+	orgConfigAdd    = "+"
+	orgConfigUpdate = "~"
+	orgConfigRemove = "-"
+)
+
+type orgConfigRole struct {
+	role  models.Role
+	name  string
+	users []string
+}
+
+func init() {
+	commandregistry.Register(&ApplyOrgConfig{})
+}
+
+func (cmd *ApplyOrgConfig) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["prune"] = &flags.BoolFlag{Name: "prune", Usage: T("Also remove roles, security group bindings and spaces that are not in the file")}
+	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force removals without confirmation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "apply-org-config",
+		Description: T("Create or update orgs, spaces, quota assignments, user roles and security group bindings to match a file"),
+		Usage: []string{
+			T("CF_NAME apply-org-config FILE [--prune] [-f]"),
+			"\n\n",
+			T(`   The file, in YAML or JSON, lists the desired orgs:
+
+   orgs:
+   - name: engineering
+     quota: large
+     managers: [alice]
+     billing_managers: []
+     auditors: []
+     spaces:
+     - name: development
+       quota: small
+       managers: [alice]
+       developers: [bob, carol]
+       auditors: []
+       security_groups: [databases]`),
+			"\n\n",
+			T("TIP:\n   Quotas and security groups are only assigned, create them first with 'CF_NAME create-quota', 'CF_NAME create-space-quota' and 'CF_NAME create-security-group'.\n   A role, security group or space list that is left out is not changed, while an empty list removes all of its entries with --prune. Orgs that are not in the file are never changed.\n   The plan is printed before it is applied. Use --prune to also remove what is not in the file, which asks for confirmation unless -f is given."),
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *ApplyOrgConfig) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("apply-org-config"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *ApplyOrgConfig) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.orgRepo = deps.RepoLocator.GetOrganizationRepository()
+	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
+	cmd.quotaRepo = deps.RepoLocator.GetQuotaRepository()
+	cmd.spaceQuotaRepo = deps.RepoLocator.GetSpaceQuotaRepository()
+	cmd.userRepo = deps.RepoLocator.GetUserRepository()
+	cmd.flagRepo = deps.RepoLocator.GetFeatureFlagRepository()
+	cmd.securityGroupRepo = deps.RepoLocator.GetSecurityGroupRepository()
+	cmd.securityGroupBinder = deps.RepoLocator.GetSecurityGroupSpaceBinder()
+	return cmd
+}
+
+func (cmd *ApplyOrgConfig) Execute(c flags.FlagContext) error {
+	path := c.Args()[0]
+
+	cmd.ui.Say(T("Comparing org config {{.File}} with the current state as {{.Username}}...",
+		map[string]interface{}{
+			"File":     terminal.EntityNameColor(path),
+			"Username": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	orgConfig, err := ReadOrgConfig(path)
+	if err != nil {
+		return err
+	}
+
+	changes, err := cmd.plan(orgConfig)
+	if err != nil {
+		return err
+	}
+
+	plan := []orgConfigChange{}
+	leftOut := 0
+	for _, change := range changes {
+		if change.symbol == orgConfigRemove && !c.Bool("prune") {
+			leftOut++
+			continue
+		}
+		plan = append(plan, change)
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(plan) == 0 {
+		cmd.ui.Say(T("Nothing to change"))
+	} else {
+		cmd.ui.Say(T("Plan:"))
+		for _, change := range plan {
+			cmd.ui.Say("   %s %s", change.symbol, change.description)
+		}
+	}
+
+	if leftOut > 0 {
+		cmd.ui.Say("")
+		cmd.ui.Say(T("TIP: {{.Count}} removals of what is not in the file were left out. Use '--prune' to apply them.",
+			map[string]interface{}{"Count": leftOut}))
+	}
+
+	if len(plan) == 0 {
+		return nil
+	}
+
+	if c.Bool("prune") && !c.Bool("f") && hasRemovals(plan) {
+		cmd.ui.Say("")
+		if !cmd.ui.Confirm(T("Really apply this plan, including its removals?{{.Prompt}}",
+			map[string]interface{}{"Prompt": terminal.PromptColor(">")})) {
+			return nil
+		}
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Applying {{.Count}} changes...", map[string]interface{}{"Count": len(plan)}))
+
+	for i, change := range plan {
+		err = change.apply()
+		if err != nil {
+			return errors.New(T("Could not {{.Change}}: {{.Error}}\nApplied {{.AppliedCount}} of {{.Count}} changes.",
+				map[string]interface{}{"Change": change.description, "Error": err.Error(), "AppliedCount": i, "Count": len(plan)}))
+		}
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+func hasRemovals(changes []orgConfigChange) bool {
+	for _, change := range changes {
+		if change.symbol == orgConfigRemove {
+			return true
+		}
+	}
+	return false
+}
+
+// plan returns the changes that bring the orgs in orgConfig to the state it
+// describes, removals included. Everything the changes refer to by name is
+// looked up here, so that a mistake in the file fails before anything is
+// changed.
+func (cmd *ApplyOrgConfig) plan(orgConfig OrgConfig) ([]orgConfigChange, error) {
+	rolesByGUID := cmd.setsRolesByGUID()
+
+	changes := []orgConfigChange{}
+	for _, orgCfg := range orgConfig.Orgs {
+		orgChanges, err := cmd.planOrg(orgCfg, rolesByGUID)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, orgChanges...)
+	}
+
+	return changes, nil
+}
+
+func (cmd *ApplyOrgConfig) planOrg(orgCfg OrgConfigOrg, rolesByGUID bool) ([]orgConfigChange, error) {
+	changes := []orgConfigChange{}
+	name := orgCfg.Name
+
+	org, err := cmd.orgRepo.FindByName(name)
+	exists := true
+	switch err.(type) {
+	case nil:
+	case *errors.ModelNotFoundError:
+		exists = false
+	default:
+		return nil, err
+	}
+
+	orgGUID := new(string)
+	*orgGUID = org.GUID
+
+	quotaGUID := ""
+	if orgCfg.Quota != "" && (!exists || org.QuotaDefinition.Name != orgCfg.Quota) {
+		quota, err := cmd.quotaRepo.FindByName(orgCfg.Quota)
+		if err != nil {
+			return nil, err
+		}
+		quotaGUID = quota.GUID
+	}
+
+	if !exists {
+		description := T("create org {{.OrgName}}", map[string]interface{}{"OrgName": name})
+		if orgCfg.Quota != "" {
+			description = T("create org {{.OrgName}} with quota {{.QuotaName}}", map[string]interface{}{"OrgName": name, "QuotaName": orgCfg.Quota})
+		}
+
+		changes = append(changes, orgConfigChange{orgConfigAdd, description, func() error {
+			err := cmd.orgRepo.Create(models.Organization{OrganizationFields: models.OrganizationFields{
+				Name:            name,
+				QuotaDefinition: models.QuotaFields{GUID: quotaGUID},
+			}})
+			if err != nil {
+				return err
+			}
+
+			created, err := cmd.orgRepo.FindByName(name)
+			*orgGUID = created.GUID
+			return err
+		}})
+	} else if quotaGUID != "" {
+		changes = append(changes, orgConfigChange{orgConfigUpdate,
+			T("set quota of org {{.OrgName}} to {{.QuotaName}} (was {{.CurrentQuotaName}})",
+				map[string]interface{}{"OrgName": name, "QuotaName": orgCfg.Quota, "CurrentQuotaName": org.QuotaDefinition.Name}),
+			func() error {
+				return cmd.quotaRepo.AssignQuotaToOrg(*orgGUID, quotaGUID)
+			}})
+	}
+
+	for _, role := range []orgConfigRole{
+		{models.RoleOrgManager, "OrgManager", orgCfg.Managers},
+		{models.RoleBillingManager, "BillingManager", orgCfg.BillingManagers},
+		{models.RoleOrgAuditor, "OrgAuditor", orgCfg.Auditors},
+	} {
+		if role.users == nil {
+			continue
+		}
+
+		current := []models.UserFields{}
+		if exists {
+			current, err = cmd.orgUserLister()(org.GUID, role.role)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		added, removed := diffUsers(role.users, current)
+		for _, username := range added {
+			userGUID, err := cmd.userGUID(username, rolesByGUID)
+			if err != nil {
+				return nil, err
+			}
+
+			username, role := username, role
+			changes = append(changes, orgConfigChange{orgConfigAdd,
+				T("add user {{.Username}} as {{.Role}} of org {{.OrgName}}",
+					map[string]interface{}{"Username": username, "Role": role.name, "OrgName": name}),
+				func() error {
+					if userGUID != "" {
+						return cmd.userRepo.SetOrgRoleByGUID(userGUID, *orgGUID, role.role)
+					}
+					return cmd.userRepo.SetOrgRoleByUsername(username, *orgGUID, role.role)
+				}})
+		}
+		for _, user := range removed {
+			user, role := user, role
+			changes = append(changes, orgConfigChange{orgConfigRemove,
+				T("remove user {{.Username}} as {{.Role}} of org {{.OrgName}}",
+					map[string]interface{}{"Username": user.Username, "Role": role.name, "OrgName": name}),
+				func() error {
+					return cmd.userRepo.UnsetOrgRoleByGUID(user.GUID, *orgGUID, role.role)
+				}})
+		}
+	}
+
+	desiredSpaces := map[string]bool{}
+	for _, spaceCfg := range orgCfg.Spaces {
+		desiredSpaces[spaceCfg.Name] = true
+
+		spaceChanges, err := cmd.planSpace(name, org, orgGUID, spaceCfg, rolesByGUID)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, spaceChanges...)
+	}
+
+	for _, space := range org.Spaces {
+		if orgCfg.Spaces == nil || desiredSpaces[space.Name] {
+			continue
+		}
+
+		space := space
+		changes = append(changes, orgConfigChange{orgConfigRemove,
+			T("delete space {{.SpaceName}} of org {{.OrgName}}, with its apps and services",
+				map[string]interface{}{"SpaceName": space.Name, "OrgName": name}),
+			func() error {
+				return cmd.spaceRepo.Delete(space.GUID)
+			}})
+	}
+
+	return changes, nil
+}
+
+func (cmd *ApplyOrgConfig) planSpace(orgName string, org models.Organization, orgGUID *string, spaceCfg OrgConfigSpace, rolesByGUID bool) ([]orgConfigChange, error) {
+	changes := []orgConfigChange{}
+	name := spaceCfg.Name
+
+	space := models.Space{}
+	exists := false
+	for _, existing := range org.Spaces {
+		if existing.Name == name {
+			exists = true
+		}
+	}
+	if exists {
+		var err error
+		space, err = cmd.spaceRepo.FindByNameInOrg(name, org.GUID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	spaceGUID := new(string)
+	*spaceGUID = space.GUID
+
+	quotaGUID := ""
+	currentQuotaName := ""
+	if spaceCfg.Quota != "" {
+		found := false
+		for _, quota := range org.SpaceQuotas {
+			if quota.Name == spaceCfg.Quota {
+				quotaGUID = quota.GUID
+				found = true
+			}
+			if quota.GUID == space.SpaceQuotaGUID {
+				currentQuotaName = quota.Name
+			}
+		}
+		if !found {
+			return nil, errors.New(T("Space quota {{.QuotaName}} for space {{.SpaceName}} not found in org {{.OrgName}}",
+				map[string]interface{}{"QuotaName": spaceCfg.Quota, "SpaceName": name, "OrgName": orgName}))
+		}
+	}
+
+	if !exists {
+		description := T("create space {{.SpaceName}} in org {{.OrgName}}", map[string]interface{}{"SpaceName": name, "OrgName": orgName})
+		if spaceCfg.Quota != "" {
+			description = T("create space {{.SpaceName}} in org {{.OrgName}} with space quota {{.QuotaName}}",
+				map[string]interface{}{"SpaceName": name, "OrgName": orgName, "QuotaName": spaceCfg.Quota})
+		}
+
+		changes = append(changes, orgConfigChange{orgConfigAdd, description, func() error {
+			created, err := cmd.spaceRepo.Create(name, *orgGUID, quotaGUID)
+			*spaceGUID = created.GUID
+			return err
+		}})
+	} else if quotaGUID != "" && quotaGUID != space.SpaceQuotaGUID {
+		description := T("set space quota of space {{.SpaceName}} in org {{.OrgName}} to {{.QuotaName}}",
+			map[string]interface{}{"SpaceName": name, "OrgName": orgName, "QuotaName": spaceCfg.Quota})
+		if currentQuotaName != "" {
+			description = T("set space quota of space {{.SpaceName}} in org {{.OrgName}} to {{.QuotaName}} (was {{.CurrentQuotaName}})",
+				map[string]interface{}{"SpaceName": name, "OrgName": orgName, "QuotaName": spaceCfg.Quota, "CurrentQuotaName": currentQuotaName})
+		}
+
+		changes = append(changes, orgConfigChange{orgConfigUpdate, description, func() error {
+			return cmd.spaceQuotaRepo.AssociateSpaceWithQuota(*spaceGUID, quotaGUID)
+		}})
+	}
+
+	for _, role := range []orgConfigRole{
+		{models.RoleSpaceManager, "SpaceManager", spaceCfg.Managers},
+		{models.RoleSpaceDeveloper, "SpaceDeveloper", spaceCfg.Developers},
+		{models.RoleSpaceAuditor, "SpaceAuditor", spaceCfg.Auditors},
+	} {
+		if role.users == nil {
+			continue
+		}
+
+		current := []models.UserFields{}
+		if exists {
+			var err error
+			current, err = cmd.spaceUserLister()(space.GUID, role.role)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		added, removed := diffUsers(role.users, current)
+		for _, username := range added {
+			userGUID, err := cmd.userGUID(username, rolesByGUID)
+			if err != nil {
+				return nil, err
+			}
+
+			username, role := username, role
+			changes = append(changes, orgConfigChange{orgConfigAdd,
+				T("add user {{.Username}} as {{.Role}} of space {{.SpaceName}} in org {{.OrgName}}",
+					map[string]interface{}{"Username": username, "Role": role.name, "SpaceName": name, "OrgName": orgName}),
+				func() error {
+					if userGUID != "" {
+						return cmd.userRepo.SetSpaceRoleByGUID(userGUID, *spaceGUID, *orgGUID, role.role)
+					}
+					return cmd.userRepo.SetSpaceRoleByUsername(username, *spaceGUID, *orgGUID, role.role)
+				}})
+		}
+		for _, user := range removed {
+			user, role := user, role
+			changes = append(changes, orgConfigChange{orgConfigRemove,
+				T("remove user {{.Username}} as {{.Role}} of space {{.SpaceName}} in org {{.OrgName}}",
+					map[string]interface{}{"Username": user.Username, "Role": role.name, "SpaceName": name, "OrgName": orgName}),
+				func() error {
+					return cmd.userRepo.UnsetSpaceRoleByGUID(user.GUID, *spaceGUID, role.role)
+				}})
+		}
+	}
+
+	if spaceCfg.SecurityGroups != nil {
+		bound := map[string]bool{}
+		for _, group := range space.SecurityGroups {
+			bound[group.Name] = true
+		}
+
+		for _, groupName := range spaceCfg.SecurityGroups {
+			if bound[groupName] {
+				continue
+			}
+
+			group, err := cmd.securityGroupRepo.Read(groupName)
+			if err != nil {
+				return nil, err
+			}
+
+			changes = append(changes, orgConfigChange{orgConfigAdd,
+				T("bind security group {{.SecurityGroupName}} to space {{.SpaceName}} in org {{.OrgName}}",
+					map[string]interface{}{"SecurityGroupName": groupName, "SpaceName": name, "OrgName": orgName}),
+				func() error {
+					return cmd.securityGroupBinder.BindSpace(group.GUID, *spaceGUID)
+				}})
+		}
+
+		for _, group := range space.SecurityGroups {
+			if containsString(spaceCfg.SecurityGroups, group.Name) {
+				continue
+			}
+
+			group := group
+			changes = append(changes, orgConfigChange{orgConfigRemove,
+				T("unbind security group {{.SecurityGroupName}} from space {{.SpaceName}} in org {{.OrgName}}",
+					map[string]interface{}{"SecurityGroupName": group.Name, "SpaceName": name, "OrgName": orgName}),
+				func() error {
+					return cmd.securityGroupBinder.UnbindSpace(group.GUID, *spaceGUID)
+				}})
+		}
+	}
+
+	return changes, nil
+}
+
+// setsRolesByGUID reports whether roles have to be set by user GUID, as
+// the set-org-role and set-space-role commands do when the API cannot set
+// them by username.
+func (cmd *ApplyOrgConfig) setsRolesByGUID() bool {
+	if !cmd.config.IsMinAPIVersion(cf.SetRolesByUsernameMinimumAPIVersion) {
+		return true
+	}
+
+	setRolesByUsernameFlag, err := cmd.flagRepo.FindByName("set_roles_by_username")
+	return err != nil || !setRolesByUsernameFlag.Enabled
+}
+
+func (cmd *ApplyOrgConfig) userGUID(username string, rolesByGUID bool) (string, error) {
+	if !rolesByGUID {
+		return "", nil
+	}
+
+	user, err := cmd.userRepo.FindByUsername(username)
+	return user.GUID, err
+}
+
+func (cmd *ApplyOrgConfig) orgUserLister() func(orgGUID string, role models.Role) ([]models.UserFields, error) {
+	if cmd.config.IsMinAPIVersion(cf.ListUsersInOrgOrSpaceWithoutUAAMinimumAPIVersion) {
+		return cmd.userRepo.ListUsersInOrgForRoleWithNoUAA
+	}
+	return cmd.userRepo.ListUsersInOrgForRole
+}
+
+func (cmd *ApplyOrgConfig) spaceUserLister() func(spaceGUID string, role models.Role) ([]models.UserFields, error) {
+	if cmd.config.IsMinAPIVersion(cf.ListUsersInOrgOrSpaceWithoutUAAMinimumAPIVersion) {
+		return cmd.userRepo.ListUsersInSpaceForRoleWithNoUAA
+	}
+	return cmd.userRepo.ListUsersInSpaceForRole
+}
+
+// diffUsers returns the desired usernames that do not have a role yet, and
+// the users that have it without being desired. Usernames are compared
+// regardless of case, as UAA does.
+func diffUsers(desired []string, current []models.UserFields) ([]string, []models.UserFields) {
+	currentNames := map[string]bool{}
+	for _, user := range current {
+		currentNames[strings.ToLower(user.Username)] = true
+	}
+
+	desiredNames := map[string]bool{}
+	added := []string{}
+	for _, username := range desired {
+		name := strings.ToLower(username)
+		if !currentNames[name] && !desiredNames[name] {
+			added = append(added, username)
+		}
+		desiredNames[name] = true
+	}
+
+	removed := []models.UserFields{}
+	for _, user := range current {
+		if !desiredNames[strings.ToLower(user.Username)] {
+			removed = append(removed, user)
+		}
+	}
+
+	return added, removed
+}
+
+// OrgConfig is the desired state of a set of orgs. A role, security group
+// or space list that is left out is not managed, while an empty list means
+// that nobody has the role, that no group is bound, or that the org has no
+// spaces.
+type OrgConfig struct {
+	Orgs []OrgConfigOrg `yaml:"orgs"`
+}
+
+type OrgConfigOrg struct {
+	Name            string           `yaml:"name"`
+	Quota           string           `yaml:"quota"`
+	Managers        []string         `yaml:"managers"`
+	BillingManagers []string         `yaml:"billing_managers"`
+	Auditors        []string         `yaml:"auditors"`
+	Spaces          []OrgConfigSpace `yaml:"spaces"`
+}
+
+type OrgConfigSpace struct {
+	Name           string   `yaml:"name"`
+	Quota          string   `yaml:"quota"`
+	Managers       []string `yaml:"managers"`
+	Developers     []string `yaml:"developers"`
+	Auditors       []string `yaml:"auditors"`
+	SecurityGroups []string `yaml:"security_groups"`
+}
+
+var (
+	orgConfigOrgFields   = []string{"name", "quota", "managers", "billing_managers", "auditors", "spaces"}
+	orgConfigSpaceFields = []string{"name", "quota", "managers", "developers", "auditors", "security_groups"}
+)
+
+// ReadOrgConfig reads the org config in the YAML, or JSON, file at path.
+// The error lists every problem found in the file.
+func ReadOrgConfig(path string) (OrgConfig, error) {
+	config := OrgConfig{}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+
+	err = yaml.Unmarshal(contents, &config)
+	if err != nil {
+		return config, errors.New(T("Incorrect org config format: file: {{.File}}\n{{.Error}}",
+			map[string]interface{}{"File": path, "Error": err.Error()}))
+	}
+
+	// the document is decoded a second time without a schema, to report the
+	// fields that would otherwise be ignored
+	document := map[string]interface{}{}
+	_ = yaml.Unmarshal(contents, &document)
+
+	problems := unknownOrgConfigFields(document)
+	problems = append(problems, config.validate()...)
+	if len(problems) > 0 {
+		return config, errors.New(T("Invalid org config in file: {{.File}}",
+			map[string]interface{}{"File": path}) + "\n   " + strings.Join(problems, "\n   "))
+	}
+
+	return config, nil
+}
+
+func unknownOrgConfigFields(document map[string]interface{}) []string {
+	problems := []string{}
+
+	for _, name := range sortedKeys(document) {
+		if name != "orgs" {
+			problems = append(problems, T("unknown field {{.Field}}",
+				map[string]interface{}{"Field": strconv.Quote(name)}))
+		}
+	}
+
+	orgs, _ := document["orgs"].([]interface{})
+	for i, org := range orgs {
+		orgFields, _ := org.(map[interface{}]interface{})
+		problems = append(problems, unknownFields(orgFields, orgConfigOrgFields, T("org {{.Index}}", map[string]interface{}{"Index": i + 1}))...)
+
+		spaces, _ := orgFields["spaces"].([]interface{})
+		for j, space := range spaces {
+			spaceFields, _ := space.(map[interface{}]interface{})
+			problems = append(problems, unknownFields(spaceFields, orgConfigSpaceFields,
+				T("org {{.Index}}, space {{.SpaceIndex}}", map[string]interface{}{"Index": i + 1, "SpaceIndex": j + 1}))...)
+		}
+	}
+
+	return problems
+}
+
+func unknownFields(fields map[interface{}]interface{}, known []string, location string) []string {
+	names := map[string]interface{}{}
+	for name, value := range fields {
+		names[fmt.Sprint(name)] = value
+	}
+
+	problems := []string{}
+	for _, name := range sortedKeys(names) {
+		if !containsString(known, name) {
+			problems = append(problems, T("{{.Location}}: unknown field {{.Field}}",
+				map[string]interface{}{"Location": location, "Field": strconv.Quote(name)}))
+		}
+	}
+	return problems
+}
+
+func (config OrgConfig) validate() []string {
+	problems := []string{}
+	orgNames := map[string]bool{}
+
+	for i, org := range config.Orgs {
+		location := T("org {{.Index}}", map[string]interface{}{"Index": i + 1})
+		if org.Name == "" {
+			problems = append(problems, T("{{.Location}}: name is missing", map[string]interface{}{"Location": location}))
+		} else if orgNames[org.Name] {
+			problems = append(problems, T("{{.Location}}: org {{.Name}} is listed more than once",
+				map[string]interface{}{"Location": location, "Name": org.Name}))
+		}
+		orgNames[org.Name] = true
+
+		problems = append(problems, validateLists(location, org.Managers, org.BillingManagers, org.Auditors)...)
+
+		spaceNames := map[string]bool{}
+		for j, space := range org.Spaces {
+			location := T("org {{.Index}}, space {{.SpaceIndex}}", map[string]interface{}{"Index": i + 1, "SpaceIndex": j + 1})
+			if space.Name == "" {
+				problems = append(problems, T("{{.Location}}: name is missing", map[string]interface{}{"Location": location}))
+			} else if spaceNames[space.Name] {
+				problems = append(problems, T("{{.Location}}: space {{.Name}} is listed more than once",
+					map[string]interface{}{"Location": location, "Name": space.Name}))
+			}
+			spaceNames[space.Name] = true
+
+			problems = append(problems, validateLists(location, space.Managers, space.Developers, space.Auditors, space.SecurityGroups)...)
+		}
+	}
+
+	return problems
+}
+
+func validateLists(location string, lists ...[]string) []string {
+	for _, list := range lists {
+		for _, name := range list {
+			if strings.TrimSpace(name) == "" {
+				return []string{T("{{.Location}}: lists cannot have empty entries", map[string]interface{}{"Location": location})}
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys(fields map[string]interface{}) []string {
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func containsString(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
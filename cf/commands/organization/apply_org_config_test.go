@@ -0,0 +1,433 @@
+package organization_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/featureflags/featureflagsfakes"
+	"code.cloudfoundry.org/cli/cf/api/organizations/organizationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/quotas/quotasfakes"
+	"code.cloudfoundry.org/cli/cf/api/securitygroups/securitygroupsfakes"
+	securitygroupspacesfakes "code.cloudfoundry.org/cli/cf/api/securitygroups/spaces/spacesfakes"
+	"code.cloudfoundry.org/cli/cf/api/spacequotas/spacequotasfakes"
+	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("apply-org-config command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *requirementsfakes.FakeFactory
+		orgRepo             *organizationsfakes.FakeOrganizationRepository
+		spaceRepo           *spacesfakes.FakeSpaceRepository
+		quotaRepo           *quotasfakes.FakeQuotaRepository
+		spaceQuotaRepo      *spacequotasfakes.FakeSpaceQuotaRepository
+		userRepo            *apifakes.FakeUserRepository
+		flagRepo            *featureflagsfakes.FakeFeatureFlagRepository
+		securityGroupRepo   *securitygroupsfakes.FakeSecurityGroupRepo
+		securityGroupBinder *securitygroupspacesfakes.FakeSecurityGroupSpaceBinder
+		deps                commandregistry.Dependency
+
+		configFile *os.File
+		newOrgs    map[string]bool
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetOrganizationRepository(orgRepo)
+		deps.RepoLocator = deps.RepoLocator.SetSpaceRepository(spaceRepo)
+		deps.RepoLocator = deps.RepoLocator.SetQuotaRepository(quotaRepo)
+		deps.RepoLocator = deps.RepoLocator.SetSpaceQuotaRepository(spaceQuotaRepo)
+		deps.RepoLocator = deps.RepoLocator.SetUserRepository(userRepo)
+		deps.RepoLocator = deps.RepoLocator.SetFeatureFlagRepository(flagRepo)
+		deps.RepoLocator = deps.RepoLocator.SetSecurityGroupRepository(securityGroupRepo)
+		deps.RepoLocator = deps.RepoLocator.SetSecurityGroupSpaceBinder(securityGroupBinder)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("apply-org-config").SetDependency(deps, pluginCall))
+	}
+
+	writeConfig := func(contents string) {
+		_, err := configFile.WriteString(contents)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("apply-org-config", append([]string{configFile.Name()}, args...), requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		config = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		orgRepo = new(organizationsfakes.FakeOrganizationRepository)
+		spaceRepo = new(spacesfakes.FakeSpaceRepository)
+		quotaRepo = new(quotasfakes.FakeQuotaRepository)
+		spaceQuotaRepo = new(spacequotasfakes.FakeSpaceQuotaRepository)
+		userRepo = new(apifakes.FakeUserRepository)
+		flagRepo = new(featureflagsfakes.FakeFeatureFlagRepository)
+		securityGroupRepo = new(securitygroupsfakes.FakeSecurityGroupRepo)
+		securityGroupBinder = new(securitygroupspacesfakes.FakeSecurityGroupSpaceBinder)
+
+		var err error
+		configFile, err = ioutil.TempFile("", "org-config")
+		Expect(err).NotTo(HaveOccurred())
+
+		newOrgs = map[string]bool{}
+		orgRepo.CreateStub = func(org models.Organization) error {
+			newOrgs[org.Name] = true
+			return nil
+		}
+		orgRepo.FindByNameStub = func(name string) (models.Organization, error) {
+			switch {
+			case name == "existing-org":
+				org := models.Organization{
+					Spaces: []models.SpaceFields{
+						{Name: "dev", GUID: "dev-guid"},
+						{Name: "old", GUID: "old-guid"},
+					},
+					SpaceQuotas: []models.SpaceQuota{
+						{Name: "small", GUID: "small-guid"},
+						{Name: "big", GUID: "big-guid"},
+					},
+				}
+				org.Name = name
+				org.GUID = "existing-org-guid"
+				org.QuotaDefinition = models.QuotaFields{Name: "default", GUID: "default-guid"}
+				return org, nil
+			case newOrgs[name]:
+				org := models.Organization{}
+				org.Name = name
+				org.GUID = name + "-guid"
+				return org, nil
+			}
+			return models.Organization{}, errors.NewModelNotFoundError("Org", name)
+		}
+
+		spaceRepo.FindByNameInOrgReturns(models.Space{
+			SpaceFields:    models.SpaceFields{Name: "dev", GUID: "dev-guid"},
+			SpaceQuotaGUID: "big-guid",
+			SecurityGroups: []models.SecurityGroupFields{{Name: "public", GUID: "public-guid"}},
+		}, nil)
+		spaceRepo.CreateStub = func(name, orgGUID, spaceQuotaGUID string) (models.Space, error) {
+			return models.Space{SpaceFields: models.SpaceFields{Name: name, GUID: name + "-guid"}}, nil
+		}
+
+		quotaRepo.FindByNameReturns(models.QuotaFields{Name: "large", GUID: "large-guid"}, nil)
+		securityGroupRepo.ReadStub = func(name string) (models.SecurityGroup, error) {
+			group := models.SecurityGroup{}
+			group.Name = name
+			group.GUID = name + "-guid"
+			return group, nil
+		}
+
+		userRepo.FindByUsernameStub = func(username string) (models.UserFields, error) {
+			return models.UserFields{Username: username, GUID: username + "-guid"}, nil
+		}
+		userRepo.ListUsersInOrgForRoleStub = func(orgGUID string, role models.Role) ([]models.UserFields, error) {
+			if role == models.RoleOrgManager {
+				return []models.UserFields{{Username: "alice", GUID: "alice-guid"}, {Username: "eve", GUID: "eve-guid"}}, nil
+			}
+			return []models.UserFields{}, nil
+		}
+		userRepo.ListUsersInSpaceForRoleStub = func(spaceGUID string, role models.Role) ([]models.UserFields, error) {
+			if role == models.RoleSpaceDeveloper {
+				return []models.UserFields{{Username: "mallory", GUID: "mallory-guid"}}, nil
+			}
+			return []models.UserFields{}, nil
+		}
+	})
+
+	AfterEach(func() {
+		configFile.Close()
+		os.Remove(configFile.Name())
+	})
+
+	Describe("requirements", func() {
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand()).To(BeFalse())
+		})
+
+		It("fails with usage when no file is given", func() {
+			testcmd.RunCLICommand("apply-org-config", []string{}, requirementsFactory, updateCommandDependency, false, ui)
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires an argument"}))
+		})
+	})
+
+	Context("when the file differs from the current state", func() {
+		BeforeEach(func() {
+			writeConfig(`
+orgs:
+- name: existing-org
+  quota: large
+  managers: [alice, bob]
+  spaces:
+  - name: dev
+    quota: small
+    developers: [carol]
+    security_groups: [db]
+  - name: new-space
+    managers: [alice]
+- name: new-org
+  auditors: [dave]
+`)
+		})
+
+		It("prints the plan and applies everything but the removals", func() {
+			Expect(runCommand()).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Comparing org config", configFile.Name(), "my-user"},
+				[]string{"OK"},
+				[]string{"Plan:"},
+				[]string{"~ set quota of org existing-org to large (was default)"},
+				[]string{"+ add user bob as OrgManager of org existing-org"},
+				[]string{"~ set space quota of space dev in org existing-org to small (was big)"},
+				[]string{"+ add user carol as SpaceDeveloper of space dev in org existing-org"},
+				[]string{"+ bind security group db to space dev in org existing-org"},
+				[]string{"+ create space new-space in org existing-org"},
+				[]string{"+ add user alice as SpaceManager of space new-space in org existing-org"},
+				[]string{"+ create org new-org"},
+				[]string{"+ add user dave as OrgAuditor of org new-org"},
+				[]string{"TIP: 4 removals", "--prune"},
+				[]string{"Applying 9 changes..."},
+				[]string{"OK"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"add user alice as OrgManager"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"remove user eve"}))
+
+			orgGUID, quotaGUID := quotaRepo.AssignQuotaToOrgArgsForCall(0)
+			Expect(orgGUID).To(Equal("existing-org-guid"))
+			Expect(quotaGUID).To(Equal("large-guid"))
+
+			spaceGUID, spaceQuotaGUID := spaceQuotaRepo.AssociateSpaceWithQuotaArgsForCall(0)
+			Expect(spaceGUID).To(Equal("dev-guid"))
+			Expect(spaceQuotaGUID).To(Equal("small-guid"))
+
+			groupGUID, spaceGUID := securityGroupBinder.BindSpaceArgsForCall(0)
+			Expect(groupGUID).To(Equal("db-guid"))
+			Expect(spaceGUID).To(Equal("dev-guid"))
+
+			name, orgGUID, spaceQuotaGUID := spaceRepo.CreateArgsForCall(0)
+			Expect(name).To(Equal("new-space"))
+			Expect(orgGUID).To(Equal("existing-org-guid"))
+			Expect(spaceQuotaGUID).To(BeEmpty())
+
+			Expect(orgRepo.CreateCallCount()).To(Equal(1))
+			Expect(orgRepo.CreateArgsForCall(0).Name).To(Equal("new-org"))
+
+			Expect(userRepo.SetOrgRoleByGUIDCallCount()).To(Equal(2))
+			userGUID, orgGUID, role := userRepo.SetOrgRoleByGUIDArgsForCall(0)
+			Expect([]interface{}{userGUID, orgGUID, role}).To(Equal([]interface{}{"bob-guid", "existing-org-guid", models.RoleOrgManager}))
+			userGUID, orgGUID, role = userRepo.SetOrgRoleByGUIDArgsForCall(1)
+			Expect([]interface{}{userGUID, orgGUID, role}).To(Equal([]interface{}{"dave-guid", "new-org-guid", models.RoleOrgAuditor}))
+
+			Expect(userRepo.SetSpaceRoleByGUIDCallCount()).To(Equal(2))
+			userGUID, spaceGUID, orgGUID, role = userRepo.SetSpaceRoleByGUIDArgsForCall(0)
+			Expect([]interface{}{userGUID, spaceGUID, orgGUID, role}).To(Equal([]interface{}{"carol-guid", "dev-guid", "existing-org-guid", models.RoleSpaceDeveloper}))
+			userGUID, spaceGUID, orgGUID, role = userRepo.SetSpaceRoleByGUIDArgsForCall(1)
+			Expect([]interface{}{userGUID, spaceGUID, orgGUID, role}).To(Equal([]interface{}{"alice-guid", "new-space-guid", "existing-org-guid", models.RoleSpaceManager}))
+
+			Expect(userRepo.UnsetOrgRoleByGUIDCallCount()).To(Equal(0))
+			Expect(userRepo.UnsetSpaceRoleByGUIDCallCount()).To(Equal(0))
+			Expect(securityGroupBinder.UnbindSpaceCallCount()).To(Equal(0))
+			Expect(spaceRepo.DeleteCallCount()).To(Equal(0))
+		})
+
+		It("sets roles by username when the API allows it", func() {
+			config.SetAPIVersion("2.37.0")
+			flagRepo.FindByNameReturns(models.FeatureFlag{Name: "set_roles_by_username", Enabled: true}, nil)
+			userRepo.ListUsersInOrgForRoleWithNoUAAStub = userRepo.ListUsersInOrgForRoleStub
+			userRepo.ListUsersInSpaceForRoleWithNoUAAStub = userRepo.ListUsersInSpaceForRoleStub
+
+			Expect(runCommand()).To(BeTrue())
+
+			Expect(userRepo.FindByUsernameCallCount()).To(Equal(0))
+			username, orgGUID, role := userRepo.SetOrgRoleByUsernameArgsForCall(0)
+			Expect([]interface{}{username, orgGUID, role}).To(Equal([]interface{}{"bob", "existing-org-guid", models.RoleOrgManager}))
+			username, spaceGUID, orgGUID, role := userRepo.SetSpaceRoleByUsernameArgsForCall(0)
+			Expect([]interface{}{username, spaceGUID, orgGUID, role}).To(Equal([]interface{}{"carol", "dev-guid", "existing-org-guid", models.RoleSpaceDeveloper}))
+		})
+
+		Context("with --prune", func() {
+			It("also removes what is not in the file after confirmation", func() {
+				ui.Inputs = []string{"y"}
+				Expect(runCommand("--prune")).To(BeTrue())
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"- remove user eve as OrgManager of org existing-org"},
+					[]string{"- remove user mallory as SpaceDeveloper of space dev in org existing-org"},
+					[]string{"- unbind security group public from space dev in org existing-org"},
+					[]string{"- delete space old of org existing-org"},
+					[]string{"Applying 13 changes..."},
+				))
+				Expect(ui.Prompts).To(ContainSubstrings([]string{"Really apply this plan, including its removals?"}))
+
+				userGUID, orgGUID, role := userRepo.UnsetOrgRoleByGUIDArgsForCall(0)
+				Expect([]interface{}{userGUID, orgGUID, role}).To(Equal([]interface{}{"eve-guid", "existing-org-guid", models.RoleOrgManager}))
+				userGUID, spaceGUID, role := userRepo.UnsetSpaceRoleByGUIDArgsForCall(0)
+				Expect([]interface{}{userGUID, spaceGUID, role}).To(Equal([]interface{}{"mallory-guid", "dev-guid", models.RoleSpaceDeveloper}))
+				groupGUID, spaceGUID := securityGroupBinder.UnbindSpaceArgsForCall(0)
+				Expect([]interface{}{groupGUID, spaceGUID}).To(Equal([]interface{}{"public-guid", "dev-guid"}))
+				Expect(spaceRepo.DeleteArgsForCall(0)).To(Equal("old-guid"))
+			})
+
+			It("changes nothing when the plan is not confirmed", func() {
+				ui.Inputs = []string{"n"}
+				Expect(runCommand("--prune")).To(BeTrue())
+
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Applying"}))
+				Expect(quotaRepo.AssignQuotaToOrgCallCount()).To(Equal(0))
+				Expect(spaceRepo.DeleteCallCount()).To(Equal(0))
+			})
+
+			It("does not ask for confirmation with -f", func() {
+				Expect(runCommand("--prune", "-f")).To(BeTrue())
+
+				Expect(ui.Prompts).To(BeEmpty())
+				Expect(spaceRepo.DeleteCallCount()).To(Equal(1))
+			})
+		})
+
+		It("reports how far it got when a change fails", func() {
+			securityGroupBinder.BindSpaceReturns(errors.New("bind failed"))
+
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Could not bind security group db to space dev in org existing-org: bind failed"},
+				[]string{"Applied 4 of 9 changes."},
+			))
+			Expect(spaceRepo.CreateCallCount()).To(Equal(0))
+		})
+
+		It("changes nothing when a quota in the file does not exist", func() {
+			quotaRepo.FindByNameReturns(models.QuotaFields{}, errors.NewModelNotFoundError("Quota", "large"))
+
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Quota", "large", "not found"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Plan:"}))
+			Expect(userRepo.SetOrgRoleByGUIDCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the file matches the current state", func() {
+		BeforeEach(func() {
+			writeConfig(`{"orgs": [{"name": "existing-org", "managers": ["alice", "eve"], "spaces": [{"name": "dev"}, {"name": "old"}]}]}`)
+		})
+
+		It("has nothing to change", func() {
+			Expect(runCommand("--prune")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Nothing to change"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Applying"}))
+		})
+	})
+
+	Context("when an org in the file has no spaces key", func() {
+		BeforeEach(func() {
+			writeConfig("orgs:\n- name: existing-org\n  managers: [alice, eve]\n")
+		})
+
+		It("does not delete the spaces of the org with --prune", func() {
+			Expect(runCommand("--prune", "-f")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Nothing to change"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"delete space"}))
+			Expect(spaceRepo.DeleteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when an org in the file has an empty list of spaces", func() {
+		BeforeEach(func() {
+			writeConfig("orgs:\n- name: existing-org\n  spaces: []\n")
+		})
+
+		It("deletes every space of the org with --prune", func() {
+			Expect(runCommand("--prune", "-f")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"- delete space dev of org existing-org"},
+				[]string{"- delete space old of org existing-org"},
+			))
+			Expect(spaceRepo.DeleteCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when usernames in the file differ from the current ones only in case", func() {
+		BeforeEach(func() {
+			writeConfig("orgs:\n- name: existing-org\n  managers: [Alice, EVE, alice]\n")
+		})
+
+		It("neither adds nor removes the users", func() {
+			Expect(runCommand("--prune", "-f")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Nothing to change"}))
+			Expect(userRepo.SetOrgRoleByGUIDCallCount()).To(Equal(0))
+			Expect(userRepo.UnsetOrgRoleByGUIDCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when a space quota in the file does not exist in the org", func() {
+		BeforeEach(func() {
+			writeConfig("orgs:\n- name: existing-org\n  spaces:\n  - name: dev\n    quota: huge\n")
+		})
+
+		It("fails before changing anything", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Space quota huge for space dev not found in org existing-org"}))
+		})
+	})
+
+	Context("when the file is invalid", func() {
+		BeforeEach(func() {
+			writeConfig(`
+orgs:
+- name: existing-org
+  manager: [alice]
+  spaces:
+  - name: dev
+  - name: dev
+    developer: [bob]
+- quota: large
+`)
+		})
+
+		It("lists every problem", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Invalid org config in file", configFile.Name()},
+				[]string{`org 1: unknown field "manager"`},
+				[]string{`org 1, space 2: unknown field "developer"`},
+				[]string{"org 1, space 2: space dev is listed more than once"},
+				[]string{"org 2: name is missing"},
+			))
+			Expect(orgRepo.FindByNameCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the file is not YAML", func() {
+		BeforeEach(func() {
+			writeConfig("orgs: [")
+		})
+
+		It("fails with the parse error", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect org config format", configFile.Name()}))
+		})
+	})
+})
@@ -70,6 +70,7 @@ func (cmd ListOrgs) Execute(fc flags.FlagContext) error {
 
 	noOrgs := true
 	table := cmd.ui.Table([]string{T("name")})
+	table.SetFieldNames("name")
 
 	orgs, err := cmd.orgRepo.ListOrgs(orgLimit)
 	if err != nil {
@@ -31,6 +31,7 @@ type PluginInstall struct {
 	pluginConfig pluginconfig.PluginConfiguration
 	pluginRepo   pluginrepo.PluginRepo
 	checksum     utils.Sha1Checksum
+	verifier     utils.SignatureVerifier
 	rpcService   *pluginRPCService.CliRpcService
 }
 
@@ -42,14 +43,17 @@ func (cmd *PluginInstall) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["r"] = &flags.StringFlag{ShortName: "r", Usage: T("Name of a registered repository where the specified plugin is located")}
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force install of plugin without confirmation")}
+	fs["allow-unsigned"] = &flags.BoolFlag{Name: "allow-unsigned", Usage: T("Install the plugin even if its binary is not signed by a trusted publisher")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "install-plugin",
 		Description: T("Install CLI plugin"),
 		Usage: []string{
-			T(`CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME) [-f]
+			T(`CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME) [-f] [--allow-unsigned]
 
-   Prompts for confirmation unless '-f' is provided.`),
+   Prompts for confirmation unless '-f' is provided.
+
+   The plugin binary must be signed by a publisher whose public key is listed in the trusted_plugin_keys file of the CF home directory. The signature is taken from the repository metadata, or from a file next to the binary with a .sig extension.`),
 		},
 		Examples: []string{
 			"CF_NAME install-plugin ~/Downloads/plugin-foobar",
@@ -77,6 +81,7 @@ func (cmd *PluginInstall) SetDependency(deps commandregistry.Dependency, pluginC
 	cmd.pluginConfig = deps.PluginConfig
 	cmd.pluginRepo = deps.PluginRepo
 	cmd.checksum = deps.ChecksumUtil
+	cmd.verifier = deps.SignatureVerifier
 
 	//reset rpc registration in case there is other running instance,
 	//each service can only be registered once
@@ -114,15 +119,20 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 	defer removeTmpFile()
 
 	deps := &plugininstaller.Context{
-		Checksummer:    cmd.checksum,
-		GetPluginRepos: cmd.config.PluginRepos,
-		FileDownloader: fileDownloader,
-		PluginRepo:     cmd.pluginRepo,
-		RepoName:       c.String("r"),
-		UI:             cmd.ui,
+		AllowUnsigned:     c.Bool("allow-unsigned"),
+		Checksummer:       cmd.checksum,
+		GetPluginRepos:    cmd.config.PluginRepos,
+		FileDownloader:    fileDownloader,
+		PluginRepo:        cmd.pluginRepo,
+		RepoName:          c.String("r"),
+		SignatureVerifier: cmd.verifier,
+		UI:                cmd.ui,
 	}
 	installer := plugininstaller.NewPluginInstaller(deps)
-	pluginSourceFilepath := installer.Install(c.Args()[0])
+	pluginSourceFilepath, err := installer.Install(c.Args()[0])
+	if err != nil {
+		return err
+	}
 
 	_, pluginExecutableName := filepath.Split(pluginSourceFilepath)
 
@@ -135,7 +145,7 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 
 	pluginDestinationFilepath := filepath.Join(cmd.pluginConfig.GetPluginPath(), pluginExecutableName)
 
-	err := cmd.ensurePluginBinaryWithSameFileNameDoesNotAlreadyExist(pluginDestinationFilepath, pluginExecutableName)
+	err = cmd.ensurePluginBinaryWithSameFileNameDoesNotAlreadyExist(pluginDestinationFilepath, pluginExecutableName)
 	if err != nil {
 		return err
 	}
@@ -210,6 +220,13 @@ func (cmd *PluginInstall) ensurePluginIsSafeForInstallation(pluginMetadata *plug
 		)
 	}
 
+	return ensurePluginCommandsDoNotConflict(pluginMetadata, pluginSourceFilepath, plugins)
+}
+
+// ensurePluginCommandsDoNotConflict checks that the commands and aliases of
+// the plugin do not clash with core commands or with the commands of the
+// installed plugins.
+func ensurePluginCommandsDoNotConflict(pluginMetadata *plugin.PluginMetadata, pluginSourceFilepath string, plugins map[string]pluginconfig.PluginMetadata) error {
 	if pluginMetadata.Commands == nil {
 		return errors.New(T(
 			"Error getting command list from plugin {{.FilePath}}",
@@ -292,24 +309,34 @@ func (cmd *PluginInstall) installPlugin(pluginMetadata *plugin.PluginMetadata, p
 }
 
 func (cmd *PluginInstall) runBinaryAndObtainPluginMetadata(pluginSourceFilepath string) (*plugin.PluginMetadata, error) {
-	err := cmd.rpcService.Start()
+	return runBinaryAndObtainPluginMetadata(cmd.rpcService, pluginSourceFilepath)
+}
+
+// runBinaryAndObtainPluginMetadata runs the plugin binary with the
+// SendMetadata handshake and returns the metadata it sent back.
+func runBinaryAndObtainPluginMetadata(rpcService *pluginRPCService.CliRpcService, pluginSourceFilepath string) (*plugin.PluginMetadata, error) {
+	err := rpcService.Start()
 	if err != nil {
 		return nil, err
 	}
-	defer cmd.rpcService.Stop()
+	defer rpcService.Stop()
+
+	c := rpcService.RpcCmd
+	c.MetadataMutex.Lock()
+	c.PluginMetadata = &plugin.PluginMetadata{}
+	c.MetadataMutex.Unlock()
 
-	err = cmd.runPluginBinary(pluginSourceFilepath, cmd.rpcService.Port())
+	err = runPluginBinary(pluginSourceFilepath, rpcService.Port())
 	if err != nil {
 		return nil, err
 	}
 
-	c := cmd.rpcService.RpcCmd
 	c.MetadataMutex.RLock()
 	defer c.MetadataMutex.RUnlock()
 	return c.PluginMetadata, nil
 }
 
-func (cmd *PluginInstall) runPluginBinary(location string, servicePort string) error {
+func runPluginBinary(location string, servicePort string) error {
 	pluginInvocation := exec.Command(location, servicePort, "SendMetadata")
 
 	err := pluginInvocation.Run()
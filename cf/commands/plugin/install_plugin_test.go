@@ -1,6 +1,7 @@
 package plugin_test
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
 	"code.cloudfoundry.org/cli/cf/actors/pluginrepo/pluginrepofakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commandregistry/commandregistryfakes"
@@ -23,10 +25,9 @@ import (
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils"
 	"code.cloudfoundry.org/cli/utils/utilsfakes"
 
-	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
-
 	. "code.cloudfoundry.org/cli/testhelpers/matchers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -40,6 +41,7 @@ var _ = Describe("Install", func() {
 		pluginConfig        *pluginconfigfakes.FakePluginConfiguration
 		fakePluginRepo      *pluginrepofakes.FakePluginRepo
 		fakeChecksum        *utilsfakes.FakeSha1Checksum
+		fakeVerifier        *utilsfakes.FakeSignatureVerifier
 
 		pluginFile *os.File
 		homeDir    string
@@ -62,6 +64,7 @@ var _ = Describe("Install", func() {
 		deps.PluginConfig = pluginConfig
 		deps.PluginRepo = fakePluginRepo
 		deps.ChecksumUtil = fakeChecksum
+		deps.SignatureVerifier = fakeVerifier
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("install-plugin").SetDependency(deps, pluginCall))
 	}
 
@@ -72,6 +75,8 @@ var _ = Describe("Install", func() {
 		config = testconfig.NewRepositoryWithDefaults()
 		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
 		fakeChecksum = new(utilsfakes.FakeSha1Checksum)
+		fakeVerifier = new(utilsfakes.FakeSignatureVerifier)
+		fakeVerifier.TrustedKeysPathReturns("/home/user/.cf/trusted_plugin_keys")
 
 		dir, err := os.Getwd()
 		if err != nil {
@@ -107,10 +112,15 @@ var _ = Describe("Install", func() {
 		os.Remove(homeDir)
 	})
 
-	runCommand := func(args ...string) bool {
+	runCommandVerifyingSignature := func(args ...string) bool {
 		return testcmd.RunCLICommand("install-plugin", args, requirementsFactory, updateCommandDependency, false, ui)
 	}
 
+	runCommand := func(args ...string) bool {
+		// the plugin fixtures are not signed
+		return runCommandVerifyingSignature(append(args, "--allow-unsigned")...)
+	}
+
 	Describe("requirements", func() {
 		It("fails with usage when not provided a path to the plugin executable", func() {
 			Expect(runCommand()).ToNot(HavePassedRequirements())
@@ -182,11 +192,11 @@ var _ = Describe("Install", func() {
 			Context("downloads the binary for the machine's OS", func() {
 				Context("when binary is not available", func() {
 					It("informs user when binary is not available for OS", func() {
-						p := clipr.Plugin{
+						p := pluginrepo.Plugin{
 							Name: "plugin1",
 						}
-						result := make(map[string][]clipr.Plugin)
-						result["repo1"] = []clipr.Plugin{p}
+						result := make(map[string][]pluginrepo.Plugin)
+						result["repo1"] = []pluginrepo.Plugin{p}
 
 						config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: ""})
 						fakePluginRepo.GetPluginsReturns(result, nil)
@@ -210,9 +220,9 @@ var _ = Describe("Install", func() {
 
 						fakeChecksum.CheckSha1Returns(true)
 
-						p := clipr.Plugin{
+						p := pluginrepo.Plugin{
 							Name: "plugin1",
-							Binaries: []clipr.Binary{
+							Binaries: []pluginrepo.Binary{
 								{
 									Platform: "osx",
 									Url:      testServer.URL + "/test.exe",
@@ -235,8 +245,8 @@ var _ = Describe("Install", func() {
 								},
 							},
 						}
-						result := make(map[string][]clipr.Plugin)
-						result["repo1"] = []clipr.Plugin{p}
+						result := make(map[string][]pluginrepo.Plugin)
+						result["repo1"] = []pluginrepo.Plugin{p}
 
 						config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: ""})
 						fakePluginRepo.GetPluginsReturns(result, nil)
@@ -254,11 +264,12 @@ var _ = Describe("Install", func() {
 					It("reports error downloaded file's sha1 does not match the sha1 in metadata", func() {
 						fakeChecksum.CheckSha1Returns(false)
 
-						runCommand("plugin1", "-r", "repo1", "-f")
+						Expect(runCommand("plugin1", "-r", "repo1", "-f")).To(BeFalse())
 						Expect(ui.Outputs()).To(ContainSubstrings(
 							[]string{"FAILED"},
 							[]string{"checksum does not match"},
 						))
+						Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Installing plugin"}))
 
 					})
 
@@ -325,7 +336,7 @@ var _ = Describe("Install", func() {
 				})
 
 				It("reports error if local file is not found at given path", func() {
-					runCommand("./no/file/is/here.exe", "-f")
+					Expect(runCommand("./no/file/is/here.exe", "-f")).To(BeFalse())
 
 					Expect(ui.Outputs()).To(ContainSubstrings(
 						[]string{"File not found locally",
@@ -338,6 +349,192 @@ var _ = Describe("Install", func() {
 
 	})
 
+	Describe("signature verification", func() {
+		var signaturePath string
+
+		BeforeEach(func() {
+			signaturePath = pluginFile.Name() + ".sig"
+		})
+
+		AfterEach(func() {
+			os.Remove(signaturePath)
+		})
+
+		Context("when the plugin binary is not signed", func() {
+			It("refuses to install the plugin", func() {
+				runCommandVerifyingSignature(pluginFile.Name(), "-f")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"The plugin binary is not signed."},
+					[]string{"--allow-unsigned"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Installing plugin"}))
+			})
+
+			It("installs the plugin with a warning when --allow-unsigned is provided", func() {
+				runCommandVerifyingSignature(pluginFile.Name(), "-f", "--allow-unsigned")
+
+				Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"Installing an unsigned plugin binary."}))
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Installing plugin"}))
+			})
+		})
+
+		Context("when a signature file is next to the plugin binary", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(signaturePath, []byte("c2lnbmF0dXJl\n"), 0600)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("verifies the signature against the trusted keys", func() {
+				fakeVerifier.VerifyReturns("acme", nil)
+
+				runCommandVerifyingSignature(pluginFile.Name(), "-f")
+
+				Expect(fakeVerifier.VerifyCallCount()).To(Equal(1))
+				path, signature := fakeVerifier.VerifyArgsForCall(0)
+				Expect(path).To(Equal(pluginFile.Name()))
+				Expect(signature).To(Equal("c2lnbmF0dXJl"))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Plugin signature verified, signed by acme"},
+					[]string{"Installing plugin"},
+				))
+			})
+
+			It("refuses to install the plugin when the signature is not from a trusted publisher", func() {
+				fakeVerifier.VerifyReturns("", utils.ErrUntrustedSignature)
+
+				runCommandVerifyingSignature(pluginFile.Name(), "-f")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"The plugin binary is not signed by a trusted publisher."},
+					[]string{"/home/user/.cf/trusted_plugin_keys"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Installing plugin"}))
+			})
+
+			It("installs the plugin with a warning when the publisher is not trusted and --allow-unsigned is provided", func() {
+				fakeVerifier.VerifyReturns("", utils.ErrUntrustedSignature)
+
+				runCommandVerifyingSignature(pluginFile.Name(), "-f", "--allow-unsigned")
+
+				Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"not signed by a trusted publisher"}))
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Installing plugin"}))
+			})
+
+			It("refuses to install the plugin when the trusted keys cannot be read", func() {
+				fakeVerifier.VerifyReturns("", errors.New("trusted_plugin_keys:3: invalid public key"))
+
+				runCommandVerifyingSignature(pluginFile.Name(), "-f", "--allow-unsigned")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Could not verify the plugin signature: trusted_plugin_keys:3: invalid public key"},
+				))
+			})
+		})
+
+		Context("when installing from an internet address", func() {
+			var (
+				testServer      *httptest.Server
+				signatureStatus int
+			)
+
+			BeforeEach(func() {
+				signatureStatus = http.StatusOK
+				testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/test.exe.sig" {
+						w.WriteHeader(signatureStatus)
+						fmt.Fprintln(w, "dXJsLXNpZ25hdHVyZQ==")
+						return
+					}
+					fmt.Fprintln(w, "abc")
+				}))
+			})
+
+			AfterEach(func() {
+				testServer.Close()
+			})
+
+			It("verifies the signature published next to the binary", func() {
+				fakeVerifier.VerifyReturns("acme", nil)
+
+				runCommandVerifyingSignature(testServer.URL+"/test.exe", "-f")
+
+				Expect(fakeVerifier.VerifyCallCount()).To(Equal(1))
+				_, signature := fakeVerifier.VerifyArgsForCall(0)
+				Expect(signature).To(Equal("dXJsLXNpZ25hdHVyZQ=="))
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Plugin signature verified, signed by acme"}))
+			})
+
+			It("treats the binary as unsigned when no signature is published", func() {
+				signatureStatus = http.StatusNotFound
+
+				runCommandVerifyingSignature(testServer.URL+"/test.exe", "-f")
+
+				Expect(fakeVerifier.VerifyCallCount()).To(Equal(0))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"The plugin binary is not signed."},
+					[]string{"FAILED"},
+				))
+			})
+
+			It("fails when the signature cannot be downloaded", func() {
+				signatureStatus = http.StatusInternalServerError
+
+				runCommandVerifyingSignature(testServer.URL+"/test.exe", "-f", "--allow-unsigned")
+
+				Expect(fakeVerifier.VerifyCallCount()).To(Equal(0))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Download attempt failed", testServer.URL + "/test.exe.sig"},
+					[]string{"the plugin signature could not be downloaded"},
+					[]string{"FAILED"},
+				))
+			})
+		})
+
+		Context("when installing from a repository", func() {
+			var testServer *httptest.Server
+
+			BeforeEach(func() {
+				testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprintln(w, "abc")
+				}))
+				fakeChecksum.CheckSha1Returns(true)
+
+				binaries := []pluginrepo.Binary{}
+				for _, platform := range []string{"osx", "win64", "win32", "linux32", "linux64"} {
+					binaries = append(binaries, pluginrepo.Binary{
+						Platform:  platform,
+						Url:       testServer.URL + "/test.exe",
+						Signature: "cmVwby1zaWduYXR1cmU=",
+					})
+				}
+
+				config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: ""})
+				fakePluginRepo.GetPluginsReturns(map[string][]pluginrepo.Plugin{
+					"repo1": {{Name: "plugin1", Binaries: binaries}},
+				}, nil)
+			})
+
+			AfterEach(func() {
+				testServer.Close()
+			})
+
+			It("verifies the signature from the repository metadata", func() {
+				fakeVerifier.VerifyReturns("acme", nil)
+
+				runCommandVerifyingSignature("plugin1", "-r", "repo1", "-f")
+
+				Expect(fakeVerifier.VerifyCallCount()).To(Equal(1))
+				_, signature := fakeVerifier.VerifyArgsForCall(0)
+				Expect(signature).To(Equal("cmVwby1zaWduYXR1cmU="))
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Plugin signature verified, signed by acme"}))
+			})
+		})
+	})
+
 	Describe("install failures", func() {
 		Context("when the plugin contains a 'help' command", func() {
 			It("fails", func() {
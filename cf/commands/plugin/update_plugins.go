@@ -0,0 +1,284 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/actors/plugininstaller"
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/utils"
+	"code.cloudfoundry.org/cli/utils/downloader"
+	"code.cloudfoundry.org/gofileutils/fileutils"
+	"github.com/blang/semver"
+
+	pluginRPCService "code.cloudfoundry.org/cli/plugin/rpc"
+)
+
+type UpdatePlugins struct {
+	ui           terminal.UI
+	config       coreconfig.Reader
+	pluginConfig pluginconfig.PluginConfiguration
+	pluginRepo   pluginrepo.PluginRepo
+	checksum     utils.Sha1Checksum
+	verifier     utils.SignatureVerifier
+	rpcService   *pluginRPCService.CliRpcService
+}
+
+func init() {
+	commandregistry.Register(&UpdatePlugins{})
+}
+
+func (cmd *UpdatePlugins) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["all"] = &flags.BoolFlag{Name: "all", Usage: T("Update all installed plugins")}
+	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force update of plugins without confirmation")}
+	fs["allow-unsigned"] = &flags.BoolFlag{Name: "allow-unsigned", Usage: T("Install the plugin even if its binary is not signed by a trusted publisher")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "update-plugins",
+		Description: T("Update installed plugins to the latest version in the added plugin repositories"),
+		Usage: []string{
+			T(`CF_NAME update-plugins (--all | PLUGIN_NAME) [-f] [--allow-unsigned]
+
+   Prompts for confirmation unless '-f' is provided. A plugin whose new version fails to start is restored to its installed version.`),
+		},
+		Examples: []string{
+			"CF_NAME update-plugins --all",
+			"CF_NAME update-plugins plugin-echo -f",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *UpdatePlugins) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) > 1 || (len(fc.Args()) == 1) == fc.Bool("all") {
+		cmd.ui.Failed(T("Incorrect Usage. Requires a plugin name or --all\n\n") + commandregistry.Commands.CommandUsage("update-plugins"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments with --all %t", len(fc.Args()), fc.Bool("all"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs, nil
+}
+
+func (cmd *UpdatePlugins) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.pluginConfig = deps.PluginConfig
+	cmd.pluginRepo = deps.PluginRepo
+	cmd.checksum = deps.ChecksumUtil
+	cmd.verifier = deps.SignatureVerifier
+
+	//reset rpc registration in case there is other running instance,
+	//each service can only be registered once
+	server := rpc.NewServer()
+
+	rpcService, err := pluginRPCService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, pluginRPCService.NewCommandRunner(), deps.Logger, cmd.ui.Writer(), server)
+	if err != nil {
+		cmd.ui.Failed("Error initializing RPC service: " + err.Error())
+	}
+
+	cmd.rpcService = rpcService
+
+	return cmd
+}
+
+func (cmd *UpdatePlugins) Execute(c flags.FlagContext) error {
+	plugins := cmd.pluginConfig.Plugins()
+
+	installed := make(map[string]plugin.VersionType)
+	if c.Bool("all") {
+		for name, metadata := range plugins {
+			installed[name] = metadata.Version
+		}
+	} else {
+		pluginName := c.Args()[0]
+		metadata, ok := plugins[pluginName]
+		if !ok {
+			return errors.New(T("Plugin name {{.PluginName}} does not exist", map[string]interface{}{"PluginName": pluginName}))
+		}
+		installed[pluginName] = metadata.Version
+	}
+
+	cmd.ui.Say(T("Checking plugin repositories for updates..."))
+
+	repos := cmd.config.PluginRepos()
+	if len(repos) == 0 {
+		return errors.New(T("No plugin repositories added\nTip: use 'add-plugin-repo' to register the repo"))
+	}
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins(repos)
+	for _, repoError := range repoErrors {
+		cmd.ui.Warn(repoError)
+	}
+
+	updates := pluginrepo.FindUpdates(installed, repoPlugins)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(updates) == 0 {
+		cmd.ui.Say(T("All plugins are up to date."))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("name"), T("installed version"), T("available version"), T("repository")})
+	for _, update := range updates {
+		table.Add(update.Name, update.InstalledVersion.String(), update.Version.String(), update.RepoName)
+	}
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+	cmd.ui.Say("")
+
+	if !c.Bool("f") && !cmd.ui.Confirm(T("Do you want to update these plugins? (y or n)")) {
+		return errors.New(T("Plugin update cancelled"))
+	}
+
+	failed := []string{}
+	for _, update := range updates {
+		err = cmd.updatePlugin(update, plugins, c.Bool("allow-unsigned"))
+		if err != nil {
+			cmd.ui.Warn(T("Could not update plugin {{.PluginName}}: {{.Error}}",
+				map[string]interface{}{"PluginName": update.Name, "Error": err.Error()}))
+			failed = append(failed, update.Name)
+			continue
+		}
+
+		cmd.ui.Say(T("Plugin {{.PluginName}} updated to v{{.Version}}.",
+			map[string]interface{}{"PluginName": terminal.EntityNameColor(update.Name), "Version": update.Version.String()}))
+	}
+
+	if len(failed) > 0 {
+		return errors.New(T("Failed to update plugins: {{.PluginNames}}",
+			map[string]interface{}{"PluginNames": strings.Join(failed, ", ")}))
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+// updatePlugin installs the new version of a plugin in place of the
+// installed one. The installed binary is kept aside until the new one has
+// answered the metadata handshake, and is put back if it does not.
+func (cmd *UpdatePlugins) updatePlugin(update pluginrepo.PluginUpdate, plugins map[string]pluginconfig.PluginMetadata, allowUnsigned bool) error {
+	cmd.ui.Say(T("Updating plugin {{.PluginName}} from v{{.InstalledVersion}} to v{{.Version}}...",
+		map[string]interface{}{
+			"PluginName":       terminal.EntityNameColor(update.Name),
+			"InstalledVersion": update.InstalledVersion.String(),
+			"Version":          update.Version.String(),
+		}))
+
+	fileDownloader := downloader.NewDownloader(os.TempDir())
+	defer func() {
+		err := fileDownloader.RemoveFile()
+		if err != nil {
+			cmd.ui.Say(T("Problem removing downloaded binary in temp directory: ") + err.Error())
+		}
+	}()
+
+	installer := plugininstaller.NewPluginInstaller(&plugininstaller.Context{
+		AllowUnsigned:     allowUnsigned,
+		Checksummer:       cmd.checksum,
+		GetPluginRepos:    cmd.config.PluginRepos,
+		FileDownloader:    fileDownloader,
+		PluginRepo:        cmd.pluginRepo,
+		RepoName:          update.RepoName,
+		SignatureVerifier: cmd.verifier,
+		UI:                cmd.ui,
+		Version:           update.Version.String(),
+	})
+	pluginSourceFilepath, err := installer.Install(update.Name)
+	if err != nil {
+		return err
+	}
+
+	current := plugins[update.Name]
+	_, pluginExecutableName := filepath.Split(pluginSourceFilepath)
+	pluginDestinationFilepath := filepath.Join(cmd.pluginConfig.GetPluginPath(), pluginExecutableName)
+
+	if pluginDestinationFilepath != current.Location {
+		if _, err = os.Stat(pluginDestinationFilepath); err == nil {
+			return errors.New(T("The file {{.PluginExecutableName}} already exists under the plugin directory.\n",
+				map[string]interface{}{"PluginExecutableName": pluginExecutableName}))
+		}
+	}
+
+	backupFilepath := current.Location + ".backup"
+	err = os.Rename(current.Location, backupFilepath)
+	if err != nil {
+		return err
+	}
+
+	restore := func() {
+		_ = os.Remove(pluginDestinationFilepath)
+		_ = os.Rename(backupFilepath, current.Location)
+	}
+
+	err = fileutils.CopyPathToPath(pluginSourceFilepath, pluginDestinationFilepath)
+	if err != nil {
+		restore()
+		return err
+	}
+
+	pluginMetadata, err := runBinaryAndObtainPluginMetadata(cmd.rpcService, pluginDestinationFilepath)
+	if err == nil {
+		err = ensureUpdatedPluginIsSafe(update, pluginMetadata, pluginDestinationFilepath, plugins)
+	}
+	if err != nil {
+		restore()
+		return errors.New(T("{{.Error}}\nv{{.InstalledVersion}} was restored",
+			map[string]interface{}{"Error": err.Error(), "InstalledVersion": update.InstalledVersion.String()}))
+	}
+
+	_ = os.Remove(backupFilepath)
+
+	cmd.pluginConfig.SetPlugin(update.Name, pluginconfig.PluginMetadata{
+		Location: pluginDestinationFilepath,
+		Version:  pluginMetadata.Version,
+		Commands: pluginMetadata.Commands,
+	})
+	return nil
+}
+
+// ensureUpdatedPluginIsSafe checks the metadata of the new version of an
+// installed plugin, which must keep the plugin's name, must be the version
+// that was selected for the update and must not take the commands of other
+// plugins.
+func ensureUpdatedPluginIsSafe(update pluginrepo.PluginUpdate, pluginMetadata *plugin.PluginMetadata, pluginFilepath string, plugins map[string]pluginconfig.PluginMetadata) error {
+	pluginName := update.Name
+	if pluginMetadata.Name != pluginName {
+		return errors.New(T("The new version of the plugin reports the name '{{.Name}}' instead of '{{.PluginName}}'",
+			map[string]interface{}{"Name": pluginMetadata.Name, "PluginName": pluginName}))
+	}
+
+	// Plugins report only major, minor and build numbers, so pre-release
+	// and build metadata of the repo version are not compared.
+	expectedVersion := semver.Version{Major: update.Version.Major, Minor: update.Version.Minor, Patch: update.Version.Patch}
+	reportedVersion := pluginrepo.InstalledVersion(pluginMetadata.Version)
+	if !reportedVersion.Equals(expectedVersion) {
+		return errors.New(T("The new version of the plugin reports v{{.Version}} instead of v{{.ExpectedVersion}}",
+			map[string]interface{}{"Version": reportedVersion.String(), "ExpectedVersion": expectedVersion.String()}))
+	}
+
+	otherPlugins := make(map[string]pluginconfig.PluginMetadata)
+	for name, metadata := range plugins {
+		if name != pluginName {
+			otherPlugins[name] = metadata
+		}
+	}
+
+	return ensurePluginCommandsDoNotConflict(pluginMetadata, pluginFilepath, otherPlugins)
+}
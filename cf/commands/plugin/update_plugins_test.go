@@ -0,0 +1,274 @@
+package plugin_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo/pluginrepofakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig/pluginconfigfakes"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/plugin"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/utilsfakes"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("update-plugins", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		config              coreconfig.Repository
+		pluginConfig        *pluginconfigfakes.FakePluginConfiguration
+		fakePluginRepo      *pluginrepofakes.FakePluginRepo
+		fakeChecksum        *utilsfakes.FakeSha1Checksum
+		fakeVerifier        *utilsfakes.FakeSignatureVerifier
+		deps                commandregistry.Dependency
+
+		fixturesDir     string
+		homeDir         string
+		pluginDir       string
+		installedPath   string
+		servedFixture   string
+		testServer      *httptest.Server
+		requestedPaths  []string
+		installedPlugin pluginconfig.PluginMetadata
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.PluginConfig = pluginConfig
+		deps.PluginRepo = fakePluginRepo
+		deps.ChecksumUtil = fakeChecksum
+		deps.SignatureVerifier = fakeVerifier
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("update-plugins").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		// the plugin fixtures are not signed
+		return testcmd.RunCLICommand("update-plugins", append(args, "--allow-unsigned"), requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		config = testconfig.NewRepositoryWithDefaults()
+		pluginConfig = new(pluginconfigfakes.FakePluginConfiguration)
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
+		fakeChecksum = new(utilsfakes.FakeSha1Checksum)
+		fakeChecksum.CheckSha1Returns(true)
+		fakeVerifier = new(utilsfakes.FakeSignatureVerifier)
+
+		dir, err := os.Getwd()
+		Expect(err).ToNot(HaveOccurred())
+		fixturesDir = filepath.Join(dir, "..", "..", "..", "fixtures", "plugins")
+
+		homeDir, err = ioutil.TempDir("", "update-plugins")
+		Expect(err).ToNot(HaveOccurred())
+		pluginDir = filepath.Join(homeDir, ".cf", "plugins")
+		Expect(os.MkdirAll(pluginDir, 0700)).To(Succeed())
+		pluginConfig.GetPluginPathReturns(pluginDir)
+
+		installedPath = filepath.Join(pluginDir, "test_1.exe")
+		Expect(ioutil.WriteFile(installedPath, []byte("installed binary"), 0700)).To(Succeed())
+
+		installedPlugin = pluginconfig.PluginMetadata{
+			Location: installedPath,
+			Version:  plugin.VersionType{Major: 1, Minor: 0, Build: 0},
+			Commands: []plugin.Command{{Name: "test_1_cmd1"}},
+		}
+		pluginConfig.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+			"Test1": installedPlugin,
+			"Uninstall-Test": {
+				Location: filepath.Join(pluginDir, "test_2.exe"),
+				Version:  plugin.VersionType{Major: 2},
+				Commands: []plugin.Command{{Name: "test_2_cmd1"}},
+			},
+		})
+
+		servedFixture = "test_1.exe"
+		requestedPaths = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestedPaths = append(requestedPaths, r.URL.Path)
+			http.ServeFile(w, r, filepath.Join(fixturesDir, servedFixture))
+		}))
+
+		binaries := []pluginrepo.Binary{}
+		for _, platform := range []string{"osx", "win32", "win64", "linux32", "linux64"} {
+			binaries = append(binaries, pluginrepo.Binary{Platform: platform, Url: testServer.URL + "/test_1.exe"})
+		}
+		config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: "https://repo1.example.com"})
+		fakePluginRepo.GetPluginsReturns(map[string][]pluginrepo.Plugin{
+			"repo1": {
+				{Name: "Test1", Version: "1.2.4", Binaries: binaries},
+				{Name: "Uninstall-Test", Version: "1.0.0"},
+			},
+		}, nil)
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+		os.RemoveAll(homeDir)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when neither a plugin name nor --all is provided", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires a plugin name or --all"}))
+		})
+
+		It("fails with usage when both a plugin name and --all are provided", func() {
+			Expect(runCommand("Test1", "--all")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires a plugin name or --all"}))
+		})
+	})
+
+	It("fails when the plugin is not installed", func() {
+		Expect(runCommand("not-a-plugin", "-f")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Plugin name not-a-plugin does not exist"}))
+		Expect(fakePluginRepo.GetPluginsCallCount()).To(Equal(0))
+	})
+
+	It("fails when no plugin repositories are added", func() {
+		config.UnSetPluginRepo(0)
+
+		Expect(runCommand("--all", "-f")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"No plugin repositories added"}))
+	})
+
+	It("says so when all plugins are up to date", func() {
+		fakePluginRepo.GetPluginsReturns(map[string][]pluginrepo.Plugin{
+			"repo1": {{Name: "Test1", Version: "1.0.0"}},
+		}, []string{"repo2 is unreachable"})
+
+		Expect(runCommand("--all", "-f")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"repo2 is unreachable"},
+			[]string{"All plugins are up to date."},
+		))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+	})
+
+	It("lists the available updates and stops unless confirmed", func() {
+		ui.Inputs = []string{"n"}
+
+		Expect(runCommand("--all")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"name", "installed version", "available version", "repository"},
+			[]string{"Test1", "1.0.0", "1.2.4", "repo1"},
+			[]string{"Plugin update cancelled"},
+		))
+		Expect(ui.Outputs()).ToNot(ContainSubstrings([]string{"Uninstall-Test"}))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+	})
+
+	It("replaces the binary and records the new version of the plugin", func() {
+		Expect(runCommand("Test1", "-f")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Updating plugin Test1 from v1.0.0 to v1.2.4"},
+			[]string{"Plugin Test1 updated to v1.2.4."},
+			[]string{"OK"},
+		))
+
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(1))
+		name, metadata := pluginConfig.SetPluginArgsForCall(0)
+		Expect(name).To(Equal("Test1"))
+		Expect(metadata.Location).To(Equal(installedPath))
+		Expect(metadata.Version).To(Equal(plugin.VersionType{Major: 1, Minor: 2, Build: 4}))
+		Expect(metadata.Commands).ToNot(BeEmpty())
+
+		contents, err := ioutil.ReadFile(installedPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).ToNot(Equal("installed binary"))
+		Expect(installedPath + ".backup").ToNot(BeAnExistingFile())
+	})
+
+	It("installs the selected version when the repository carries several versions of the plugin", func() {
+		binariesAt := func(path string) []pluginrepo.Binary {
+			binaries := []pluginrepo.Binary{}
+			for _, platform := range []string{"osx", "win32", "win64", "linux32", "linux64"} {
+				binaries = append(binaries, pluginrepo.Binary{Platform: platform, Url: testServer.URL + path})
+			}
+			return binaries
+		}
+		fakePluginRepo.GetPluginsReturns(map[string][]pluginrepo.Plugin{
+			"repo1": {
+				{Name: "Test1", Version: "1.2.4", Binaries: binariesAt("/v1.2.4/test_1.exe")},
+				{Name: "Test1", Version: "1.1.0", Binaries: binariesAt("/v1.1.0/test_1.exe")},
+			},
+		}, nil)
+
+		Expect(runCommand("Test1", "-f")).To(BeTrue())
+		Expect(requestedPaths).To(Equal([]string{"/v1.2.4/test_1.exe"}))
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Plugin Test1 updated to v1.2.4."}))
+	})
+
+	It("does not replace the installed binary when the checksum of the download does not match", func() {
+		fakeChecksum.CheckSha1Returns(false)
+
+		Expect(runCommand("Test1", "-f")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Could not update plugin Test1", "checksum does not match"},
+			[]string{"Failed to update plugins: Test1"},
+		))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+
+		contents, err := ioutil.ReadFile(installedPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("installed binary"))
+		Expect(installedPath + ".backup").ToNot(BeAnExistingFile())
+	})
+
+	It("restores the installed binary when the new one reports another version than the repository", func() {
+		fakePluginRepo.GetPluginsReturns(map[string][]pluginrepo.Plugin{
+			"repo1": {{Name: "Test1", Version: "1.3.0", Binaries: []pluginrepo.Binary{
+				{Platform: "osx", Url: testServer.URL + "/test_1.exe"},
+				{Platform: "win32", Url: testServer.URL + "/test_1.exe"},
+				{Platform: "win64", Url: testServer.URL + "/test_1.exe"},
+				{Platform: "linux32", Url: testServer.URL + "/test_1.exe"},
+				{Platform: "linux64", Url: testServer.URL + "/test_1.exe"},
+			}}},
+		}, nil)
+
+		Expect(runCommand("Test1", "-f")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Could not update plugin Test1", "reports v1.2.4 instead of v1.3.0"},
+			[]string{"v1.0.0 was restored"},
+		))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+
+		contents, err := ioutil.ReadFile(installedPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("installed binary"))
+	})
+
+	It("restores the installed binary when the new one fails the metadata check", func() {
+		servedFixture = "test_2.exe"
+
+		Expect(runCommand("Test1", "-f")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Could not update plugin Test1", "instead of 'Test1'"},
+			[]string{"v1.0.0 was restored"},
+			[]string{"Failed to update plugins: Test1"},
+		))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+
+		contents, err := ioutil.ReadFile(installedPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("installed binary"))
+		Expect(installedPath + ".backup").ToNot(BeAnExistingFile())
+	})
+})
@@ -12,8 +12,6 @@ import (
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
 
-	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
-
 	. "code.cloudfoundry.org/cli/cf/i18n"
 )
 
@@ -93,7 +91,7 @@ func (cmd *RepoPlugins) Execute(c flags.FlagContext) error {
 	return nil
 }
 
-func (cmd RepoPlugins) printTable(repoPlugins map[string][]clipr.Plugin) error {
+func (cmd RepoPlugins) printTable(repoPlugins map[string][]pluginrepo.Plugin) error {
 	for k, plugins := range repoPlugins {
 		cmd.ui.Say(terminal.ColorizeBold(T("Repository: ")+k, 33))
 		table := cmd.ui.Table([]string{T("name"), T("version"), T("description")})
@@ -1,6 +1,7 @@
 package pluginrepo_test
 
 import (
+	pluginrepoactor "code.cloudfoundry.org/cli/cf/actors/pluginrepo"
 	"code.cloudfoundry.org/cli/cf/actors/pluginrepo/pluginrepofakes"
 	"code.cloudfoundry.org/cli/cf/commands/pluginrepo"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -13,8 +14,6 @@ import (
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
 
-	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
-
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -117,14 +116,14 @@ var _ = Describe("repo-plugins", func() {
 
 		Context("when GetPlugins returns a list of plugin meta data", func() {
 			It("lists all plugin data", func() {
-				result := make(map[string][]clipr.Plugin)
-				result["repo1"] = []clipr.Plugin{
+				result := make(map[string][]pluginrepoactor.Plugin)
+				result["repo1"] = []pluginrepoactor.Plugin{
 					{
 						Name:        "plugin1",
 						Description: "none1",
 					},
 				}
-				result["repo2"] = []clipr.Plugin{
+				result["repo2"] = []pluginrepoactor.Plugin{
 					{
 						Name:        "plugin2",
 						Description: "none2",
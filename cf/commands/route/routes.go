@@ -86,6 +86,7 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 	}
 
 	table := cmd.ui.Table([]string{T("space"), T("host"), T("domain"), T("port"), T("path"), T("type"), T("apps"), T("service")})
+	table.SetFieldNames("space", "host", "domain", "port", "path", "type", "apps", "service")
 
 	d := make(map[string]models.DomainFields)
 	err := cmd.domainRepo.ListDomainsForOrg(cmd.config.OrganizationFields().GUID, func(domain models.DomainFields) bool {
@@ -1,7 +1,13 @@
 package securitygroup
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -10,9 +16,10 @@ import (
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
-	"code.cloudfoundry.org/cli/utils/json"
+	"gopkg.in/yaml.v2"
 )
 
 type CreateSecurityGroup struct {
@@ -38,7 +45,10 @@ func (cmd *CreateSecurityGroup) MetaData() commandregistry.CommandMetadata {
        "destination": "10.244.1.18",
        "ports": "3306"
      }
-   ]`)
+   ]
+
+   The rules can also be given as a YAML array in a file with a .yml or .yaml extension.
+   The rules are checked before they are uploaded.`)
 
 	return commandregistry.CommandMetadata{
 		Name:        "create-security-group",
@@ -74,18 +84,9 @@ func (cmd *CreateSecurityGroup) SetDependency(deps commandregistry.Dependency, p
 func (cmd *CreateSecurityGroup) Execute(context flags.FlagContext) error {
 	name := context.Args()[0]
 	pathToJSONFile := context.Args()[1]
-	rules, err := json.ParseJSONArray(pathToJSONFile)
+	rules, err := ReadRulesFile(pathToJSONFile)
 	if err != nil {
-		return errors.New(T(`Incorrect json format: file: {{.JSONFile}}
-		
-Valid json file example:
-[
-  {
-    "protocol": "tcp",
-    "destination": "10.244.1.18",
-    "ports": "3306"
-  }
-]`, map[string]interface{}{"JSONFile": pathToJSONFile}))
+		return err
 	}
 
 	cmd.ui.Say(T("Creating security group {{.security_group}} as {{.username}}",
@@ -114,3 +115,143 @@ Valid json file example:
 	cmd.ui.Ok()
 	return nil
 }
+
+// ReadRulesFile reads the security group rules in the file at path, which
+// holds an array of rules in JSON or, when its extension is .yml or .yaml,
+// in YAML. Every rule is validated, and the error lists the problems of
+// each invalid rule.
+func ReadRulesFile(path string) ([]models.SecurityGroupRule, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleMaps := []map[string]interface{}{}
+	if isYAMLFile(path) {
+		err = yaml.Unmarshal(contents, &ruleMaps)
+	} else {
+		err = json.Unmarshal(contents, &ruleMaps)
+	}
+	if err != nil {
+		return nil, errors.New(rulesFileFormatError(path, err))
+	}
+
+	rules := []models.SecurityGroupRule{}
+	problems := []string{}
+	for i, ruleMap := range ruleMaps {
+		// a rule is only validated once its fields are known and of the
+		// right type, so that a misspelt field is not also reported missing
+		rule, ruleProblems := ruleFromMap(ruleMap)
+		if len(ruleProblems) == 0 {
+			ruleProblems = rule.Validate()
+		}
+		for _, problem := range ruleProblems {
+			problems = append(problems, T("rule {{.Index}}: {{.Problem}}",
+				map[string]interface{}{"Index": i + 1, "Problem": problem}))
+		}
+		rules = append(rules, rule)
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New(T("Invalid security group rules in file: {{.File}}",
+			map[string]interface{}{"File": path}) + "\n   " + strings.Join(problems, "\n   "))
+	}
+
+	return rules, nil
+}
+
+func isYAMLFile(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+	return extension == ".yml" || extension == ".yaml"
+}
+
+func rulesFileFormatError(path string, err error) string {
+	if isYAMLFile(path) {
+		return T(`Incorrect yaml format: file: {{.File}}
+{{.Error}}
+
+Valid yaml file example:
+- protocol: tcp
+  destination: 10.244.1.18
+  ports: "3306"`, map[string]interface{}{"File": path, "Error": err.Error()})
+	}
+
+	return T(`Incorrect json format: file: {{.File}}
+{{.Error}}
+
+Valid json file example:
+[
+  {
+    "protocol": "tcp",
+    "destination": "10.244.1.18",
+    "ports": "3306"
+  }
+]`, map[string]interface{}{"File": path, "Error": err.Error()})
+}
+
+// ruleFromMap builds a rule from the fields of a decoded file, reporting
+// fields that are unknown or of the wrong type rather than ignoring them.
+func ruleFromMap(fields map[string]interface{}) (models.SecurityGroupRule, []string) {
+	rule := models.SecurityGroupRule{}
+	problems := []string{}
+
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := fields[name]
+		ok := true
+
+		switch name {
+		case "protocol":
+			rule.Protocol, ok = value.(string)
+		case "destination":
+			rule.Destination, ok = value.(string)
+		case "description":
+			rule.Description, ok = value.(string)
+		case "log":
+			rule.Log, ok = value.(bool)
+		case "ports":
+			// YAML files may give a single port as a number
+			if port, isInt := wholeNumber(value); isInt {
+				rule.Ports = strconv.Itoa(port)
+			} else {
+				rule.Ports, ok = value.(string)
+			}
+		case "type", "code":
+			var number int
+			number, ok = wholeNumber(value)
+			if name == "type" {
+				rule.Type = &number
+			} else {
+				rule.Code = &number
+			}
+		default:
+			problems = append(problems, T("unknown field {{.Field}}",
+				map[string]interface{}{"Field": strconv.Quote(name)}))
+			continue
+		}
+
+		if !ok {
+			problems = append(problems, T("{{.Field}} has a value of the wrong type",
+				map[string]interface{}{"Field": name}))
+		}
+	}
+
+	return rule, problems
+}
+
+func wholeNumber(value interface{}) (int, bool) {
+	switch number := value.(type) {
+	case int:
+		return number, true
+	case float64:
+		if number == float64(int(number)) {
+			return int(number), true
+		}
+	}
+	return 0, false
+}
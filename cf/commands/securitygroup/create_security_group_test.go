@@ -3,11 +3,13 @@ package securitygroup_test
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"code.cloudfoundry.org/cli/cf/api/securitygroups/securitygroupsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
@@ -100,8 +102,8 @@ var _ = Describe("create-security-group command", func() {
 
 			It("creates the security group with those rules", func() {
 				_, rules := securityGroupRepo.CreateArgsForCall(0)
-				Expect(rules).To(Equal([]map[string]interface{}{
-					{"protocol": "udp", "ports": "8080-9090", "destination": "198.41.191.47/1"},
+				Expect(rules).To(Equal([]models.SecurityGroupRule{
+					{Protocol: "udp", Ports: "8080-9090", Destination: "198.41.191.47/1"},
 				}))
 			})
 
@@ -145,5 +147,66 @@ var _ = Describe("create-security-group command", func() {
 				))
 			})
 		})
+
+		Context("when a rule in the file is invalid", func() {
+			BeforeEach(func() {
+				tempFile.Write([]byte(`[
+					{"protocol":"tcp","ports":"443","destination":"10.0.0.0/8"},
+					{"protocol":"tcp","ports":"9090-8080","destination":"10.0.0.0/33"},
+					{"protocol":"tcp","ports":"443","destination":["10.0.0.1"]}
+				]`))
+			})
+
+			It("does not create the security group", func() {
+				Expect(securityGroupRepo.CreateCallCount()).To(Equal(0))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid security group rules in file:", tempFile.Name()},
+					[]string{"rule 2:", "10.0.0.0/33", "is not an IPv4 address, CIDR block or address range"},
+					[]string{"rule 2:", "9090-8080", "is a reversed range"},
+					[]string{"rule 3: destination has a value of the wrong type"},
+				))
+				Expect(ui.Outputs()).ToNot(ContainSubstrings([]string{"rule 1"}))
+			})
+		})
+	})
+
+	Context("when the rules are in a YAML file", func() {
+		var yamlFile string
+
+		BeforeEach(func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+
+			dir, err := ioutil.TempDir("", "create-security-group")
+			Expect(err).NotTo(HaveOccurred())
+			yamlFile = filepath.Join(dir, "rules.yml")
+			err = ioutil.WriteFile(yamlFile, []byte(`---
+- protocol: tcp
+  destination: 10.0.11.0/24
+  ports: 443
+  log: true
+- protocol: icmp
+  destination: 10.0.11.0-10.0.11.255
+  type: 0
+  code: -1
+`), 0600)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(filepath.Dir(yamlFile))
+		})
+
+		It("creates the security group with those rules", func() {
+			runCommand("my-group", yamlFile)
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"OK"}))
+			icmpType, icmpCode := 0, -1
+			_, rules := securityGroupRepo.CreateArgsForCall(0)
+			Expect(rules).To(Equal([]models.SecurityGroupRule{
+				{Protocol: "tcp", Destination: "10.0.11.0/24", Ports: "443", Log: true},
+				{Protocol: "icmp", Destination: "10.0.11.0-10.0.11.255", Type: &icmpType, Code: &icmpCode},
+			}))
+		})
 	})
 })
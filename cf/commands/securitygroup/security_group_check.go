@@ -0,0 +1,264 @@
+package securitygroup
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/api/securitygroups/defaults/running"
+	"code.cloudfoundry.org/cli/cf/api/securitygroups/defaults/staging"
+	"code.cloudfoundry.org/cli/cf/api/spaces"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type SecurityGroupCheck struct {
+	ui                       terminal.UI
+	configRepo               coreconfig.Reader
+	appRepo                  applications.Repository
+	spaceRepo                spaces.SpaceRepository
+	runningSecurityGroupRepo running.SecurityGroupsRepo
+	stagingSecurityGroupRepo staging.SecurityGroupsRepo
+}
+
+// securityGroupLifecycle is the set of security groups applied while an
+// app is either staging or running.
+type securityGroupLifecycle struct {
+	name   string
+	groups []models.SecurityGroupFields
+}
+
+func init() {
+	commandregistry.Register(&SecurityGroupCheck{})
+}
+
+func (cmd *SecurityGroupCheck) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["destination"] = &flags.StringFlag{Name: "destination", Usage: T("Host or IPv4 address and port the traffic is sent to, such as db.example.com:5432")}
+	fs["protocol"] = &flags.StringFlag{Name: "protocol", Usage: T("Protocol of the traffic, tcp or udp (Default: tcp)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "security-group-check",
+		Description: T("Check whether the security groups of an app or space allow traffic to a destination"),
+		Usage: []string{
+			T("CF_NAME security-group-check APP_OR_SPACE --destination HOST:PORT [--protocol PROTOCOL]"),
+			"\n\n",
+			T("TIP:\n   The argument is looked up as an app in the targeted space, then as a space in the targeted org.\n   The running and staging default security groups are checked along with the groups bound to the space, and every rule that allows the traffic is listed."),
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *SecurityGroupCheck) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("security-group-check"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	if fc.String("destination") == "" {
+		cmd.ui.Failed(T("Incorrect Usage. Requires --destination\n\n") + commandregistry.Commands.CommandUsage("security-group-check"))
+		return nil, fmt.Errorf("Incorrect usage: --destination is required")
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedOrgRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *SecurityGroupCheck) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.configRepo = deps.Config
+	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
+	cmd.runningSecurityGroupRepo = deps.RepoLocator.GetRunningSecurityGroupsRepository()
+	cmd.stagingSecurityGroupRepo = deps.RepoLocator.GetStagingSecurityGroupsRepository()
+	return cmd
+}
+
+func (cmd *SecurityGroupCheck) Execute(c flags.FlagContext) error {
+	name := c.Args()[0]
+
+	protocol := c.String("protocol")
+	if protocol == "" {
+		protocol = models.SecurityGroupProtocolTCP
+	}
+	if protocol != models.SecurityGroupProtocolTCP && protocol != models.SecurityGroupProtocolUDP {
+		return errors.New(T("Option '--protocol' must be tcp or udp"))
+	}
+
+	host, port, err := parseDestination(c.String("destination"))
+	if err != nil {
+		return err
+	}
+
+	space, appName, err := cmd.findSpace(name)
+	if err != nil {
+		return err
+	}
+
+	if appName != "" {
+		cmd.ui.Say(T("Checking {{.Protocol}} traffic from app {{.AppName}} to {{.Destination}} as {{.Username}}...",
+			map[string]interface{}{
+				"Protocol":    protocol,
+				"AppName":     terminal.EntityNameColor(appName),
+				"Destination": terminal.EntityNameColor(c.String("destination")),
+				"Username":    terminal.EntityNameColor(cmd.configRepo.Username()),
+			}))
+	} else {
+		cmd.ui.Say(T("Checking {{.Protocol}} traffic from space {{.SpaceName}} to {{.Destination}} as {{.Username}}...",
+			map[string]interface{}{
+				"Protocol":    protocol,
+				"SpaceName":   terminal.EntityNameColor(space.Name),
+				"Destination": terminal.EntityNameColor(c.String("destination")),
+				"Username":    terminal.EntityNameColor(cmd.configRepo.Username()),
+			}))
+	}
+
+	ips, err := resolveIPv4(host)
+	if err != nil {
+		return err
+	}
+
+	runningGroups, err := cmd.runningSecurityGroupRepo.List()
+	if err != nil {
+		return err
+	}
+	stagingGroups, err := cmd.stagingSecurityGroupRepo.List()
+	if err != nil {
+		return err
+	}
+
+	lifecycles := []securityGroupLifecycle{
+		{name: T("running"), groups: uniqueSecurityGroups(runningGroups, space.SecurityGroups)},
+		{name: T("staging"), groups: uniqueSecurityGroups(stagingGroups, space.SecurityGroups)},
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	table := cmd.ui.Table([]string{T("lifecycle"), T("destination"), T("result"), T("security group"), T("rule")})
+	for _, lifecycle := range lifecycles {
+		for _, ip := range ips {
+			destination := net.JoinHostPort(ip.String(), strconv.Itoa(port))
+
+			allowed := false
+			for _, group := range lifecycle.groups {
+				for i, rule := range group.Rules {
+					if !rule.Allows(protocol, ip, port) {
+						continue
+					}
+					allowed = true
+					table.Add(lifecycle.name, destination, terminal.SuccessColor(T("allowed")), group.Name,
+						T("rule {{.Index}}: {{.Rule}}", map[string]interface{}{"Index": i + 1, "Rule": rule.String()}))
+				}
+			}
+
+			if !allowed {
+				table.Add(lifecycle.name, destination, terminal.FailureColor(T("denied")), "", T("no rule allows this traffic"))
+			}
+		}
+	}
+
+	return table.Print()
+}
+
+// findSpace returns the space whose security groups apply to name, which
+// is an app in the targeted space or a space in the targeted org. The app
+// name is returned when name is an app.
+func (cmd *SecurityGroupCheck) findSpace(name string) (models.Space, string, error) {
+	if cmd.configRepo.HasSpace() {
+		app, err := cmd.appRepo.Read(name)
+		switch err.(type) {
+		case nil:
+			space, err := cmd.spaceRepo.FindByName(cmd.configRepo.SpaceFields().Name)
+			return space, app.Name, err
+		case *errors.ModelNotFoundError:
+		default:
+			return models.Space{}, "", err
+		}
+	}
+
+	space, err := cmd.spaceRepo.FindByName(name)
+	switch err.(type) {
+	case nil:
+		return space, "", nil
+	case *errors.ModelNotFoundError:
+		return models.Space{}, "", errors.NewModelNotFoundError("App or space", name)
+	default:
+		return models.Space{}, "", err
+	}
+}
+
+// parseDestination splits a destination such as db.example.com:5432 into
+// its host and port.
+func parseDestination(destination string) (string, int, error) {
+	invalid := errors.New(T("Invalid destination {{.Destination}}: it must be HOST:PORT with a port between 1 and 65535",
+		map[string]interface{}{"Destination": destination}))
+
+	host, portString, err := net.SplitHostPort(destination)
+	if err != nil || host == "" {
+		return "", 0, invalid
+	}
+
+	port, err := strconv.Atoi(portString)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, invalid
+	}
+
+	return host, port, nil
+}
+
+// resolveIPv4 returns host when it is an IPv4 address, or else the IPv4
+// addresses it resolves to, as security group rules only cover IPv4.
+func resolveIPv4(host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.To4() == nil {
+			return nil, errors.New(T("Security groups only apply to IPv4 destinations"))
+		}
+		return []net.IP{ip.To4()}, nil
+	}
+
+	addresses, err := net.LookupIP(host)
+	if err != nil {
+		return nil, errors.New(T("Could not resolve {{.Host}}: {{.Error}}",
+			map[string]interface{}{"Host": host, "Error": err.Error()}))
+	}
+
+	ips := []net.IP{}
+	for _, address := range addresses {
+		if address.To4() != nil {
+			ips = append(ips, address.To4())
+		}
+	}
+	if len(ips) == 0 {
+		return nil, errors.New(T("{{.Host}} has no IPv4 address", map[string]interface{}{"Host": host}))
+	}
+
+	return ips, nil
+}
+
+// uniqueSecurityGroups returns the default groups followed by the space
+// groups, leaving out any group that is both a default and bound to the
+// space.
+func uniqueSecurityGroups(defaultGroups, spaceGroups []models.SecurityGroupFields) []models.SecurityGroupFields {
+	groups := []models.SecurityGroupFields{}
+	seen := map[string]bool{}
+	for _, group := range append(append([]models.SecurityGroupFields{}, defaultGroups...), spaceGroups...) {
+		if seen[group.GUID] {
+			continue
+		}
+		seen[group.GUID] = true
+		groups = append(groups, group)
+	}
+	return groups
+}
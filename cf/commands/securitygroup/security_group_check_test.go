@@ -0,0 +1,204 @@
+package securitygroup_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/securitygroups/defaults/running/runningfakes"
+	"code.cloudfoundry.org/cli/cf/api/securitygroups/defaults/staging/stagingfakes"
+	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("security-group-check command", func() {
+	var (
+		ui                           *testterm.FakeUI
+		configRepo                   coreconfig.Repository
+		requirementsFactory          *requirementsfakes.FakeFactory
+		appRepo                      *applicationsfakes.FakeRepository
+		spaceRepo                    *spacesfakes.FakeSpaceRepository
+		fakeRunningSecurityGroupRepo *runningfakes.FakeSecurityGroupsRepo
+		fakeStagingSecurityGroupRepo *stagingfakes.FakeSecurityGroupsRepo
+		deps                         commandregistry.Dependency
+
+		dbGroup models.SecurityGroupFields
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+		deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+		deps.RepoLocator = deps.RepoLocator.SetSpaceRepository(spaceRepo)
+		deps.RepoLocator = deps.RepoLocator.SetRunningSecurityGroupRepository(fakeRunningSecurityGroupRepo)
+		deps.RepoLocator = deps.RepoLocator.SetStagingSecurityGroupRepository(fakeStagingSecurityGroupRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("security-group-check").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedOrgRequirementReturns(new(requirementsfakes.FakeTargetedOrgRequirement))
+		appRepo = new(applicationsfakes.FakeRepository)
+		spaceRepo = new(spacesfakes.FakeSpaceRepository)
+		fakeRunningSecurityGroupRepo = new(runningfakes.FakeSecurityGroupsRepo)
+		fakeStagingSecurityGroupRepo = new(stagingfakes.FakeSecurityGroupsRepo)
+
+		dbGroup = models.SecurityGroupFields{
+			Name: "db-access",
+			GUID: "db-access-guid",
+			Rules: []models.SecurityGroupRule{
+				{Protocol: "udp", Destination: "10.0.0.0/24", Ports: "53"},
+				{Protocol: "tcp", Destination: "10.0.0.0/24", Ports: "5432"},
+			},
+		}
+
+		appRepo.ReadReturns(models.Application{ApplicationFields: models.ApplicationFields{Name: "my-app"}}, nil)
+		spaceRepo.FindByNameReturns(models.Space{
+			SpaceFields:    models.SpaceFields{Name: "my-space", GUID: "my-space-guid"},
+			SecurityGroups: []models.SecurityGroupFields{dbGroup},
+		}, nil)
+		fakeRunningSecurityGroupRepo.ListReturns([]models.SecurityGroupFields{
+			{
+				Name:  "public-networks",
+				GUID:  "public-networks-guid",
+				Rules: []models.SecurityGroupRule{{Protocol: "all", Destination: "0.0.0.0-9.255.255.255"}},
+			},
+			dbGroup,
+		}, nil)
+		fakeStagingSecurityGroupRepo.ListReturns([]models.SecurityGroupFields{}, nil)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("security-group-check", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-app", "--destination", "10.0.0.5:5432")).To(BeFalse())
+		})
+
+		It("fails with usage when no argument is given", func() {
+			runCommand("--destination", "10.0.0.5:5432")
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires an argument"}))
+		})
+
+		It("fails with usage when no destination is given", func() {
+			runCommand("my-app")
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires --destination"}))
+		})
+	})
+
+	Context("when the traffic is allowed by a space group", func() {
+		It("lists the rule that allows it, once per lifecycle", func() {
+			Expect(runCommand("my-app", "--destination", "10.0.0.5:5432")).To(BeTrue())
+
+			Expect(appRepo.ReadArgsForCall(0)).To(Equal("my-app"))
+			Expect(spaceRepo.FindByNameArgsForCall(0)).To(Equal("my-space"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Checking tcp traffic from app", "my-app", "10.0.0.5:5432", "my-user"},
+				[]string{"OK"},
+				[]string{"lifecycle", "destination", "result", "security group", "rule"},
+				[]string{"running", "10.0.0.5:5432", "allowed", "db-access", "rule 2: tcp 10.0.0.0/24 ports 5432"},
+				[]string{"staging", "10.0.0.5:5432", "allowed", "db-access", "rule 2: tcp 10.0.0.0/24 ports 5432"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"public-networks"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"rule 1:"}))
+		})
+	})
+
+	Context("when the traffic is only allowed by a running default group", func() {
+		It("reports it denied while staging", func() {
+			Expect(runCommand("my-app", "--destination", "8.8.8.8:53", "--protocol", "udp")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Checking udp traffic"},
+				[]string{"running", "8.8.8.8:53", "allowed", "public-networks", "rule 1: all 0.0.0.0-9.255.255.255"},
+				[]string{"staging", "8.8.8.8:53", "denied", "no rule allows this traffic"},
+			))
+		})
+	})
+
+	Context("when the argument is not an app", func() {
+		BeforeEach(func() {
+			appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "my-space"))
+		})
+
+		It("checks the space with that name", func() {
+			Expect(runCommand("my-space", "--destination", "10.0.0.5:5432")).To(BeTrue())
+
+			Expect(spaceRepo.FindByNameArgsForCall(0)).To(Equal("my-space"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Checking tcp traffic from space", "my-space"},
+				[]string{"running", "allowed", "db-access"},
+			))
+		})
+
+		It("fails when there is no such space either", func() {
+			spaceRepo.FindByNameReturns(models.Space{}, errors.NewModelNotFoundError("Space", "nope"))
+
+			Expect(runCommand("nope", "--destination", "10.0.0.5:5432")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"App or space", "nope", "not found"}))
+		})
+	})
+
+	Context("when no space is targeted", func() {
+		BeforeEach(func() {
+			configRepo.SetSpaceFields(models.SpaceFields{})
+		})
+
+		It("only looks for a space", func() {
+			Expect(runCommand("my-space", "--destination", "10.0.0.5:5432")).To(BeTrue())
+			Expect(appRepo.ReadCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when reading the app fails", func() {
+		It("fails with the error", func() {
+			appRepo.ReadReturns(models.Application{}, errors.New("app read failed"))
+
+			Expect(runCommand("my-app", "--destination", "10.0.0.5:5432")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"app read failed"}))
+			Expect(spaceRepo.FindByNameCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when listing the default groups fails", func() {
+		It("fails with the error", func() {
+			fakeStagingSecurityGroupRepo.ListReturns(nil, errors.New("list failed"))
+
+			Expect(runCommand("my-app", "--destination", "10.0.0.5:5432")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"list failed"}))
+		})
+	})
+
+	Context("when an option is invalid", func() {
+		It("fails for an unknown protocol", func() {
+			Expect(runCommand("my-app", "--destination", "10.0.0.5:5432", "--protocol", "icmp")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"must be tcp or udp"}))
+			Expect(appRepo.ReadCallCount()).To(Equal(0))
+		})
+
+		It("fails for a destination without a port", func() {
+			Expect(runCommand("my-app", "--destination", "10.0.0.5")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Invalid destination 10.0.0.5"}))
+		})
+
+		It("fails for a port out of range", func() {
+			Expect(runCommand("my-app", "--destination", "10.0.0.5:70000")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Invalid destination 10.0.0.5:70000"}))
+		})
+	})
+})
@@ -66,12 +66,12 @@ var _ = Describe("security-group command", func() {
 
 		Context("when the group with the given name exists", func() {
 			BeforeEach(func() {
-				rulesMap := []map[string]interface{}{{"just-pretend": "that-this-is-correct"}}
+				rules := []models.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443"}}
 				securityGroup := models.SecurityGroup{
 					SecurityGroupFields: models.SecurityGroupFields{
 						Name:  "my-group",
 						GUID:  "group-guid",
-						Rules: rulesMap,
+						Rules: rules,
 					},
 					Spaces: []models.Space{
 						{
@@ -102,7 +102,9 @@ var _ = Describe("security-group command", func() {
 					[]string{"Rules"},
 					[]string{"["},
 					[]string{"{"},
-					[]string{"just-pretend", "that-this-is-correct"},
+					[]string{"protocol", "tcp"},
+					[]string{"destination", "10.0.0.1"},
+					[]string{"ports", "443"},
 					[]string{"}"},
 					[]string{"]"},
 					[]string{"#0", "org-1", "space-1"},
@@ -115,7 +117,7 @@ var _ = Describe("security-group command", func() {
 					SecurityGroupFields: models.SecurityGroupFields{
 						Name:  "my-group",
 						GUID:  "group-guid",
-						Rules: []map[string]interface{}{},
+						Rules: []models.SecurityGroupRule{},
 					},
 					Spaces: []models.Space{},
 				}
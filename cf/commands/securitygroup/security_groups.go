@@ -76,6 +76,7 @@ func (cmd *SecurityGroups) Execute(c flags.FlagContext) error {
 	}
 
 	table := cmd.ui.Table([]string{"", T("Name"), T("Organization"), T("Space")})
+	table.SetFieldNames("index", "name", "organization", "space")
 
 	for index, securityGroup := range securityGroups {
 		if len(securityGroup.Spaces) > 0 {
@@ -99,7 +99,7 @@ var _ = Describe("unbind-security-group command", func() {
 					SecurityGroupFields: models.SecurityGroupFields{
 						Name:  "my-group",
 						GUID:  "my-group-guid",
-						Rules: []map[string]interface{}{},
+						Rules: []models.SecurityGroupRule{},
 					},
 				}
 
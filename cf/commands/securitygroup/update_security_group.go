@@ -11,7 +11,6 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
-	"code.cloudfoundry.org/cli/utils/json"
 )
 
 type UpdateSecurityGroup struct {
@@ -26,7 +25,7 @@ func init() {
 
 func (cmd *UpdateSecurityGroup) MetaData() commandregistry.CommandMetadata {
 	primaryUsage := T("CF_NAME update-security-group SECURITY_GROUP PATH_TO_JSON_RULES_FILE")
-	secondaryUsage := T("   The provided path can be an absolute or relative path to a file.\n   It should have a single array with JSON objects inside describing the rules.")
+	secondaryUsage := T("   The provided path can be an absolute or relative path to a file.\n   It should have a single array with JSON objects inside describing the rules,\n   or a YAML array when its extension is .yml or .yaml. The rules are checked before they are uploaded.")
 	tipUsage := T("TIP: Changes will not apply to existing running applications until they are restarted.")
 	return commandregistry.CommandMetadata{
 		Name:        "update-security-group",
@@ -66,7 +65,7 @@ func (cmd *UpdateSecurityGroup) Execute(context flags.FlagContext) error {
 	}
 
 	pathToJSONFile := context.Args()[1]
-	rules, err := json.ParseJSONArray(pathToJSONFile)
+	rules, err := ReadRulesFile(pathToJSONFile)
 	if err != nil {
 		return err
 	}
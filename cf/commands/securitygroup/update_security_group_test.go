@@ -95,7 +95,7 @@ var _ = Describe("update-security-group command", func() {
 
 		Context("when the file specified has valid json", func() {
 			BeforeEach(func() {
-				tempFile.Write([]byte(`[{"protocol":"udp","port":"8080-9090","destination":"198.41.191.47/1"}]`))
+				tempFile.Write([]byte(`[{"protocol":"udp","ports":"8080-9090","destination":"198.41.191.47/1"}]`))
 			})
 
 			It("displays a message describing what its going to do", func() {
@@ -107,13 +107,13 @@ var _ = Describe("update-security-group command", func() {
 			})
 
 			It("updates the security group with those rules, obviously", func() {
-				jsonData := []map[string]interface{}{
-					{"protocol": "udp", "port": "8080-9090", "destination": "198.41.191.47/1"},
+				rules := []models.SecurityGroupRule{
+					{Protocol: "udp", Ports: "8080-9090", Destination: "198.41.191.47/1"},
 				}
 
-				_, jsonArg := securityGroupRepo.UpdateArgsForCall(0)
+				_, rulesArg := securityGroupRepo.UpdateArgsForCall(0)
 
-				Expect(jsonArg).To(Equal(jsonData))
+				Expect(rulesArg).To(Equal(rules))
 			})
 
 			Context("when the API returns an error", func() {
@@ -143,5 +143,21 @@ var _ = Describe("update-security-group command", func() {
 				})
 			})
 		})
+
+		Context("when a rule in the file is invalid", func() {
+			BeforeEach(func() {
+				tempFile.Write([]byte(`[{"protocol":"tcp","port":"443","destination":"10.0.0.0/8"}]`))
+			})
+
+			It("does not update the security group", func() {
+				Expect(securityGroupRepo.UpdateCallCount()).To(Equal(0))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid security group rules in file:", tempFile.Name()},
+					[]string{"rule 1: unknown field \"port\""},
+				))
+				Expect(ui.Outputs()).ToNot(ContainSubstrings([]string{"ports are required"}))
+			})
+		})
 	})
 })
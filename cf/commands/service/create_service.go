@@ -18,10 +18,11 @@ import (
 )
 
 type CreateService struct {
-	ui             terminal.UI
-	config         coreconfig.Reader
-	serviceRepo    api.ServiceRepository
-	serviceBuilder servicebuilder.ServiceBuilder
+	ui              terminal.UI
+	config          coreconfig.Reader
+	serviceRepo     api.ServiceRepository
+	serviceBuilder  servicebuilder.ServiceBuilder
+	OperationWaiter *ServiceOperationWaiter
 }
 
 func init() {
@@ -32,8 +33,9 @@ func (cmd *CreateService) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["c"] = &flags.StringFlag{ShortName: "c", Usage: T("Valid JSON object containing service-specific configuration parameters, provided either in-line or in a file. For a list of supported configuration parameters, see documentation for the particular service offering.")}
 	fs["t"] = &flags.StringFlag{ShortName: "t", Usage: T("User provided tags")}
+	fs["wait"] = &flags.BoolFlag{Name: "wait", Usage: T("Wait until the operation has finished, and fail if it fails. The timeout is set with 'config --async-timeout' (Default: 60 minutes)")}
 
-	baseUsage := T("CF_NAME create-service SERVICE PLAN SERVICE_INSTANCE [-c PARAMETERS_AS_JSON] [-t TAGS]")
+	baseUsage := T("CF_NAME create-service SERVICE PLAN SERVICE_INSTANCE [-c PARAMETERS_AS_JSON] [-t TAGS] [--wait]")
 	paramsUsage := T(`   Optionally provide service-specific configuration parameters in a valid JSON object in-line:
 
    CF_NAME create-service SERVICE PLAN SERVICE_INSTANCE -c '{"name":"value","name":"value"}'
@@ -100,6 +102,7 @@ func (cmd *CreateService) SetDependency(deps commandregistry.Dependency, pluginC
 	cmd.config = deps.Config
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
 	cmd.serviceBuilder = deps.ServiceBuilder
+	cmd.OperationWaiter = NewServiceOperationWaiter(deps.UI, deps.Config, cmd.serviceRepo)
 	return cmd
 }
 
@@ -129,7 +132,11 @@ func (cmd *CreateService) Execute(c flags.FlagContext) error {
 
 	switch err.(type) {
 	case nil:
-		err := printSuccessMessageForServiceInstance(serviceInstanceName, cmd.serviceRepo, cmd.ui)
+		if c.Bool("wait") {
+			err = cmd.OperationWaiter.waitAndReport(serviceInstanceName)
+		} else {
+			err = printSuccessMessageForServiceInstance(serviceInstanceName, cmd.serviceRepo, cmd.ui)
+		}
 		if err != nil {
 			return err
 		}
@@ -145,6 +152,10 @@ func (cmd *CreateService) Execute(c flags.FlagContext) error {
 			cmd.ui.Say("")
 		}
 	case *errors.ModelAlreadyExistsError:
+		if c.Bool("wait") {
+			cmd.ui.Warn(err.Error())
+			return cmd.OperationWaiter.waitAndReport(serviceInstanceName)
+		}
 		cmd.ui.Ok()
 		cmd.ui.Warn(err.Error())
 	default:
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/actors/servicebuilder/servicebuilderfakes"
+	"code.cloudfoundry.org/cli/cf/commands/service"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -43,7 +45,9 @@ var _ = Describe("create-service command", func() {
 		deps.Config = config
 		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
 		deps.ServiceBuilder = serviceBuilder
-		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("create-service").SetDependency(deps, pluginCall))
+		cmd := commandregistry.Commands.FindCommand("create-service").SetDependency(deps, pluginCall).(*service.CreateService)
+		cmd.OperationWaiter.PollInterval = time.Millisecond
+		commandregistry.Commands.SetCommand(cmd)
 	}
 
 	BeforeEach(func() {
@@ -245,6 +249,64 @@ var _ = Describe("create-service command", func() {
 		})
 	})
 
+	Context("when --wait is passed", func() {
+		var inProgress, succeeded models.ServiceInstance
+
+		BeforeEach(func() {
+			inProgress = models.ServiceInstance{}
+			inProgress.Name = "my-cleardb-service"
+			inProgress.ServicePlan = models.ServicePlanFields{GUID: "cleardb-spark-guid"}
+			inProgress.LastOperation = models.LastOperationFields{Type: "create", State: "in progress"}
+
+			succeeded = inProgress
+			succeeded.LastOperation.State = "succeeded"
+
+			serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+				if serviceRepo.FindInstanceByNameCallCount() < 3 {
+					return inProgress, nil
+				}
+				return succeeded, nil
+			}
+		})
+
+		It("waits until the service instance is created", func() {
+			Expect(callCreateService([]string{"cleardb", "spark", "my-cleardb-service", "--wait"})).To(BeTrue())
+
+			Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(3))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Creating service instance", "my-cleardb-service"},
+				[]string{"OK"},
+				[]string{"Waiting for the operation on service instance my-cleardb-service to finish..."},
+				[]string{"create in progress", "elapsed"},
+				[]string{"create succeeded"},
+				[]string{"OK"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Use 'cf services'"}))
+		})
+
+		It("fails when the creation fails", func() {
+			succeeded.LastOperation.State = "failed"
+			succeeded.LastOperation.Description = "no capacity"
+
+			Expect(callCreateService([]string{"cleardb", "spark", "my-cleardb-service", "--wait"})).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Service instance my-cleardb-service: create failed: no capacity"},
+			))
+		})
+
+		It("waits for the instance that already exists", func() {
+			serviceRepo.CreateServiceInstanceReturns(errors.NewModelAlreadyExistsError("Service", "my-cleardb-service"))
+
+			Expect(callCreateService([]string{"cleardb", "spark", "my-cleardb-service", "--wait"})).To(BeTrue())
+			Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"my-cleardb-service", "already exists"}))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Waiting for the operation on service instance my-cleardb-service to finish..."},
+				[]string{"create succeeded"},
+			))
+		})
+	})
+
 	Describe("warning the user about paid services", func() {
 		It("does not warn the user when the service is free", func() {
 			callCreateService([]string{"cleardb", "spark", "my-free-cleardb-service"})
@@ -18,6 +18,7 @@ type DeleteService struct {
 	config             coreconfig.Reader
 	serviceRepo        api.ServiceRepository
 	serviceInstanceReq requirements.ServiceInstanceRequirement
+	OperationWaiter    *ServiceOperationWaiter
 }
 
 func init() {
@@ -27,13 +28,14 @@ func init() {
 func (cmd *DeleteService) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force deletion without confirmation")}
+	fs["wait"] = &flags.BoolFlag{Name: "wait", Usage: T("Wait until the operation has finished, and fail if it fails. The timeout is set with 'config --async-timeout' (Default: 60 minutes)")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "delete-service",
 		ShortName:   "ds",
 		Description: T("Delete a service instance"),
 		Usage: []string{
-			T("CF_NAME delete-service SERVICE_INSTANCE [-f]"),
+			T("CF_NAME delete-service SERVICE_INSTANCE [-f] [--wait]"),
 		},
 		Flags: fs,
 	}
@@ -56,6 +58,7 @@ func (cmd *DeleteService) SetDependency(deps commandregistry.Dependency, pluginC
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
+	cmd.OperationWaiter = NewServiceOperationWaiter(deps.UI, deps.Config, cmd.serviceRepo)
 	return cmd
 }
 
@@ -93,6 +96,15 @@ func (cmd *DeleteService) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if c.Bool("wait") {
+		err = cmd.OperationWaiter.waitAndReport(serviceName)
+		if _, ok := err.(*errors.ModelNotFoundError); ok {
+			cmd.ui.Ok()
+			return nil
+		}
+		return err
+	}
+
 	err = printSuccessMessageForServiceInstance(serviceName, cmd.serviceRepo, cmd.ui)
 	if err != nil {
 		cmd.ui.Ok()
@@ -1,8 +1,11 @@
 package service_test
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/service"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -31,7 +34,9 @@ var _ = Describe("delete-service command", func() {
 		deps.UI = ui
 		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
 		deps.Config = configRepo
-		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("delete-service").SetDependency(deps, pluginCall))
+		cmd := commandregistry.Commands.FindCommand("delete-service").SetDependency(deps, pluginCall).(*service.DeleteService)
+		cmd.OperationWaiter.PollInterval = time.Millisecond
+		commandregistry.Commands.SetCommand(cmd)
 	}
 
 	BeforeEach(func() {
@@ -144,6 +149,67 @@ var _ = Describe("delete-service command", func() {
 					))
 				})
 			})
+
+			Context("and --wait is passed", func() {
+				var lookups []error
+
+				BeforeEach(func() {
+					serviceInstance = models.ServiceInstance{}
+					serviceInstance.Name = "my-service"
+					serviceInstance.GUID = "my-service-guid"
+					serviceInstance.ServicePlan = models.ServicePlanFields{GUID: "my-plan-guid"}
+					serviceInstance.LastOperation.Type = "delete"
+					serviceInstance.LastOperation.State = "in progress"
+
+					notFound := errors.NewModelNotFoundError("Service instance", "my-service")
+					lookups = []error{nil, nil, notFound}
+					serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+						err := lookups[serviceRepo.FindInstanceByNameCallCount()-1]
+						if err != nil {
+							return models.ServiceInstance{}, err
+						}
+						return serviceInstance, nil
+					}
+				})
+
+				It("waits until the service instance is gone", func() {
+					Expect(runCommand("-f", "--wait", "my-service")).To(BeTrue())
+
+					Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(3))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Deleting service", "my-service"},
+						[]string{"Waiting for the operation on service instance my-service to finish..."},
+						[]string{"delete in progress", "elapsed"},
+						[]string{"Service instance", "my-service", "was deleted"},
+						[]string{"OK"},
+					))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Delete in progress. Use"}))
+				})
+
+				It("succeeds when the deletion was synchronous", func() {
+					lookups = []error{nil, lookups[2]}
+
+					Expect(runCommand("-f", "--wait", "my-service")).To(BeTrue())
+
+					Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(2))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Waiting for the operation on service instance my-service to finish..."},
+						[]string{"OK"},
+					))
+				})
+
+				It("fails when the deletion fails", func() {
+					lookups = []error{nil, nil, nil}
+					serviceInstance.LastOperation.State = "failed"
+					serviceInstance.LastOperation.Description = "instance is still bound"
+
+					Expect(runCommand("-f", "--wait", "my-service")).To(BeFalse())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"FAILED"},
+						[]string{"Service instance my-service: delete failed: instance is still bound"},
+					))
+				})
+			})
 		})
 
 		Context("when the service does not exist", func() {
@@ -113,6 +113,7 @@ func (cmd MarketplaceServices) marketplaceByService(serviceName string) error {
 	}
 
 	table := cmd.ui.Table([]string{T("service plan"), T("description"), T("free or paid")})
+	table.SetFieldNames("service_plan", "description", "free_or_paid")
 	for _, plan := range serviceOffering.Plans {
 		var freeOrPaid string
 		if plan.Free {
@@ -161,6 +162,7 @@ func (cmd MarketplaceServices) marketplace() error {
 	}
 
 	table := cmd.ui.Table([]string{T("service"), T("plans"), T("description")})
+	table.SetFieldNames("service", "plans", "description")
 
 	sort.Sort(serviceOfferings)
 	var paidPlanExists bool
@@ -86,6 +86,7 @@ func (cmd *ListServices) Execute(fc flags.FlagContext) error {
 	}
 
 	table := cmd.ui.Table([]string{T("name"), T("service"), T("plan"), T("bound apps"), T("last operation")})
+	table.SetFieldNames("name", "service", "plan", "bound_apps", "last_operation")
 
 	for _, instance := range serviceInstances {
 		var serviceColumn string
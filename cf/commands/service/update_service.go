@@ -20,10 +20,11 @@ import (
 )
 
 type UpdateService struct {
-	ui          terminal.UI
-	config      coreconfig.Reader
-	serviceRepo api.ServiceRepository
-	planBuilder planbuilder.PlanBuilder
+	ui              terminal.UI
+	config          coreconfig.Reader
+	serviceRepo     api.ServiceRepository
+	planBuilder     planbuilder.PlanBuilder
+	OperationWaiter *ServiceOperationWaiter
 }
 
 func init() {
@@ -31,7 +32,7 @@ func init() {
 }
 
 func (cmd *UpdateService) MetaData() commandregistry.CommandMetadata {
-	baseUsage := T("CF_NAME update-service SERVICE_INSTANCE [-p NEW_PLAN] [-c PARAMETERS_AS_JSON] [-t TAGS]")
+	baseUsage := T("CF_NAME update-service SERVICE_INSTANCE [-p NEW_PLAN] [-c PARAMETERS_AS_JSON] [-t TAGS] [--wait]")
 	paramsUsage := T(`   Optionally provide service-specific configuration parameters in a valid JSON object in-line.
    CF_NAME update-service -c '{"name":"value","name":"value"}'
 
@@ -52,6 +53,7 @@ func (cmd *UpdateService) MetaData() commandregistry.CommandMetadata {
 	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Change service plan for a service instance")}
 	fs["c"] = &flags.StringFlag{ShortName: "c", Usage: T("Valid JSON object containing service-specific configuration parameters, provided either in-line or in a file. For a list of supported configuration parameters, see documentation for the particular service offering.")}
 	fs["t"] = &flags.StringFlag{ShortName: "t", Usage: T("User provided tags")}
+	fs["wait"] = &flags.BoolFlag{Name: "wait", Usage: T("Wait until the operation has finished, and fail if it fails. The timeout is set with 'config --async-timeout' (Default: 60 minutes)")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "update-service",
@@ -96,6 +98,7 @@ func (cmd *UpdateService) SetDependency(deps commandregistry.Dependency, pluginC
 	cmd.config = deps.Config
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
 	cmd.planBuilder = deps.PlanBuilder
+	cmd.OperationWaiter = NewServiceOperationWaiter(deps.UI, deps.Config, cmd.serviceRepo)
 	return cmd
 }
 
@@ -139,6 +142,9 @@ func (cmd *UpdateService) Execute(c flags.FlagContext) error {
 	if err != nil {
 		return err
 	}
+	if c.Bool("wait") {
+		return cmd.OperationWaiter.waitAndReport(serviceInstanceName)
+	}
 	err = printSuccessMessageForServiceInstance(serviceInstanceName, cmd.serviceRepo, cmd.ui)
 	if err != nil {
 		return err
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
+	"time"
 
 	planbuilderfakes "code.cloudfoundry.org/cli/cf/actors/planbuilder/planbuilderfakes"
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
@@ -39,7 +40,9 @@ var _ = Describe("update-service command", func() {
 		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
 		deps.Config = config
 		deps.PlanBuilder = planBuilder
-		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("update-service").SetDependency(deps, pluginCall))
+		cmd := commandregistry.Commands.FindCommand("update-service").SetDependency(deps, pluginCall).(*service.UpdateService)
+		cmd.OperationWaiter.PollInterval = time.Millisecond
+		commandregistry.Commands.SetCommand(cmd)
 	}
 
 	BeforeEach(func() {
@@ -490,4 +493,54 @@ var _ = Describe("update-service command", func() {
 		})
 
 	})
+
+	Context("when --wait is passed", func() {
+		var serviceInstance models.ServiceInstance
+
+		BeforeEach(func() {
+			serviceInstance = models.ServiceInstance{}
+			serviceInstance.Name = "my-service-instance"
+			serviceInstance.GUID = "my-service-instance-guid"
+			serviceInstance.ServicePlan = models.ServicePlanFields{GUID: "murkydb-spark-guid"}
+			serviceInstance.LastOperation = models.LastOperationFields{Type: "update", State: "in progress"}
+
+			serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+				instance := serviceInstance
+				if serviceRepo.FindInstanceByNameCallCount() > 2 {
+					instance.LastOperation.State = "succeeded"
+				}
+				return instance, nil
+			}
+		})
+
+		It("waits until the update has finished", func() {
+			Expect(callUpdateService([]string{"-t", "tag1", "--wait", "my-service-instance"})).To(BeTrue())
+
+			Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(3))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Updating service instance", "my-service-instance"},
+				[]string{"Waiting for the operation on service instance my-service-instance to finish..."},
+				[]string{"update in progress", "elapsed"},
+				[]string{"update succeeded"},
+				[]string{"OK"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Use 'cf services'"}))
+		})
+
+		It("fails when the update fails", func() {
+			serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+				instance := serviceInstance
+				if serviceRepo.FindInstanceByNameCallCount() > 1 {
+					instance.LastOperation.State = "failed"
+				}
+				return instance, nil
+			}
+
+			Expect(callUpdateService([]string{"-t", "tag1", "--wait", "my-service-instance"})).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Service instance my-service-instance: update failed"},
+			))
+		})
+	})
 })
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type WaitForService struct {
+	ui              terminal.UI
+	config          coreconfig.Reader
+	serviceRepo     api.ServiceRepository
+	OperationWaiter *ServiceOperationWaiter
+}
+
+func init() {
+	commandregistry.Register(&WaitForService{})
+}
+
+func (cmd *WaitForService) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["timeout"] = &flags.IntFlag{Name: "timeout", Usage: T("Minutes to wait before failing (Default: the timeout set with 'config --async-timeout', or 60)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "wait-for-service",
+		Description: T("Wait for the last operation on a service instance to finish"),
+		Usage: []string{
+			T("CF_NAME wait-for-service SERVICE_INSTANCE [--timeout MINUTES]"),
+			"\n\n",
+			T("TIP:\n   The command fails when the operation fails or does not finish in time, so scripts can wait for a service instance to be provisioned before binding it.\n   Waiting for a delete succeeds once the service instance is gone."),
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *WaitForService) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("wait-for-service"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *WaitForService) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
+	cmd.OperationWaiter = NewServiceOperationWaiter(deps.UI, deps.Config, cmd.serviceRepo)
+	return cmd
+}
+
+func (cmd *WaitForService) Execute(c flags.FlagContext) error {
+	serviceInstanceName := c.Args()[0]
+
+	waiter := *cmd.OperationWaiter
+	if c.IsSet("timeout") {
+		if c.Int("timeout") <= 0 {
+			return errors.New(T("Option '--timeout' must be a positive number of minutes"))
+		}
+		waiter.Timeout = time.Duration(c.Int("timeout")) * time.Minute
+	}
+
+	cmd.ui.Say(T("Waiting for the last operation on service instance {{.ServiceName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"ServiceName": terminal.EntityNameColor(serviceInstanceName),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	err := waiter.Wait(serviceInstanceName)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+const (
+	DefaultServiceOperationTimeout      = 60 * time.Minute
+	DefaultServiceOperationPollInterval = 5 * time.Second
+
+	serviceOperationProgressInterval = time.Minute
+)
+
+// ServiceOperationWaiter polls the last operation of a service instance
+// until the broker reports that it is no longer in progress.
+type ServiceOperationWaiter struct {
+	ui           terminal.UI
+	serviceRepo  api.ServiceRepository
+	Timeout      time.Duration
+	PollInterval time.Duration
+}
+
+// NewServiceOperationWaiter returns a waiter that gives up after the async
+// timeout of the config, or DefaultServiceOperationTimeout when none is set.
+func NewServiceOperationWaiter(ui terminal.UI, config coreconfig.Reader, serviceRepo api.ServiceRepository) *ServiceOperationWaiter {
+	timeout := DefaultServiceOperationTimeout
+	if config.AsyncTimeout() > 0 {
+		timeout = time.Duration(config.AsyncTimeout()) * time.Minute
+	}
+
+	return &ServiceOperationWaiter{
+		ui:           ui,
+		serviceRepo:  serviceRepo,
+		Timeout:      timeout,
+		PollInterval: DefaultServiceOperationPollInterval,
+	}
+}
+
+// Wait blocks until the last operation of the service instance succeeds,
+// which includes the instance being deleted while it is watched, and fails
+// when the operation fails or does not finish in time. A ModelNotFoundError
+// is returned when the instance does not exist to begin with.
+func (waiter *ServiceOperationWaiter) Wait(serviceInstanceName string) error {
+	start := time.Now()
+	timer := time.NewTimer(waiter.Timeout)
+	defer timer.Stop()
+
+	lastProgress := ""
+	var lastProgressAt time.Time
+
+	for polls := 0; ; polls++ {
+		instance, err := waiter.serviceRepo.FindInstanceByName(serviceInstanceName)
+		switch err.(type) {
+		case nil:
+		case *errors.ModelNotFoundError:
+			if polls == 0 {
+				return err
+			}
+			waiter.ui.Say(T("Service instance {{.ServiceName}} was deleted",
+				map[string]interface{}{"ServiceName": terminal.EntityNameColor(serviceInstanceName)}))
+			return nil
+		default:
+			return err
+		}
+
+		operation := instance.LastOperation
+		if instance.IsUserProvided() || operation.State == "" {
+			return nil
+		}
+
+		status := InstanceStateToStatus(operation.Type, operation.State, false)
+		switch operation.State {
+		case "succeeded":
+			waiter.ui.Say("%s", status)
+			return nil
+		case "failed":
+			message := status
+			if operation.Description != "" {
+				message = fmt.Sprintf("%s: %s", status, operation.Description)
+			}
+			return errors.New(T("Service instance {{.ServiceName}}: {{.Message}}",
+				map[string]interface{}{"ServiceName": serviceInstanceName, "Message": message}))
+		}
+
+		progress := status
+		if operation.Description != "" {
+			progress = fmt.Sprintf("%s: %s", status, operation.Description)
+		}
+		if progress != lastProgress || time.Since(lastProgressAt) >= serviceOperationProgressInterval {
+			waiter.ui.Say("%s", T("{{.Progress}} ({{.Elapsed}} elapsed)",
+				map[string]interface{}{"Progress": progress, "Elapsed": time.Since(start).Round(time.Second)}))
+			lastProgress = progress
+			lastProgressAt = time.Now()
+		}
+
+		select {
+		case <-timer.C:
+			return errors.New(T("Timed out after {{.Timeout}} waiting for service instance {{.ServiceName}}. Use '{{.ServiceCommand}}' to check the operation status.",
+				map[string]interface{}{
+					"Timeout":        waiter.Timeout,
+					"ServiceName":    serviceInstanceName,
+					"ServiceCommand": terminal.CommandColor(fmt.Sprintf("%s service %s", cf.Name, serviceInstanceName)),
+				}))
+		case <-time.After(waiter.PollInterval):
+		}
+	}
+}
+
+// waitAndReport says that it waits for the operation on the service
+// instance, and prints OK once the operation succeeded.
+func (waiter *ServiceOperationWaiter) waitAndReport(serviceInstanceName string) error {
+	waiter.ui.Say(T("Waiting for the operation on service instance {{.ServiceName}} to finish...",
+		map[string]interface{}{"ServiceName": terminal.EntityNameColor(serviceInstanceName)}))
+
+	err := waiter.Wait(serviceInstanceName)
+	if err != nil {
+		return err
+	}
+
+	waiter.ui.Ok()
+	return nil
+}
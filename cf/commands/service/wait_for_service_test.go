@@ -0,0 +1,210 @@
+package service_test
+
+import (
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/service"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+)
+
+var _ = Describe("wait-for-service command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *requirementsfakes.FakeFactory
+		serviceRepo         *apifakes.FakeServiceRepository
+		deps                commandregistry.Dependency
+		timeout             time.Duration
+
+		instances []models.ServiceInstance
+		findErrs  []error
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
+
+		cmd := commandregistry.Commands.FindCommand("wait-for-service").SetDependency(deps, pluginCall).(*service.WaitForService)
+		cmd.OperationWaiter.PollInterval = time.Millisecond
+		if timeout > 0 {
+			cmd.OperationWaiter.Timeout = timeout
+		}
+		commandregistry.Commands.SetCommand(cmd)
+	}
+
+	managedInstance := func(operationType, state, description string) models.ServiceInstance {
+		instance := models.ServiceInstance{}
+		instance.Name = "my-db"
+		instance.GUID = "my-db-guid"
+		instance.ServicePlan = models.ServicePlanFields{GUID: "plan-guid"}
+		instance.LastOperation = models.LastOperationFields{Type: operationType, State: state, Description: description}
+		return instance
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		config = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		serviceRepo = new(apifakes.FakeServiceRepository)
+		timeout = 0
+
+		instances = []models.ServiceInstance{
+			managedInstance("create", "in progress", "Provisioning the cluster"),
+			managedInstance("create", "in progress", "Provisioning the cluster"),
+			managedInstance("create", "succeeded", ""),
+		}
+		findErrs = []error{nil, nil, nil}
+
+		serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+			call := serviceRepo.FindInstanceByNameCallCount() - 1
+			if call >= len(instances) {
+				call = len(instances) - 1
+			}
+			return instances[call], findErrs[call]
+		}
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("wait-for-service", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-db")).To(BeFalse())
+		})
+
+		It("fails when a space is not targeted", func() {
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Failing{Message: "not targeted"})
+			Expect(runCommand("my-db")).To(BeFalse())
+		})
+
+		It("fails with usage when no argument is given", func() {
+			runCommand()
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires an argument"}))
+		})
+	})
+
+	It("waits until the operation succeeds", func() {
+		Expect(runCommand("my-db")).To(BeTrue())
+
+		Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(3))
+		Expect(serviceRepo.FindInstanceByNameArgsForCall(0)).To(Equal("my-db"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Waiting for the last operation on service instance", "my-db", "my-org", "my-space", "my-user"},
+			[]string{"create in progress: Provisioning the cluster", "elapsed"},
+			[]string{"create succeeded"},
+			[]string{"OK"},
+		))
+	})
+
+	It("only shows progress again when it changes", func() {
+		instances = []models.ServiceInstance{
+			managedInstance("update", "in progress", ""),
+			managedInstance("update", "in progress", ""),
+			managedInstance("update", "in progress", "50% done"),
+			managedInstance("update", "succeeded", ""),
+		}
+		findErrs = []error{nil, nil, nil, nil}
+
+		Expect(runCommand("my-db")).To(BeTrue())
+
+		progress := 0
+		for _, line := range ui.Outputs() {
+			if strings.Contains(line, "update in progress") {
+				progress++
+			}
+		}
+		Expect(progress).To(Equal(2))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"update in progress", "elapsed"},
+			[]string{"update in progress: 50% done"},
+			[]string{"update succeeded"},
+		))
+	})
+
+	It("fails when the operation fails", func() {
+		instances[2] = managedInstance("create", "failed", "Out of capacity")
+
+		Expect(runCommand("my-db")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Service instance my-db: create failed: Out of capacity"},
+		))
+	})
+
+	It("succeeds when the instance is deleted while waiting", func() {
+		instances[0] = managedInstance("delete", "in progress", "")
+		findErrs[1] = errors.NewModelNotFoundError("Service instance", "my-db")
+
+		Expect(runCommand("my-db")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Service instance", "my-db", "was deleted"},
+			[]string{"OK"},
+		))
+	})
+
+	It("fails when the instance does not exist", func() {
+		findErrs[0] = errors.NewModelNotFoundError("Service instance", "my-db")
+
+		Expect(runCommand("my-db")).To(BeFalse())
+		Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(1))
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Service instance", "my-db", "not found"}))
+	})
+
+	It("returns at once for a user-provided service instance", func() {
+		instances[0] = models.ServiceInstance{ServiceInstanceFields: models.ServiceInstanceFields{Name: "my-db"}}
+
+		Expect(runCommand("my-db")).To(BeTrue())
+		Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(1))
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"OK"}))
+	})
+
+	It("fails when the operation does not finish in time", func() {
+		timeout = 20 * time.Millisecond
+		instances = []models.ServiceInstance{managedInstance("create", "in progress", "")}
+		findErrs = []error{nil}
+
+		Expect(runCommand("my-db")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Timed out after 20ms waiting for service instance my-db", "cf service my-db"},
+		))
+	})
+
+	It("fails when the timeout is not positive", func() {
+		Expect(runCommand("my-db", "--timeout", "0")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Option '--timeout' must be a positive number of minutes"}))
+		Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(0))
+	})
+
+	Describe("the timeout", func() {
+		It("defaults to an hour", func() {
+			waiter := service.NewServiceOperationWaiter(ui, config, serviceRepo)
+			Expect(waiter.Timeout).To(Equal(service.DefaultServiceOperationTimeout))
+			Expect(waiter.Timeout).To(Equal(time.Hour))
+		})
+
+		It("uses the async timeout of the config", func() {
+			config.SetAsyncTimeout(15)
+			waiter := service.NewServiceOperationWaiter(ui, config, serviceRepo)
+			Expect(waiter.Timeout).To(Equal(15 * time.Minute))
+		})
+	})
+})
@@ -0,0 +1,386 @@
+package space
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/environmentvariablegroups"
+	"code.cloudfoundry.org/cli/cf/api/spaces"
+	"code.cloudfoundry.org/cli/cf/api/stacks"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type ExportSpace struct {
+	ui                           terminal.UI
+	config                       coreconfig.Reader
+	appSummaryRepo               api.AppSummaryRepository
+	stackRepo                    stacks.StackRepository
+	serviceSummaryRepo           api.ServiceSummaryRepository
+	serviceRepo                  api.ServiceRepository
+	userProvidedServiceRepo      api.UserProvidedServiceInstanceRepository
+	routeRepo                    api.RouteRepository
+	spaceRepo                    spaces.SpaceRepository
+	environmentVariableGroupRepo environmentvariablegroups.Repository
+}
+
+func init() {
+	commandregistry.Register(&ExportSpace{})
+}
+
+func (cmd *ExportSpace) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "export-space",
+		Description: T("Export the apps, services, routes and security groups of the targeted space to a directory"),
+		Usage: []string{
+			T("CF_NAME export-space DIR"),
+			"\n\n",
+			T("TIP:\n   DIR gets a space.json file describing the space, and an apps directory with a manifest for each app.\n   The bundle includes service credentials and environment variables, so store it securely.\n   Use 'CF_NAME import-space DIR' to recreate the space in the targeted space of any foundation."),
+		},
+	}
+}
+
+func (cmd *ExportSpace) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("export-space"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *ExportSpace) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
+	cmd.stackRepo = deps.RepoLocator.GetStackRepository()
+	cmd.serviceSummaryRepo = deps.RepoLocator.GetServiceSummaryRepository()
+	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
+	cmd.userProvidedServiceRepo = deps.RepoLocator.GetUserProvidedServiceInstanceRepository()
+	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
+	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
+	cmd.environmentVariableGroupRepo = deps.RepoLocator.GetEnvironmentVariableGroupsRepository()
+	return cmd
+}
+
+func (cmd *ExportSpace) Execute(c flags.FlagContext) error {
+	dir := c.Args()[0]
+
+	cmd.ui.Say(T("Exporting space {{.SpaceName}} in org {{.OrgName}} to {{.Dir}} as {{.Username}}...",
+		map[string]interface{}{
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"Dir":       terminal.EntityNameColor(dir),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	err := os.MkdirAll(filepath.Join(dir, SpaceBundleAppsDir), 0700)
+	if err != nil {
+		return errors.New(T("Error creating directory: ") + err.Error())
+	}
+
+	bundle := SpaceBundle{
+		Org:                  cmd.config.OrganizationFields().Name,
+		Space:                cmd.config.SpaceFields().Name,
+		Apps:                 []SpaceBundleApp{},
+		UserProvidedServices: []SpaceBundleUserProvidedService{},
+		Services:             []SpaceBundleService{},
+		Routes:               []SpaceBundleRoute{},
+		SecurityGroups:       []SpaceBundleSecurityGroup{},
+	}
+
+	err = cmd.exportApps(dir, &bundle)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.exportServices(&bundle)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.routeRepo.ListRoutes(func(route models.Route) bool {
+		appNames := []string{}
+		for _, app := range route.Apps {
+			appNames = append(appNames, app.Name)
+		}
+		sort.Strings(appNames)
+
+		bundle.Routes = append(bundle.Routes, SpaceBundleRoute{
+			Host:   route.Host,
+			Domain: route.Domain.Name,
+			Path:   route.Path,
+			Port:   route.Port,
+			Apps:   appNames,
+		})
+		return true
+	})
+	if err != nil {
+		return errors.New(T("Error retrieving routes: ") + err.Error())
+	}
+
+	space, err := cmd.spaceRepo.FindByName(cmd.config.SpaceFields().Name)
+	if err != nil {
+		return errors.New(T("Error retrieving security groups: ") + err.Error())
+	}
+	for _, group := range space.SecurityGroups {
+		bundle.SecurityGroups = append(bundle.SecurityGroups, SpaceBundleSecurityGroup{Name: group.Name, Rules: group.Rules})
+	}
+
+	bundle.EnvironmentVariableGroups = cmd.exportEnvironmentVariableGroups()
+
+	err = WriteSpaceBundle(dir, bundle)
+	if err != nil {
+		return errors.New(T("Error writing space bundle: ") + err.Error())
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say(T("Exported {{.Apps}} apps, {{.Services}} service instances, {{.UserProvidedServices}} user-provided service instances, {{.Routes}} routes and {{.SecurityGroups}} security groups.",
+		map[string]interface{}{
+			"Apps":                 len(bundle.Apps),
+			"Services":             len(bundle.Services),
+			"UserProvidedServices": len(bundle.UserProvidedServices),
+			"Routes":               len(bundle.Routes),
+			"SecurityGroups":       len(bundle.SecurityGroups),
+		}))
+	cmd.ui.Say("")
+	cmd.ui.Warn(T("The bundle includes service credentials and environment variables. Store it securely."))
+	cmd.ui.Say(T("TIP: Use '{{.Command}}' to recreate the space in the targeted space.",
+		map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " import-space " + dir)}))
+	return nil
+}
+
+func (cmd *ExportSpace) exportApps(dir string, bundle *SpaceBundle) error {
+	apps, err := cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+	if err != nil {
+		return errors.New(T("Error retrieving apps: ") + err.Error())
+	}
+
+	for _, app := range apps {
+		cmd.ui.Say(T("Exporting app {{.AppName}}...", map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
+
+		application, err := cmd.appSummaryRepo.GetSummary(app.GUID)
+		if err != nil {
+			return errors.New(T("Error getting application summary: ") + err.Error())
+		}
+
+		stack, err := cmd.stackRepo.FindByGUID(application.StackGUID)
+		if err != nil {
+			return errors.New(T("Error retrieving stack: ") + err.Error())
+		}
+		application.Stack = &stack
+
+		generator := manifest.NewGenerator()
+		err = manifest.GenerateManifest(generator, application)
+		if err != nil {
+			return err
+		}
+
+		manifestPath := filepath.Join(SpaceBundleAppsDir, application.Name+"_manifest.yml")
+		err = saveManifest(generator, filepath.Join(dir, manifestPath))
+		if err != nil {
+			return errors.New(T("Error creating manifest file: ") + err.Error())
+		}
+
+		bundle.Apps = append(bundle.Apps, SpaceBundleApp{Name: application.Name, Manifest: filepath.ToSlash(manifestPath)})
+	}
+
+	return nil
+}
+
+func (cmd *ExportSpace) exportServices(bundle *SpaceBundle) error {
+	summaries, err := cmd.serviceSummaryRepo.GetSummariesInCurrentSpace()
+	if err != nil {
+		return errors.New(T("Error retrieving service instances: ") + err.Error())
+	}
+
+	for _, summary := range summaries {
+		cmd.ui.Say(T("Exporting service instance {{.ServiceName}}...", map[string]interface{}{"ServiceName": terminal.EntityNameColor(summary.Name)}))
+
+		instance, err := cmd.serviceRepo.FindInstanceByName(summary.Name)
+		if err != nil {
+			return err
+		}
+
+		if instance.IsUserProvided() {
+			service, err := cmd.userProvidedServiceRepo.Get(instance.GUID)
+			if err != nil {
+				return err
+			}
+
+			bundle.UserProvidedServices = append(bundle.UserProvidedServices, SpaceBundleUserProvidedService{
+				Name:            instance.Name,
+				Credentials:     service.Credentials,
+				SyslogDrainURL:  service.SysLogDrainURL,
+				RouteServiceURL: service.RouteServiceURL,
+			})
+			continue
+		}
+
+		params, err := cmd.serviceRepo.GetServiceInstanceParameters(instance.GUID)
+		if err != nil {
+			cmd.ui.Warn(T("Could not export the parameters of service instance {{.ServiceName}}: {{.Error}}",
+				map[string]interface{}{"ServiceName": instance.Name, "Error": err.Error()}))
+			params = nil
+		}
+
+		bundle.Services = append(bundle.Services, SpaceBundleService{
+			Name:       instance.Name,
+			Service:    instance.ServiceOffering.Label,
+			Plan:       instance.ServicePlan.Name,
+			Tags:       instance.Tags,
+			Parameters: params,
+		})
+	}
+
+	return nil
+}
+
+// exportEnvironmentVariableGroups returns the running and staging
+// environment variable groups, or nil when they cannot be read, as they
+// are optional on import.
+func (cmd *ExportSpace) exportEnvironmentVariableGroups() *SpaceBundleEnvironmentVariableGroups {
+	running, err := cmd.environmentVariableGroupRepo.ListRunning()
+	if err == nil {
+		var staging []models.EnvironmentVariable
+		staging, err = cmd.environmentVariableGroupRepo.ListStaging()
+		if err == nil {
+			return &SpaceBundleEnvironmentVariableGroups{
+				Running: environmentVariableMap(running),
+				Staging: environmentVariableMap(staging),
+			}
+		}
+	}
+
+	cmd.ui.Warn(T("Could not export the environment variable groups: {{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	return nil
+}
+
+func environmentVariableMap(variables []models.EnvironmentVariable) map[string]string {
+	values := map[string]string{}
+	for _, variable := range variables {
+		values[variable.Name] = variable.Value
+	}
+	return values
+}
+
+func saveManifest(generator manifest.App, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return generator.Save(f)
+}
+
+const (
+	// SpaceBundleFile is the file in a bundle directory that describes the
+	// space. App manifests are written next to it, under SpaceBundleAppsDir.
+	SpaceBundleFile    = "space.json"
+	SpaceBundleAppsDir = "apps"
+)
+
+// SpaceBundle is the portable description of a space written by
+// export-space and recreated by import-space. Apps are described by the
+// manifest files they point to, relative to the bundle directory.
+type SpaceBundle struct {
+	Org                       string                                `json:"org"`
+	Space                     string                                `json:"space"`
+	Apps                      []SpaceBundleApp                      `json:"apps"`
+	UserProvidedServices      []SpaceBundleUserProvidedService      `json:"user_provided_services"`
+	Services                  []SpaceBundleService                  `json:"services"`
+	Routes                    []SpaceBundleRoute                    `json:"routes"`
+	SecurityGroups            []SpaceBundleSecurityGroup            `json:"security_groups"`
+	EnvironmentVariableGroups *SpaceBundleEnvironmentVariableGroups `json:"environment_variable_groups,omitempty"`
+}
+
+type SpaceBundleApp struct {
+	Name     string `json:"name"`
+	Manifest string `json:"manifest"`
+}
+
+type SpaceBundleUserProvidedService struct {
+	Name            string                 `json:"name"`
+	Credentials     map[string]interface{} `json:"credentials,omitempty"`
+	SyslogDrainURL  string                 `json:"syslog_drain_url,omitempty"`
+	RouteServiceURL string                 `json:"route_service_url,omitempty"`
+}
+
+type SpaceBundleService struct {
+	Name       string                 `json:"name"`
+	Service    string                 `json:"service"`
+	Plan       string                 `json:"plan"`
+	Tags       []string               `json:"tags,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type SpaceBundleRoute struct {
+	Host   string   `json:"host,omitempty"`
+	Domain string   `json:"domain"`
+	Path   string   `json:"path,omitempty"`
+	Port   int      `json:"port,omitempty"`
+	Apps   []string `json:"apps,omitempty"`
+}
+
+type SpaceBundleSecurityGroup struct {
+	Name  string                     `json:"name"`
+	Rules []models.SecurityGroupRule `json:"rules"`
+}
+
+type SpaceBundleEnvironmentVariableGroups struct {
+	Running map[string]string `json:"running"`
+	Staging map[string]string `json:"staging"`
+}
+
+// WriteSpaceBundle writes the description of the space to dir, which must
+// exist.
+func WriteSpaceBundle(dir string, bundle SpaceBundle) error {
+	contents, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, SpaceBundleFile), append(contents, '\n'), 0600)
+}
+
+// ReadSpaceBundle reads the description of the space in dir.
+func ReadSpaceBundle(dir string) (SpaceBundle, error) {
+	bundle := SpaceBundle{}
+	path := filepath.Join(dir, SpaceBundleFile)
+
+	contents, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return bundle, errors.New(T("{{.Dir}} is not a space bundle: {{.File}} is missing",
+			map[string]interface{}{"Dir": dir, "File": SpaceBundleFile}))
+	}
+	if err != nil {
+		return bundle, err
+	}
+
+	err = json.Unmarshal(contents, &bundle)
+	if err != nil {
+		return bundle, errors.New(T("Incorrect space bundle format: file: {{.File}}\n{{.Error}}",
+			map[string]interface{}{"File": path, "Error": err.Error()}))
+	}
+
+	return bundle, nil
+}
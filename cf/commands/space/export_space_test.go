@@ -0,0 +1,251 @@
+package space_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/environmentvariablegroups/environmentvariablegroupsfakes"
+	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
+	"code.cloudfoundry.org/cli/cf/api/stacks/stacksfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	. "code.cloudfoundry.org/cli/cf/commands/space"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+)
+
+var _ = Describe("export-space command", func() {
+	var (
+		ui                      *testterm.FakeUI
+		configRepo              coreconfig.Repository
+		requirementsFactory     *requirementsfakes.FakeFactory
+		appSummaryRepo          *apifakes.FakeAppSummaryRepository
+		stackRepo               *stacksfakes.FakeStackRepository
+		serviceSummaryRepo      *apifakes.FakeServiceSummaryRepository
+		serviceRepo             *apifakes.FakeServiceRepository
+		userProvidedServiceRepo *apifakes.FakeUserProvidedServiceInstanceRepository
+		routeRepo               *apifakes.FakeRouteRepository
+		spaceRepo               *spacesfakes.FakeSpaceRepository
+		envVarGroupRepo         *environmentvariablegroupsfakes.FakeRepository
+		deps                    commandregistry.Dependency
+		dir                     string
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+		deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+		deps.RepoLocator = deps.RepoLocator.SetStackRepository(stackRepo)
+		deps.RepoLocator = deps.RepoLocator.SetServiceSummaryRepository(serviceSummaryRepo)
+		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
+		deps.RepoLocator = deps.RepoLocator.SetUserProvidedServiceInstanceRepository(userProvidedServiceRepo)
+		deps.RepoLocator = deps.RepoLocator.SetRouteRepository(routeRepo)
+		deps.RepoLocator = deps.RepoLocator.SetSpaceRepository(spaceRepo)
+		deps.RepoLocator = deps.RepoLocator.SetEnvironmentVariableGroupsRepository(envVarGroupRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("export-space").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+		stackRepo = new(stacksfakes.FakeStackRepository)
+		serviceSummaryRepo = new(apifakes.FakeServiceSummaryRepository)
+		serviceRepo = new(apifakes.FakeServiceRepository)
+		userProvidedServiceRepo = new(apifakes.FakeUserProvidedServiceInstanceRepository)
+		routeRepo = new(apifakes.FakeRouteRepository)
+		spaceRepo = new(spacesfakes.FakeSpaceRepository)
+		envVarGroupRepo = new(environmentvariablegroupsfakes.FakeRepository)
+
+		var err error
+		dir, err = ioutil.TempDir("", "export-space")
+		Expect(err).NotTo(HaveOccurred())
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		app.StackGUID = "my-stack-guid"
+		app.Memory = 256
+		app.DiskQuota = 1024
+		app.InstanceCount = 2
+		app.Services = []models.ServicePlanSummary{{Name: "my-db"}}
+		appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{app}, nil)
+		appSummaryRepo.GetSummaryReturns(app, nil)
+		stackRepo.FindByGUIDReturns(models.Stack{Name: "cflinuxfs2"}, nil)
+
+		serviceSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.ServiceInstance{
+			{ServiceInstanceFields: models.ServiceInstanceFields{Name: "my-db"}},
+			{ServiceInstanceFields: models.ServiceInstanceFields{Name: "my-ups"}},
+		}, nil)
+		serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+			if name == "my-ups" {
+				return models.ServiceInstance{ServiceInstanceFields: models.ServiceInstanceFields{Name: "my-ups", GUID: "my-ups-guid"}}, nil
+			}
+			return models.ServiceInstance{
+				ServiceInstanceFields: models.ServiceInstanceFields{Name: "my-db", GUID: "my-db-guid", Tags: []string{"sql"}},
+				ServicePlan:           models.ServicePlanFields{Name: "small", GUID: "small-guid"},
+				ServiceOffering:       models.ServiceOfferingFields{Label: "p-mysql"},
+			}, nil
+		}
+		serviceRepo.GetServiceInstanceParametersReturns(map[string]interface{}{"size": "large"}, nil)
+		userProvidedServiceRepo.GetReturns(models.UserProvidedService{
+			Name:           "my-ups",
+			Credentials:    map[string]interface{}{"password": "secret"},
+			SysLogDrainURL: "syslog://example.com",
+		}, nil)
+
+		routeRepo.ListRoutesStub = func(cb func(models.Route) bool) error {
+			cb(models.Route{
+				Host:   "my-app",
+				Domain: models.DomainFields{Name: "example.com"},
+				Path:   "/api",
+				Apps:   []models.ApplicationFields{{Name: "my-app"}},
+			})
+			return nil
+		}
+
+		spaceRepo.FindByNameReturns(models.Space{
+			SecurityGroups: []models.SecurityGroupFields{{
+				Name:  "db-access",
+				Rules: []models.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.0/24", Ports: "3306"}},
+			}},
+		}, nil)
+
+		envVarGroupRepo.ListRunningReturns([]models.EnvironmentVariable{{Name: "HTTP_PROXY", Value: "proxy.example.com"}}, nil)
+		envVarGroupRepo.ListStagingReturns([]models.EnvironmentVariable{}, nil)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("export-space", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand(dir)).To(BeFalse())
+		})
+
+		It("fails when no space is targeted", func() {
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Failing{Message: "no space targeted"})
+			Expect(runCommand(dir)).To(BeFalse())
+		})
+
+		It("fails with usage when no directory is given", func() {
+			runCommand()
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires an argument"}))
+		})
+	})
+
+	It("writes the space bundle and a manifest for each app", func() {
+		Expect(runCommand(dir)).To(BeTrue())
+
+		bundle, err := ReadSpaceBundle(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bundle.Org).To(Equal("my-org"))
+		Expect(bundle.Space).To(Equal("my-space"))
+		Expect(bundle.Apps).To(Equal([]SpaceBundleApp{{Name: "my-app", Manifest: "apps/my-app_manifest.yml"}}))
+		Expect(bundle.UserProvidedServices).To(Equal([]SpaceBundleUserProvidedService{{
+			Name:           "my-ups",
+			Credentials:    map[string]interface{}{"password": "secret"},
+			SyslogDrainURL: "syslog://example.com",
+		}}))
+		Expect(bundle.Services).To(Equal([]SpaceBundleService{{
+			Name:       "my-db",
+			Service:    "p-mysql",
+			Plan:       "small",
+			Tags:       []string{"sql"},
+			Parameters: map[string]interface{}{"size": "large"},
+		}}))
+		Expect(bundle.Routes).To(Equal([]SpaceBundleRoute{{Host: "my-app", Domain: "example.com", Path: "/api", Apps: []string{"my-app"}}}))
+		Expect(bundle.SecurityGroups).To(Equal([]SpaceBundleSecurityGroup{{
+			Name:  "db-access",
+			Rules: []models.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.0/24", Ports: "3306"}},
+		}}))
+		Expect(bundle.EnvironmentVariableGroups).To(Equal(&SpaceBundleEnvironmentVariableGroups{
+			Running: map[string]string{"HTTP_PROXY": "proxy.example.com"},
+			Staging: map[string]string{},
+		}))
+
+		Expect(userProvidedServiceRepo.GetArgsForCall(0)).To(Equal("my-ups-guid"))
+		Expect(serviceRepo.GetServiceInstanceParametersArgsForCall(0)).To(Equal("my-db-guid"))
+		Expect(spaceRepo.FindByNameArgsForCall(0)).To(Equal("my-space"))
+
+		contents, err := ioutil.ReadFile(filepath.Join(dir, "apps", "my-app_manifest.yml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(ContainSubstring("name: my-app"))
+		Expect(string(contents)).To(ContainSubstring("stack: cflinuxfs2"))
+		Expect(string(contents)).To(ContainSubstring("- my-db"))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Exporting space", "my-space", "my-org", dir, "my-user"},
+			[]string{"Exporting app", "my-app"},
+			[]string{"OK"},
+			[]string{"Exported 1 apps, 1 service instances, 1 user-provided service instances, 1 routes and 1 security groups."},
+			[]string{"service credentials", "Store it securely"},
+			[]string{"import-space", dir},
+		))
+	})
+
+	Context("when the broker cannot return the parameters of a service instance", func() {
+		BeforeEach(func() {
+			serviceRepo.GetServiceInstanceParametersReturns(nil, errors.New("not supported"))
+		})
+
+		It("warns and exports the service instance without them", func() {
+			Expect(runCommand(dir)).To(BeTrue())
+
+			bundle, err := ReadSpaceBundle(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bundle.Services[0].Name).To(Equal("my-db"))
+			Expect(bundle.Services[0].Parameters).To(BeNil())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Could not export the parameters of service instance my-db", "not supported"}))
+		})
+	})
+
+	Context("when the environment variable groups cannot be read", func() {
+		BeforeEach(func() {
+			envVarGroupRepo.ListRunningReturns(nil, errors.New("not authorized"))
+		})
+
+		It("warns and leaves them out", func() {
+			Expect(runCommand(dir)).To(BeTrue())
+
+			bundle, err := ReadSpaceBundle(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bundle.EnvironmentVariableGroups).To(BeNil())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Could not export the environment variable groups", "not authorized"}))
+		})
+	})
+
+	Context("when the app summary cannot be read", func() {
+		BeforeEach(func() {
+			appSummaryRepo.GetSummaryReturns(models.Application{}, errors.New("summary failed"))
+		})
+
+		It("fails without writing the bundle", func() {
+			Expect(runCommand(dir)).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Error getting application summary", "summary failed"}))
+
+			_, err := os.Stat(filepath.Join(dir, SpaceBundleFile))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+})
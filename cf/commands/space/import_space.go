@@ -0,0 +1,471 @@
+package space
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/servicebuilder"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/api/environmentvariablegroups"
+	"code.cloudfoundry.org/cli/cf/api/securitygroups"
+	securitygroupspaces "code.cloudfoundry.org/cli/cf/api/securitygroups/spaces"
+	"code.cloudfoundry.org/cli/cf/api/stacks"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type ImportSpace struct {
+	ui                           terminal.UI
+	config                       coreconfig.Reader
+	manifestRepo                 manifest.Repository
+	securityGroupRepo            securitygroups.SecurityGroupRepo
+	securityGroupSpaceBinder     securitygroupspaces.SecurityGroupSpaceBinder
+	serviceRepo                  api.ServiceRepository
+	serviceBuilder               servicebuilder.ServiceBuilder
+	userProvidedServiceRepo      api.UserProvidedServiceInstanceRepository
+	appRepo                      applications.Repository
+	stackRepo                    stacks.StackRepository
+	serviceBindingRepo           api.ServiceBindingRepository
+	domainRepo                   api.DomainRepository
+	routeRepo                    api.RouteRepository
+	environmentVariableGroupRepo environmentvariablegroups.Repository
+}
+
+func init() {
+	commandregistry.Register(&ImportSpace{})
+}
+
+func (cmd *ImportSpace) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["env-var-groups"] = &flags.BoolFlag{Name: "env-var-groups", Usage: T("Also set the running and staging environment variable groups, which apply to every app in the foundation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "import-space",
+		Description: T("Recreate a space exported with export-space in the targeted space"),
+		Usage: []string{
+			T("CF_NAME import-space DIR [--env-var-groups]"),
+			"\n\n",
+			T("TIP:\n   Security groups, service instances, apps and routes that already exist are left as they are, so an import that failed can be run again.\n   Apps are created stopped and without bits. Push each app with its manifest from DIR/apps to start it."),
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *ImportSpace) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("import-space"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *ImportSpace) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.manifestRepo = deps.ManifestRepo
+	cmd.securityGroupRepo = deps.RepoLocator.GetSecurityGroupRepository()
+	cmd.securityGroupSpaceBinder = deps.RepoLocator.GetSecurityGroupSpaceBinder()
+	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
+	cmd.serviceBuilder = deps.ServiceBuilder
+	cmd.userProvidedServiceRepo = deps.RepoLocator.GetUserProvidedServiceInstanceRepository()
+	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.stackRepo = deps.RepoLocator.GetStackRepository()
+	cmd.serviceBindingRepo = deps.RepoLocator.GetServiceBindingRepository()
+	cmd.domainRepo = deps.RepoLocator.GetDomainRepository()
+	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
+	cmd.environmentVariableGroupRepo = deps.RepoLocator.GetEnvironmentVariableGroupsRepository()
+	return cmd
+}
+
+func (cmd *ImportSpace) Execute(c flags.FlagContext) error {
+	dir := c.Args()[0]
+
+	bundle, err := ReadSpaceBundle(dir)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Importing space {{.BundleSpaceName}} from {{.Dir}} into space {{.SpaceName}} in org {{.OrgName}} as {{.Username}}...",
+		map[string]interface{}{
+			"BundleSpaceName": terminal.EntityNameColor(bundle.Space),
+			"Dir":             terminal.EntityNameColor(dir),
+			"SpaceName":       terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"OrgName":         terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"Username":        terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	err = cmd.importSecurityGroups(bundle.SecurityGroups)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.importServices(bundle)
+	if err != nil {
+		return err
+	}
+
+	appGUIDs, err := cmd.importApps(dir, bundle.Apps)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.importRoutes(bundle.Routes, appGUIDs)
+	if err != nil {
+		return err
+	}
+
+	if bundle.EnvironmentVariableGroups != nil {
+		if c.Bool("env-var-groups") {
+			err = cmd.importEnvironmentVariableGroups(*bundle.EnvironmentVariableGroups)
+			if err != nil {
+				return err
+			}
+		} else {
+			cmd.ui.Say(T("Skipping the environment variable groups, as they apply to every app in the foundation. Use --env-var-groups to set them."))
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	if len(bundle.Apps) > 0 {
+		cmd.ui.Say(T("TIP: Apps are created stopped and without bits. Use '{{.Command}}' to push each app.",
+			map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " push APP_NAME -f " + filepath.Join(dir, SpaceBundleAppsDir, "APP_NAME_manifest.yml") + " -p PATH")}))
+	}
+	return nil
+}
+
+func (cmd *ImportSpace) importSecurityGroups(groups []SpaceBundleSecurityGroup) error {
+	for _, group := range groups {
+		securityGroup, err := cmd.securityGroupRepo.Read(group.Name)
+		switch err.(type) {
+		case nil:
+			cmd.ui.Say(T("Security group {{.Name}} already exists, its rules are left as they are",
+				map[string]interface{}{"Name": terminal.EntityNameColor(group.Name)}))
+			if !sameSecurityGroupRules(securityGroup.Rules, group.Rules) {
+				cmd.ui.Warn(T("The rules of security group {{.Name}} differ from the rules in the bundle. Use '{{.Command}}' to change them.",
+					map[string]interface{}{"Name": group.Name, "Command": cf.Name + " update-security-group"}))
+			}
+		case *errors.ModelNotFoundError:
+			cmd.ui.Say(T("Creating security group {{.Name}}...", map[string]interface{}{"Name": terminal.EntityNameColor(group.Name)}))
+			err = cmd.securityGroupRepo.Create(group.Name, group.Rules)
+			if err != nil {
+				return importError(T("security group"), group.Name, err)
+			}
+
+			securityGroup, err = cmd.securityGroupRepo.Read(group.Name)
+			if err != nil {
+				return importError(T("security group"), group.Name, err)
+			}
+		default:
+			return importError(T("security group"), group.Name, err)
+		}
+
+		cmd.ui.Say(T("Binding security group {{.Name}} to the space...", map[string]interface{}{"Name": terminal.EntityNameColor(group.Name)}))
+		err = cmd.securityGroupSpaceBinder.BindSpace(securityGroup.GUID, cmd.config.SpaceFields().GUID)
+		if err != nil {
+			return importError(T("security group"), group.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// sameSecurityGroupRules reports whether both lists hold the same rules,
+// in any order.
+func sameSecurityGroupRules(a, b []models.SecurityGroupRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := map[string]int{}
+	for _, rule := range a {
+		encoded, _ := json.Marshal(rule)
+		counts[string(encoded)]++
+	}
+	for _, rule := range b {
+		encoded, _ := json.Marshal(rule)
+		if counts[string(encoded)] == 0 {
+			return false
+		}
+		counts[string(encoded)]--
+	}
+
+	return true
+}
+
+func (cmd *ImportSpace) importServices(bundle SpaceBundle) error {
+	for _, service := range bundle.UserProvidedServices {
+		exists, err := cmd.serviceInstanceExists(service.Name)
+		if err != nil {
+			return importError(T("service instance"), service.Name, err)
+		}
+		if exists {
+			continue
+		}
+
+		cmd.ui.Say(T("Creating user-provided service instance {{.Name}}...", map[string]interface{}{"Name": terminal.EntityNameColor(service.Name)}))
+		err = cmd.userProvidedServiceRepo.Create(service.Name, service.SyslogDrainURL, service.RouteServiceURL, service.Credentials)
+		if err != nil {
+			return importError(T("service instance"), service.Name, err)
+		}
+	}
+
+	for _, service := range bundle.Services {
+		exists, err := cmd.serviceInstanceExists(service.Name)
+		if err != nil {
+			return importError(T("service instance"), service.Name, err)
+		}
+		if exists {
+			continue
+		}
+
+		cmd.ui.Say(T("Creating service instance {{.Name}} of service {{.Service}} with plan {{.Plan}}...",
+			map[string]interface{}{
+				"Name":    terminal.EntityNameColor(service.Name),
+				"Service": terminal.EntityNameColor(service.Service),
+				"Plan":    terminal.EntityNameColor(service.Plan),
+			}))
+
+		offerings, err := cmd.serviceBuilder.GetServicesByNameForSpaceWithPlans(cmd.config.SpaceFields().GUID, service.Service)
+		if err != nil {
+			return importError(T("service instance"), service.Name, err)
+		}
+
+		planGUID, err := findBundlePlan(offerings, service.Service, service.Plan)
+		if err != nil {
+			return importError(T("service instance"), service.Name, err)
+		}
+
+		err = cmd.serviceRepo.CreateServiceInstance(service.Name, planGUID, service.Parameters, service.Tags)
+		if err != nil {
+			return importError(T("service instance"), service.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// findBundlePlan returns the GUID of the plan with the given name. Offerings
+// of several brokers can share a label, so the plan has to be unique among
+// all of them.
+func findBundlePlan(offerings models.ServiceOfferings, serviceName string, planName string) (string, error) {
+	var planGUIDs []string
+	for _, offering := range offerings {
+		for _, plan := range offering.Plans {
+			if plan.Name == planName {
+				planGUIDs = append(planGUIDs, plan.GUID)
+			}
+		}
+	}
+
+	switch len(planGUIDs) {
+	case 0:
+		return "", errors.New(T("Could not find plan with name {{.ServicePlanName}}",
+			map[string]interface{}{"ServicePlanName": planName}))
+	case 1:
+		return planGUIDs[0], nil
+	default:
+		return "", errors.New(T("Found more than one plan named {{.ServicePlanName}} of service {{.ServiceName}}. The service is offered by several service brokers.",
+			map[string]interface{}{"ServicePlanName": planName, "ServiceName": serviceName}))
+	}
+}
+
+func (cmd *ImportSpace) serviceInstanceExists(name string) (bool, error) {
+	_, err := cmd.serviceRepo.FindInstanceByName(name)
+	switch err.(type) {
+	case nil:
+		cmd.ui.Say(T("Service instance {{.Name}} already exists", map[string]interface{}{"Name": terminal.EntityNameColor(name)}))
+		return true, nil
+	case *errors.ModelNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// importApps creates the apps that do not exist yet from their manifests
+// and binds them to their services. It returns the GUID of every app in the
+// bundle by name.
+func (cmd *ImportSpace) importApps(dir string, bundleApps []SpaceBundleApp) (map[string]string, error) {
+	appGUIDs := map[string]string{}
+
+	for _, bundleApp := range bundleApps {
+		params, err := cmd.readAppManifest(filepath.Join(dir, filepath.FromSlash(bundleApp.Manifest)), bundleApp.Name)
+		if err != nil {
+			return nil, importError(T("app"), bundleApp.Name, err)
+		}
+
+		app, err := cmd.appRepo.Read(bundleApp.Name)
+		switch err.(type) {
+		case nil:
+			cmd.ui.Say(T("App {{.Name}} already exists", map[string]interface{}{"Name": terminal.EntityNameColor(bundleApp.Name)}))
+		case *errors.ModelNotFoundError:
+			cmd.ui.Say(T("Creating app {{.Name}}...", map[string]interface{}{"Name": terminal.EntityNameColor(bundleApp.Name)}))
+			app, err = cmd.createApp(params)
+			if err != nil {
+				return nil, importError(T("app"), bundleApp.Name, err)
+			}
+		default:
+			return nil, importError(T("app"), bundleApp.Name, err)
+		}
+		appGUIDs[app.Name] = app.GUID
+
+		for _, serviceName := range params.ServicesToBind {
+			cmd.ui.Say(T("Binding service {{.ServiceName}} to app {{.AppName}}...",
+				map[string]interface{}{
+					"ServiceName": terminal.EntityNameColor(serviceName),
+					"AppName":     terminal.EntityNameColor(app.Name),
+				}))
+
+			instance, err := cmd.serviceRepo.FindInstanceByName(serviceName)
+			if err != nil {
+				return nil, importError(T("app"), bundleApp.Name, err)
+			}
+
+			err = cmd.serviceBindingRepo.Create(instance.GUID, app.GUID, nil)
+			if httpErr, ok := err.(errors.HTTPError); ok && httpErr.ErrorCode() == errors.ServiceBindingAppServiceTaken {
+				err = nil
+			}
+			if err != nil {
+				return nil, importError(T("app"), bundleApp.Name, err)
+			}
+		}
+	}
+
+	return appGUIDs, nil
+}
+
+func (cmd *ImportSpace) readAppManifest(path string, name string) (models.AppParams, error) {
+	m, err := cmd.manifestRepo.ReadManifest(path)
+	if err != nil {
+		return models.AppParams{}, err
+	}
+
+	apps, err := m.Applications()
+	if err != nil {
+		return models.AppParams{}, err
+	}
+
+	for _, app := range apps {
+		if app.Name != nil && *app.Name == name {
+			return app, nil
+		}
+	}
+
+	return models.AppParams{}, errors.New(T("Could not find app named '{{.AppName}}' in manifest", map[string]interface{}{"AppName": name}))
+}
+
+func (cmd *ImportSpace) createApp(params models.AppParams) (models.Application, error) {
+	if params.StackName != nil {
+		stack, err := cmd.stackRepo.FindByName(*params.StackName)
+		if err != nil {
+			return models.Application{}, err
+		}
+		params.StackGUID = &stack.GUID
+	}
+
+	spaceGUID := cmd.config.SpaceFields().GUID
+	state := models.ApplicationStateStopped
+	params.SpaceGUID = &spaceGUID
+	params.State = &state
+
+	return cmd.appRepo.Create(params)
+}
+
+func (cmd *ImportSpace) importRoutes(routes []SpaceBundleRoute, appGUIDs map[string]string) error {
+	for _, bundleRoute := range routes {
+		url := (&models.RoutePresenter{
+			Host:   bundleRoute.Host,
+			Domain: bundleRoute.Domain,
+			Path:   bundleRoute.Path,
+			Port:   bundleRoute.Port,
+		}).URL()
+
+		domain, err := cmd.domainRepo.FindByNameInOrg(bundleRoute.Domain, cmd.config.OrganizationFields().GUID)
+		if err != nil {
+			return importError(T("route"), url, err)
+		}
+
+		route, err := cmd.routeRepo.Find(bundleRoute.Host, domain, bundleRoute.Path, bundleRoute.Port)
+		switch err.(type) {
+		case nil:
+			cmd.ui.Say(T("Route {{.URL}} already exists", map[string]interface{}{"URL": terminal.EntityNameColor(url)}))
+		case *errors.ModelNotFoundError:
+			cmd.ui.Say(T("Creating route {{.URL}}...", map[string]interface{}{"URL": terminal.EntityNameColor(url)}))
+			route, err = cmd.routeRepo.CreateInSpace(bundleRoute.Host, bundleRoute.Path, domain.GUID, cmd.config.SpaceFields().GUID, bundleRoute.Port, false)
+			if err != nil {
+				return importError(T("route"), url, err)
+			}
+		default:
+			return importError(T("route"), url, err)
+		}
+
+		for _, appName := range bundleRoute.Apps {
+			appGUID, ok := appGUIDs[appName]
+			if !ok {
+				app, err := cmd.appRepo.Read(appName)
+				if err != nil {
+					return importError(T("route"), url, err)
+				}
+				appGUID = app.GUID
+			}
+
+			cmd.ui.Say(T("Mapping route {{.URL}} to app {{.AppName}}...",
+				map[string]interface{}{
+					"URL":     terminal.EntityNameColor(url),
+					"AppName": terminal.EntityNameColor(appName),
+				}))
+			err = cmd.routeRepo.Bind(route.GUID, appGUID)
+			if err != nil {
+				return importError(T("route"), url, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cmd *ImportSpace) importEnvironmentVariableGroups(groups SpaceBundleEnvironmentVariableGroups) error {
+	cmd.ui.Say(T("Setting the running and staging environment variable groups..."))
+
+	running, err := json.Marshal(groups.Running)
+	if err != nil {
+		return err
+	}
+	err = cmd.environmentVariableGroupRepo.SetRunning(string(running))
+	if err != nil {
+		return importError(T("environment variable group"), T("running"), err)
+	}
+
+	staging, err := json.Marshal(groups.Staging)
+	if err != nil {
+		return err
+	}
+	err = cmd.environmentVariableGroupRepo.SetStaging(string(staging))
+	if err != nil {
+		return importError(T("environment variable group"), T("staging"), err)
+	}
+
+	return nil
+}
+
+func importError(kind string, name string, err error) error {
+	return errors.New(T("Could not import {{.Kind}} {{.Name}}: {{.Error}}\nImports skip what already exists, so the import can be run again once the problem is fixed.",
+		map[string]interface{}{"Kind": kind, "Name": name, "Error": err.Error()}))
+}
@@ -0,0 +1,394 @@
+package space_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/actors/servicebuilder/servicebuilderfakes"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/environmentvariablegroups/environmentvariablegroupsfakes"
+	"code.cloudfoundry.org/cli/cf/api/securitygroups/securitygroupsfakes"
+	securitygroupspacesfakes "code.cloudfoundry.org/cli/cf/api/securitygroups/spaces/spacesfakes"
+	"code.cloudfoundry.org/cli/cf/api/stacks/stacksfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	. "code.cloudfoundry.org/cli/cf/commands/space"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/manifest/manifestfakes"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/generic"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+)
+
+var _ = Describe("import-space command", func() {
+	var (
+		ui                       *testterm.FakeUI
+		configRepo               coreconfig.Repository
+		requirementsFactory      *requirementsfakes.FakeFactory
+		manifestRepo             *manifestfakes.FakeRepository
+		securityGroupRepo        *securitygroupsfakes.FakeSecurityGroupRepo
+		securityGroupSpaceBinder *securitygroupspacesfakes.FakeSecurityGroupSpaceBinder
+		serviceRepo              *apifakes.FakeServiceRepository
+		serviceBuilder           *servicebuilderfakes.FakeServiceBuilder
+		userProvidedServiceRepo  *apifakes.FakeUserProvidedServiceInstanceRepository
+		appRepo                  *applicationsfakes.FakeRepository
+		stackRepo                *stacksfakes.FakeStackRepository
+		serviceBindingRepo       *apifakes.FakeServiceBindingRepository
+		domainRepo               *apifakes.FakeDomainRepository
+		routeRepo                *apifakes.FakeRouteRepository
+		envVarGroupRepo          *environmentvariablegroupsfakes.FakeRepository
+		deps                     commandregistry.Dependency
+		dir                      string
+		bundle                   SpaceBundle
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+		deps.ManifestRepo = manifestRepo
+		deps.ServiceBuilder = serviceBuilder
+		deps.RepoLocator = deps.RepoLocator.SetSecurityGroupRepository(securityGroupRepo)
+		deps.RepoLocator = deps.RepoLocator.SetSecurityGroupSpaceBinder(securityGroupSpaceBinder)
+		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
+		deps.RepoLocator = deps.RepoLocator.SetUserProvidedServiceInstanceRepository(userProvidedServiceRepo)
+		deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+		deps.RepoLocator = deps.RepoLocator.SetStackRepository(stackRepo)
+		deps.RepoLocator = deps.RepoLocator.SetServiceBindingRepository(serviceBindingRepo)
+		deps.RepoLocator = deps.RepoLocator.SetDomainRepository(domainRepo)
+		deps.RepoLocator = deps.RepoLocator.SetRouteRepository(routeRepo)
+		deps.RepoLocator = deps.RepoLocator.SetEnvironmentVariableGroupsRepository(envVarGroupRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("import-space").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		manifestRepo = new(manifestfakes.FakeRepository)
+		securityGroupRepo = new(securitygroupsfakes.FakeSecurityGroupRepo)
+		securityGroupSpaceBinder = new(securitygroupspacesfakes.FakeSecurityGroupSpaceBinder)
+		serviceRepo = new(apifakes.FakeServiceRepository)
+		serviceBuilder = new(servicebuilderfakes.FakeServiceBuilder)
+		userProvidedServiceRepo = new(apifakes.FakeUserProvidedServiceInstanceRepository)
+		appRepo = new(applicationsfakes.FakeRepository)
+		stackRepo = new(stacksfakes.FakeStackRepository)
+		serviceBindingRepo = new(apifakes.FakeServiceBindingRepository)
+		domainRepo = new(apifakes.FakeDomainRepository)
+		routeRepo = new(apifakes.FakeRouteRepository)
+		envVarGroupRepo = new(environmentvariablegroupsfakes.FakeRepository)
+
+		var err error
+		dir, err = ioutil.TempDir("", "import-space")
+		Expect(err).NotTo(HaveOccurred())
+
+		bundle = SpaceBundle{
+			Org:   "old-org",
+			Space: "old-space",
+			Apps:  []SpaceBundleApp{{Name: "my-app", Manifest: "apps/my-app_manifest.yml"}},
+			UserProvidedServices: []SpaceBundleUserProvidedService{{
+				Name:           "my-ups",
+				Credentials:    map[string]interface{}{"password": "secret"},
+				SyslogDrainURL: "syslog://example.com",
+			}},
+			Services: []SpaceBundleService{{
+				Name:       "my-db",
+				Service:    "p-mysql",
+				Plan:       "small",
+				Tags:       []string{"sql"},
+				Parameters: map[string]interface{}{"size": "large"},
+			}},
+			Routes: []SpaceBundleRoute{{Host: "my-app", Domain: "example.com", Path: "/api", Apps: []string{"my-app"}}},
+			SecurityGroups: []SpaceBundleSecurityGroup{{
+				Name:  "db-access",
+				Rules: []models.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.0/24", Ports: "3306"}},
+			}},
+			EnvironmentVariableGroups: &SpaceBundleEnvironmentVariableGroups{
+				Running: map[string]string{"HTTP_PROXY": "proxy.example.com"},
+				Staging: map[string]string{},
+			},
+		}
+
+		manifestRepo.ReadManifestReturns(&manifest.Manifest{
+			Path: "apps/my-app_manifest.yml",
+			Data: generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"name":      "my-app",
+						"memory":    "256M",
+						"instances": 2,
+						"stack":     "cflinuxfs2",
+						"services":  []interface{}{"my-db"},
+					}),
+				},
+			}),
+		}, nil)
+
+		securityGroupRepo.ReadStub = func(name string) (models.SecurityGroup, error) {
+			if securityGroupRepo.CreateCallCount() == 0 {
+				return models.SecurityGroup{}, errors.NewModelNotFoundError("Security group", name)
+			}
+			return models.SecurityGroup{SecurityGroupFields: models.SecurityGroupFields{Name: name, GUID: "db-access-guid"}}, nil
+		}
+
+		serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+			if name == "my-db" && serviceRepo.CreateServiceInstanceCallCount() > 0 {
+				return models.ServiceInstance{ServiceInstanceFields: models.ServiceInstanceFields{Name: name, GUID: "my-db-guid"}}, nil
+			}
+			return models.ServiceInstance{}, errors.NewModelNotFoundError("Service instance", name)
+		}
+		serviceBuilder.GetServicesByNameForSpaceWithPlansReturns(models.ServiceOfferings{{
+			Plans: []models.ServicePlanFields{{Name: "large", GUID: "large-guid"}, {Name: "small", GUID: "small-guid"}},
+		}}, nil)
+
+		appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "my-app"))
+		appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+			app := models.Application{}
+			app.Name = *params.Name
+			app.GUID = "my-app-guid"
+			return app, nil
+		}
+		stackRepo.FindByNameReturns(models.Stack{Name: "cflinuxfs2", GUID: "cflinuxfs2-guid"}, nil)
+
+		domainRepo.FindByNameInOrgReturns(models.DomainFields{Name: "example.com", GUID: "example-guid"}, nil)
+		routeRepo.FindReturns(models.Route{}, errors.NewModelNotFoundError("Route", "my-app.example.com/api"))
+		routeRepo.CreateInSpaceReturns(models.Route{GUID: "route-guid"}, nil)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	runCommand := func(args ...string) bool {
+		Expect(WriteSpaceBundle(dir, bundle)).To(Succeed())
+		return testcmd.RunCLICommand("import-space", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand(dir)).To(BeFalse())
+		})
+
+		It("fails when no space is targeted", func() {
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Failing{Message: "no space targeted"})
+			Expect(runCommand(dir)).To(BeFalse())
+		})
+
+		It("fails with usage when no directory is given", func() {
+			runCommand()
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "Requires an argument"}))
+		})
+	})
+
+	It("fails when the directory is not a space bundle", func() {
+		emptyDir := filepath.Join(dir, "empty")
+		Expect(os.Mkdir(emptyDir, 0700)).To(Succeed())
+
+		Expect(runCommand(emptyDir)).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"is not a space bundle", "space.json is missing"}))
+	})
+
+	It("recreates the space in the targeted space", func() {
+		Expect(runCommand(dir)).To(BeTrue())
+
+		name, rules := securityGroupRepo.CreateArgsForCall(0)
+		Expect(name).To(Equal("db-access"))
+		Expect(rules).To(Equal([]models.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.0/24", Ports: "3306"}}))
+		groupGUID, spaceGUID := securityGroupSpaceBinder.BindSpaceArgsForCall(0)
+		Expect(groupGUID).To(Equal("db-access-guid"))
+		Expect(spaceGUID).To(Equal("my-space-guid"))
+
+		name, drainURL, routeServiceURL, credentials := userProvidedServiceRepo.CreateArgsForCall(0)
+		Expect(name).To(Equal("my-ups"))
+		Expect(drainURL).To(Equal("syslog://example.com"))
+		Expect(routeServiceURL).To(BeEmpty())
+		Expect(credentials).To(Equal(map[string]interface{}{"password": "secret"}))
+
+		spaceGUID, serviceLabel := serviceBuilder.GetServicesByNameForSpaceWithPlansArgsForCall(0)
+		Expect(spaceGUID).To(Equal("my-space-guid"))
+		Expect(serviceLabel).To(Equal("p-mysql"))
+		name, planGUID, params, tags := serviceRepo.CreateServiceInstanceArgsForCall(0)
+		Expect(name).To(Equal("my-db"))
+		Expect(planGUID).To(Equal("small-guid"))
+		Expect(params).To(Equal(map[string]interface{}{"size": "large"}))
+		Expect(tags).To(Equal([]string{"sql"}))
+
+		Expect(manifestRepo.ReadManifestArgsForCall(0)).To(Equal(filepath.Join(dir, "apps", "my-app_manifest.yml")))
+		appParams := appRepo.CreateArgsForCall(0)
+		Expect(*appParams.Name).To(Equal("my-app"))
+		Expect(*appParams.InstanceCount).To(Equal(2))
+		Expect(*appParams.StackGUID).To(Equal("cflinuxfs2-guid"))
+		Expect(*appParams.SpaceGUID).To(Equal("my-space-guid"))
+		Expect(*appParams.State).To(Equal("stopped"))
+
+		instanceGUID, appGUID, _ := serviceBindingRepo.CreateArgsForCall(0)
+		Expect(instanceGUID).To(Equal("my-db-guid"))
+		Expect(appGUID).To(Equal("my-app-guid"))
+
+		domainName, orgGUID := domainRepo.FindByNameInOrgArgsForCall(0)
+		Expect(domainName).To(Equal("example.com"))
+		Expect(orgGUID).To(Equal("my-org-guid"))
+		host, path, domainGUID, spaceGUID, port, randomPort := routeRepo.CreateInSpaceArgsForCall(0)
+		Expect(host).To(Equal("my-app"))
+		Expect(path).To(Equal("/api"))
+		Expect(domainGUID).To(Equal("example-guid"))
+		Expect(spaceGUID).To(Equal("my-space-guid"))
+		Expect(port).To(Equal(0))
+		Expect(randomPort).To(BeFalse())
+		routeGUID, appGUID := routeRepo.BindArgsForCall(0)
+		Expect(routeGUID).To(Equal("route-guid"))
+		Expect(appGUID).To(Equal("my-app-guid"))
+
+		Expect(envVarGroupRepo.SetRunningCallCount()).To(Equal(0))
+		Expect(envVarGroupRepo.SetStagingCallCount()).To(Equal(0))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Importing space", "old-space", dir, "my-space", "my-org", "my-user"},
+			[]string{"Creating security group", "db-access"},
+			[]string{"Binding security group", "db-access"},
+			[]string{"Creating user-provided service instance", "my-ups"},
+			[]string{"Creating service instance", "my-db", "p-mysql", "small"},
+			[]string{"Creating app", "my-app"},
+			[]string{"Binding service", "my-db", "my-app"},
+			[]string{"Creating route", "my-app.example.com/api"},
+			[]string{"Mapping route", "my-app.example.com/api", "my-app"},
+			[]string{"Skipping the environment variable groups", "--env-var-groups"},
+			[]string{"OK"},
+			[]string{"Apps are created stopped", "push APP_NAME -f"},
+		))
+	})
+
+	Context("when the security groups, services, app and route already exist", func() {
+		BeforeEach(func() {
+			securityGroupRepo.ReadStub = nil
+			securityGroupRepo.ReadReturns(models.SecurityGroup{
+				SecurityGroupFields: models.SecurityGroupFields{
+					Name:  "db-access",
+					GUID:  "db-access-guid",
+					Rules: []models.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.0/24", Ports: "3306"}},
+				},
+			}, nil)
+			serviceRepo.FindInstanceByNameStub = nil
+			serviceRepo.FindInstanceByNameReturns(models.ServiceInstance{ServiceInstanceFields: models.ServiceInstanceFields{Name: "my-db", GUID: "my-db-guid"}}, nil)
+			existingApp := models.Application{}
+			existingApp.Name = "my-app"
+			existingApp.GUID = "existing-app-guid"
+			appRepo.ReadReturns(existingApp, nil)
+			routeRepo.FindReturns(models.Route{GUID: "existing-route-guid"}, nil)
+			serviceBindingRepo.CreateReturns(errors.NewHTTPError(400, errors.ServiceBindingAppServiceTaken, "The app space binding to service is taken"))
+		})
+
+		It("leaves them as they are and still binds and maps them", func() {
+			Expect(runCommand(dir)).To(BeTrue())
+
+			Expect(securityGroupRepo.CreateCallCount()).To(Equal(0))
+			Expect(userProvidedServiceRepo.CreateCallCount()).To(Equal(0))
+			Expect(serviceRepo.CreateServiceInstanceCallCount()).To(Equal(0))
+			Expect(appRepo.CreateCallCount()).To(Equal(0))
+			Expect(routeRepo.CreateInSpaceCallCount()).To(Equal(0))
+
+			groupGUID, _ := securityGroupSpaceBinder.BindSpaceArgsForCall(0)
+			Expect(groupGUID).To(Equal("db-access-guid"))
+			routeGUID, appGUID := routeRepo.BindArgsForCall(0)
+			Expect(routeGUID).To(Equal("existing-route-guid"))
+			Expect(appGUID).To(Equal("existing-app-guid"))
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Security group", "db-access", "already exists"},
+				[]string{"Service instance", "my-ups", "already exists"},
+				[]string{"App", "my-app", "already exists"},
+				[]string{"Route", "my-app.example.com/api", "already exists"},
+				[]string{"OK"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"differ from the rules in the bundle"}))
+		})
+
+		Context("when the rules of an existing security group differ from the bundle", func() {
+			BeforeEach(func() {
+				bundle.SecurityGroups[0].Rules = append(bundle.SecurityGroups[0].Rules, models.SecurityGroupRule{Protocol: "udp", Destination: "10.0.0.1", Ports: "53"})
+			})
+
+			It("warns that the rules are left as they are", func() {
+				Expect(runCommand(dir)).To(BeTrue())
+
+				Expect(securityGroupRepo.UpdateCallCount()).To(Equal(0))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"The rules of security group db-access differ from the rules in the bundle", "update-security-group"},
+					[]string{"OK"},
+				))
+			})
+		})
+	})
+
+	Context("when --env-var-groups is given", func() {
+		It("sets the running and staging environment variable groups", func() {
+			Expect(runCommand(dir, "--env-var-groups")).To(BeTrue())
+
+			Expect(envVarGroupRepo.SetRunningArgsForCall(0)).To(MatchJSON(`{"HTTP_PROXY": "proxy.example.com"}`))
+			Expect(envVarGroupRepo.SetStagingArgsForCall(0)).To(MatchJSON(`{}`))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Skipping the environment variable groups"}))
+		})
+	})
+
+	Context("when the plan of a service instance does not exist", func() {
+		BeforeEach(func() {
+			bundle.Services[0].Plan = "huge"
+		})
+
+		It("fails and explains that the import can be run again", func() {
+			Expect(runCommand(dir)).To(BeFalse())
+
+			Expect(serviceRepo.CreateServiceInstanceCallCount()).To(Equal(0))
+			Expect(appRepo.CreateCallCount()).To(Equal(0))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Could not import service instance my-db", "Could not find plan with name huge"},
+				[]string{"can be run again"},
+			))
+		})
+	})
+
+	Context("when several service offerings with the label have a plan with the name", func() {
+		BeforeEach(func() {
+			serviceBuilder.GetServicesByNameForSpaceWithPlansReturns(models.ServiceOfferings{
+				{Plans: []models.ServicePlanFields{{Name: "small", GUID: "broker-1-small-guid"}}},
+				{Plans: []models.ServicePlanFields{{Name: "small", GUID: "broker-2-small-guid"}}},
+			}, nil)
+		})
+
+		It("fails instead of picking one of the plans", func() {
+			Expect(runCommand(dir)).To(BeFalse())
+
+			Expect(serviceRepo.CreateServiceInstanceCallCount()).To(Equal(0))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Could not import service instance my-db", "Found more than one plan named small of service p-mysql"},
+			))
+		})
+	})
+
+	Context("when creating an app fails", func() {
+		BeforeEach(func() {
+			appRepo.CreateStub = nil
+			appRepo.CreateReturns(models.Application{}, errors.New("quota exceeded"))
+		})
+
+		It("fails with the error", func() {
+			Expect(runCommand(dir)).To(BeFalse())
+
+			Expect(routeRepo.CreateInSpaceCallCount()).To(Equal(0))
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Could not import app my-app", "quota exceeded"}))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package space
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -133,9 +134,16 @@ func (cmd *ShowSpace) Execute(c flags.FlagContext) error {
 			cmd.ui.Say(T("Getting rules for the security group  : {{.SecurityGroupName}}...",
 				map[string]interface{}{"SecurityGroupName": terminal.EntityNameColor(group.Name)}))
 			table := cmd.ui.Table([]string{"", "", "", ""})
-			for _, rules := range group.Rules {
-				for ruleName, ruleValue := range rules {
-					table.Add("", ruleName, ":", fmt.Sprintf("%v", ruleValue))
+			for _, rule := range group.Rules {
+				fields := rule.Map()
+				names := []string{}
+				for name := range fields {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+
+				for _, name := range names {
+					table.Add("", name, ":", fmt.Sprintf("%v", fields[name]))
 				}
 				table.Add("", "", "", "")
 			}
@@ -213,7 +221,10 @@ func (cmd *ShowSpace) populatePluginModel(space models.Space) {
 		sg := plugin_models.GetSpace_SecurityGroup{
 			Name:  group.Name,
 			Guid:  group.GUID,
-			Rules: group.Rules,
+			Rules: []map[string]interface{}{},
+		}
+		for _, rule := range group.Rules {
+			sg.Rules = append(sg.Rules, rule.Map())
 		}
 		cmd.pluginModel.SecurityGroups = append(cmd.pluginModel.SecurityGroups, sg)
 	}
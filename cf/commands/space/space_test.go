@@ -147,11 +147,11 @@ var _ = Describe("space command", func() {
 			}
 			services := []models.ServiceInstanceFields{serviceInstance}
 
-			securityGroup1 := models.SecurityGroupFields{Name: "Nacho Security", Rules: []map[string]interface{}{
-				{"protocol": "all", "destination": "0.0.0.0-9.255.255.255", "log": true, "IntTest": 1000},
+			securityGroup1 := models.SecurityGroupFields{Name: "Nacho Security", Rules: []models.SecurityGroupRule{
+				{Protocol: "tcp", Destination: "0.0.0.0-9.255.255.255", Ports: "443", Log: true},
 			}}
-			securityGroup2 := models.SecurityGroupFields{Name: "Nacho Prime", Rules: []map[string]interface{}{
-				{"protocol": "udp", "ports": "8080-9090", "destination": "198.41.191.47/1"},
+			securityGroup2 := models.SecurityGroupFields{Name: "Nacho Prime", Rules: []models.SecurityGroupRule{
+				{Protocol: "udp", Ports: "8080-9090", Destination: "198.41.191.47/1"},
 			}}
 			securityGroups := []models.SecurityGroupFields{securityGroup1, securityGroup2}
 
@@ -223,14 +223,14 @@ var _ = Describe("space command", func() {
 					Expect(executeErr).NotTo(HaveOccurred())
 					Expect(ui.Outputs()).To(ContainSubstrings(
 						[]string{"Getting rules for the security group", "Nacho Security"},
-						[]string{"protocol", "all"},
 						[]string{"destination", "0.0.0.0-9.255.255.255"},
-						[]string{"Getting rules for the security group", "Nacho Prime"},
-						[]string{"protocol", "udp"},
 						[]string{"log", "true"},
-						[]string{"IntTest", "1000"},
-						[]string{"ports", "8080-9090"},
+						[]string{"ports", "443"},
+						[]string{"protocol", "tcp"},
+						[]string{"Getting rules for the security group", "Nacho Prime"},
 						[]string{"destination", "198.41.191.47/1"},
+						[]string{"ports", "8080-9090"},
+						[]string{"protocol", "udp"},
 					))
 				})
 			})
@@ -348,7 +348,9 @@ var _ = Describe("space command", func() {
 					Expect(getSpaceModel.SecurityGroups[0].Rules).To(HaveLen(1))
 					Expect(getSpaceModel.SecurityGroups[0].Rules[0]).To(HaveLen(4))
 					val := getSpaceModel.SecurityGroups[0].Rules[0]["protocol"]
-					Expect(val).To(Equal("all"))
+					Expect(val).To(Equal("tcp"))
+					val = getSpaceModel.SecurityGroups[0].Rules[0]["log"]
+					Expect(val).To(Equal(true))
 					val = getSpaceModel.SecurityGroups[0].Rules[0]["destination"]
 					Expect(val).To(Equal("0.0.0.0-9.255.255.255"))
 
@@ -73,6 +73,7 @@ func (cmd *ListSpaces) Execute(c flags.FlagContext) error {
 
 	foundSpaces := false
 	table := cmd.ui.Table([]string{T("name")})
+	table.SetFieldNames("name")
 	err := cmd.spaceRepo.ListSpaces(func(space models.Space) bool {
 		table.Add(space.Name)
 		foundSpaces = true
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type TargetProfile struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&TargetProfile{})
+}
+
+func (cmd *TargetProfile) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "target-profile",
+		Description: T("Save, switch between, list or delete named targets"),
+		Usage: []string{
+			T("CF_NAME target-profile save NAME"),
+			"\n   ",
+			T("CF_NAME target-profile use NAME"),
+			"\n   ",
+			T("CF_NAME target-profile list"),
+			"\n   ",
+			T("CF_NAME target-profile delete NAME"),
+			"\n\n",
+			T("TIP:\n   A profile holds the API endpoint, tokens, SSL setting and targeted org and space. Set CF_PROFILE=NAME to use a profile for a single command without switching to it. A new API endpoint targeted while CF_PROFILE is set is not saved"),
+		},
+	}
+}
+
+func (cmd *TargetProfile) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	args := fc.Args()
+	if !validTargetProfileArgs(args) {
+		cmd.ui.Failed(T("Incorrect Usage. Requires save, use or delete with a profile name, or list\n\n") + commandregistry.Commands.CommandUsage("target-profile"))
+		return nil, fmt.Errorf("Incorrect usage: invalid target-profile arguments %v", args)
+	}
+
+	reqs := []requirements.Requirement{}
+	if args[0] == "save" {
+		reqs = append(reqs, requirementsFactory.NewAPIEndpointRequirement())
+	}
+
+	return reqs, nil
+}
+
+func validTargetProfileArgs(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch args[0] {
+	case "save", "use", "delete":
+		return len(args) == 2
+	case "list":
+		return len(args) == 1
+	default:
+		return false
+	}
+}
+
+func (cmd *TargetProfile) SetDependency(deps commandregistry.Dependency, _ bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *TargetProfile) Execute(c flags.FlagContext) error {
+	args := c.Args()
+
+	switch args[0] {
+	case "save":
+		return cmd.save(args[1])
+	case "use":
+		return cmd.use(args[1])
+	case "delete":
+		return cmd.delete(args[1])
+	default:
+		return cmd.list()
+	}
+}
+
+func (cmd *TargetProfile) save(name string) error {
+	cmd.ui.Say(T("Saving the current target as profile {{.Name}}...",
+		map[string]interface{}{"Name": terminal.EntityNameColor(name)}))
+
+	cmd.config.SaveProfile(name)
+
+	cmd.ui.Ok()
+	return nil
+}
+
+func (cmd *TargetProfile) use(name string) error {
+	cmd.ui.Say(T("Switching to profile {{.Name}}...",
+		map[string]interface{}{"Name": terminal.EntityNameColor(name)}))
+
+	err := cmd.config.UseProfile(name)
+	if err != nil {
+		return errors.New(T("Target profile {{.Name}} not found",
+			map[string]interface{}{"Name": name}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return cmd.ui.ShowConfiguration(cmd.config)
+}
+
+func (cmd *TargetProfile) delete(name string) error {
+	cmd.ui.Say(T("Deleting profile {{.Name}}...",
+		map[string]interface{}{"Name": terminal.EntityNameColor(name)}))
+
+	err := cmd.config.DeleteProfile(name)
+	if err != nil {
+		cmd.ui.Ok()
+		cmd.ui.Warn(T("Target profile {{.Name}} does not exist.",
+			map[string]interface{}{"Name": name}))
+		return nil
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+func (cmd *TargetProfile) list() error {
+	cmd.ui.Say(T("Getting target profiles..."))
+
+	profiles := cmd.config.Profiles()
+	if len(profiles) == 0 {
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+		cmd.ui.Say(T("No target profiles found"))
+		return nil
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	current := cmd.config.CurrentProfile()
+	table := cmd.ui.Table([]string{"", T("name"), T("api endpoint"), T("user"), T("org"), T("space")})
+	table.SetFieldNames("current", "name", "api_endpoint", "user", "org", "space")
+	for _, name := range names {
+		profile := profiles[name]
+		marker := ""
+		if name == current {
+			marker = "*"
+		}
+		table.Add(marker, name, profile.Target, profile.Username(), profile.OrganizationFields.Name, profile.SpaceFields.Name)
+	}
+
+	return table.Print()
+}
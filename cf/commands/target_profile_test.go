@@ -0,0 +1,137 @@
+package commands_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+)
+
+var _ = Describe("target-profile command", func() {
+	var (
+		config              coreconfig.Repository
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("target-profile").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("target-profile", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetAPIEndpoint("https://api.one.example.com")
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewAPIEndpointRequirementReturns(requirements.Passing{})
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when no action is given", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Incorrect Usage.", "Requires save, use or delete with a profile name, or list"},
+			))
+		})
+
+		It("fails with usage when the action is unknown", func() {
+			runCommand("rename", "one")
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage"}))
+		})
+
+		It("fails with usage when save is not given a name", func() {
+			runCommand("save")
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage"}))
+		})
+
+		It("fails when saving without an API endpoint", func() {
+			requirementsFactory.NewAPIEndpointRequirementReturns(requirements.Failing{Message: "no api set"})
+			Expect(runCommand("save", "one")).To(BeFalse())
+		})
+	})
+
+	It("saves the current target as a profile", func() {
+		Expect(runCommand("save", "one")).To(BeTrue())
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Saving the current target as profile", "one"},
+			[]string{"OK"},
+		))
+		Expect(config.Profiles()["one"].Target).To(Equal("https://api.one.example.com"))
+		Expect(config.CurrentProfile()).To(Equal("one"))
+	})
+
+	It("switches to a saved profile and shows the target", func() {
+		config.SaveProfile("one")
+		config.SetAPIEndpoint("https://api.two.example.com")
+		config.SaveProfile("two")
+
+		Expect(runCommand("use", "one")).To(BeTrue())
+
+		Expect(config.APIEndpoint()).To(Equal("https://api.one.example.com"))
+		Expect(config.CurrentProfile()).To(Equal("one"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Switching to profile", "one"},
+			[]string{"OK"},
+		))
+	})
+
+	It("fails when the profile to use does not exist", func() {
+		Expect(runCommand("use", "missing")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Target profile missing not found"},
+		))
+	})
+
+	It("deletes a profile", func() {
+		config.SaveProfile("one")
+
+		Expect(runCommand("delete", "one")).To(BeTrue())
+		Expect(config.Profiles()).To(BeEmpty())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Deleting profile", "one"}, []string{"OK"}))
+	})
+
+	It("warns when the profile to delete does not exist", func() {
+		Expect(runCommand("delete", "missing")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Target profile missing does not exist."}))
+	})
+
+	Describe("list", func() {
+		It("says when there are no profiles", func() {
+			Expect(runCommand("list")).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"No target profiles found"}))
+		})
+
+		It("lists the profiles and marks the current one", func() {
+			config.SetOrganizationFields(models.OrganizationFields{Name: "org-one"})
+			config.SaveProfile("one")
+			config.SetAPIEndpoint("https://api.two.example.com")
+			config.SaveProfile("two")
+			Expect(config.UseProfile("one")).To(Succeed())
+
+			Expect(runCommand("list")).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"name", "api endpoint", "user", "org", "space"},
+				[]string{"*", "one", "https://api.one.example.com", "org-one"},
+				[]string{"two", "https://api.two.example.com"},
+			))
+		})
+	})
+})
@@ -40,6 +40,8 @@ type Data struct {
 	PluginRepos              []models.PluginRepo
 	MinCLIVersion            string
 	MinRecommendedCLIVersion string
+	Profiles                 map[string]Profile `json:",omitempty"`
+	CurrentProfile           string             `json:",omitempty"`
 }
 
 func NewData() *Data {
@@ -1,6 +1,8 @@
 package coreconfig
 
 import (
+	"fmt"
+	"os"
 	"strings"
 	"sync"
 
@@ -15,6 +17,15 @@ type ConfigRepository struct {
 	initOnce  *sync.Once
 	persistor configuration.Persistor
 	onError   func(error)
+
+	// profileOverride is the profile selected through CF_PROFILE. While it
+	// is set, data holds the target of that profile and savedTarget holds
+	// the target that is written to the config file.
+	profileOverride string
+	savedTarget     Profile
+	// discardReported is set once a change of the API endpoint made while
+	// profileOverride is set has been reported as not saved.
+	discardReported bool
 }
 
 type CCInfo struct {
@@ -45,11 +56,12 @@ func NewRepositoryFromPersistor(persistor configuration.Persistor, errorHandler
 	}
 
 	return &ConfigRepository{
-		data:      data,
-		mutex:     new(sync.RWMutex),
-		initOnce:  new(sync.Once),
-		persistor: persistor,
-		onError:   errorHandler,
+		data:            data,
+		mutex:           new(sync.RWMutex),
+		initOnce:        new(sync.Once),
+		persistor:       persistor,
+		onError:         errorHandler,
+		profileOverride: os.Getenv(ProfileEnvVar),
 	}
 }
 
@@ -91,6 +103,9 @@ type Reader interface {
 	Locale() string
 
 	PluginRepos() []models.PluginRepo
+
+	CurrentProfile() string
+	Profiles() map[string]Profile
 }
 
 //go:generate counterfeiter . ReadWriter
@@ -119,6 +134,9 @@ type ReadWriter interface {
 	SetLocale(string)
 	SetPluginRepo(models.PluginRepo)
 	UnSetPluginRepo(int)
+	SaveProfile(string)
+	UseProfile(string) error
+	DeleteProfile(string) error
 }
 
 //go:generate counterfeiter . Repository
@@ -136,6 +154,16 @@ func (c *ConfigRepository) init() {
 		if err != nil {
 			c.onError(err)
 		}
+
+		if c.profileOverride != "" {
+			profile, ok := c.data.Profiles[c.profileOverride]
+			if !ok {
+				c.onError(fmt.Errorf("Target profile %s set in %s was not found", c.profileOverride, ProfileEnvVar))
+				return
+			}
+			c.savedTarget = c.data.targetProfile()
+			c.data.setTargetProfile(profile)
+		}
 	})
 }
 
@@ -154,12 +182,48 @@ func (c *ConfigRepository) write(cb func()) {
 
 	cb()
 
-	err := c.persistor.Save(c.data)
+	err := c.persistor.Save(c.dataToSave())
 	if err != nil {
 		c.onError(err)
 	}
 }
 
+// dataToSave records the current target in the profile it belongs to and
+// returns the data to write to the config file. Once the API endpoint no
+// longer matches the profile, the target stops belonging to it. A profile
+// selected through CF_PROFILE is only used for the current invocation, so
+// such a target is not saved anywhere, which is reported once.
+func (c *ConfigRepository) dataToSave() *Data {
+	target := c.data.targetProfile()
+
+	if c.profileOverride == "" {
+		if profile, ok := c.data.Profiles[c.data.CurrentProfile]; ok {
+			if profile.Target == target.Target {
+				c.data.Profiles[c.data.CurrentProfile] = target
+			} else {
+				c.data.CurrentProfile = ""
+			}
+		}
+		return c.data
+	}
+
+	if profile, ok := c.data.Profiles[c.profileOverride]; ok {
+		if profile.Target == target.Target {
+			c.data.Profiles[c.profileOverride] = target
+			if c.data.CurrentProfile == c.profileOverride {
+				c.savedTarget = target
+			}
+		} else if !c.discardReported {
+			c.discardReported = true
+			c.onError(fmt.Errorf("Target change to %s is not saved because %s=%s points at %s", target.Target, ProfileEnvVar, c.profileOverride, profile.Target))
+		}
+	}
+
+	data := *c.data
+	data.setTargetProfile(c.savedTarget)
+	return &data
+}
+
 // CLOSERS
 
 func (c *ConfigRepository) Close() {
@@ -402,6 +466,26 @@ func (c *ConfigRepository) PluginRepos() (repos []models.PluginRepo) {
 	return
 }
 
+func (c *ConfigRepository) CurrentProfile() (name string) {
+	c.read(func() {
+		name = c.data.CurrentProfile
+		if c.profileOverride != "" {
+			name = c.profileOverride
+		}
+	})
+	return
+}
+
+func (c *ConfigRepository) Profiles() (profiles map[string]Profile) {
+	c.read(func() {
+		profiles = make(map[string]Profile, len(c.data.Profiles))
+		for name, profile := range c.data.Profiles {
+			profiles[name] = profile
+		}
+	})
+	return
+}
+
 // SETTERS
 
 func (c *ConfigRepository) ClearSession() {
@@ -538,3 +622,56 @@ func (c *ConfigRepository) UnSetPluginRepo(index int) {
 		c.data.PluginRepos = append(c.data.PluginRepos[:index], c.data.PluginRepos[index+1:]...)
 	})
 }
+
+// SaveProfile stores the current target as the named profile. Unless a
+// profile is selected through CF_PROFILE, the saved profile becomes the one
+// in use, and later changes to the target are recorded in it.
+func (c *ConfigRepository) SaveProfile(name string) {
+	c.write(func() {
+		if c.data.Profiles == nil {
+			c.data.Profiles = map[string]Profile{}
+		}
+		c.data.Profiles[name] = c.data.targetProfile()
+		if c.profileOverride == "" {
+			c.data.CurrentProfile = name
+		}
+	})
+}
+
+// UseProfile makes the named profile the current target. While a profile is
+// selected through CF_PROFILE, the change takes effect for the invocations
+// without CF_PROFILE.
+func (c *ConfigRepository) UseProfile(name string) (err error) {
+	c.write(func() {
+		profile, ok := c.data.Profiles[name]
+		if !ok {
+			err = fmt.Errorf("Target profile %s not found", name)
+			return
+		}
+
+		c.data.CurrentProfile = name
+		if c.profileOverride == "" {
+			c.data.setTargetProfile(profile)
+		} else {
+			c.savedTarget = profile
+		}
+	})
+	return
+}
+
+// DeleteProfile removes the named profile. The current target is left
+// unchanged.
+func (c *ConfigRepository) DeleteProfile(name string) (err error) {
+	c.write(func() {
+		if _, ok := c.data.Profiles[name]; !ok {
+			err = fmt.Errorf("Target profile %s not found", name)
+			return
+		}
+
+		delete(c.data.Profiles, name)
+		if c.data.CurrentProfile == name {
+			c.data.CurrentProfile = ""
+		}
+	})
+	return
+}
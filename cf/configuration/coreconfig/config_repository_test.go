@@ -193,6 +193,134 @@ var _ = Describe("Configuration Repository", func() {
 		})
 	})
 
+	Describe("target profiles", func() {
+		savedData := func() *coreconfig.Data {
+			Expect(persistor.SaveCallCount()).To(BeNumerically(">", 0))
+			return persistor.SaveArgsForCall(persistor.SaveCallCount() - 1).(*coreconfig.Data)
+		}
+
+		BeforeEach(func() {
+			config.SetAPIEndpoint("https://api.one.example.com")
+			config.SetOrganizationFields(models.OrganizationFields{Name: "org-one"})
+		})
+
+		It("saves the current target as a profile and makes it current", func() {
+			config.SaveProfile("one")
+
+			Expect(config.CurrentProfile()).To(Equal("one"))
+			Expect(config.Profiles()).To(HaveKey("one"))
+			Expect(config.Profiles()["one"].Target).To(Equal("https://api.one.example.com"))
+			Expect(config.Profiles()["one"].OrganizationFields.Name).To(Equal("org-one"))
+		})
+
+		It("records later changes to the target in the current profile", func() {
+			config.SaveProfile("one")
+			config.SetSpaceFields(models.SpaceFields{Name: "space-one"})
+
+			Expect(config.Profiles()["one"].SpaceFields.Name).To(Equal("space-one"))
+		})
+
+		It("stops recording changes in the current profile once the API endpoint changes", func() {
+			config.SaveProfile("one")
+			config.SetAPIEndpoint("https://api.two.example.com")
+
+			Expect(config.CurrentProfile()).To(BeEmpty())
+			Expect(config.Profiles()["one"].Target).To(Equal("https://api.one.example.com"))
+		})
+
+		It("switches to a saved profile", func() {
+			config.SaveProfile("one")
+			config.SetAPIEndpoint("https://api.two.example.com")
+			config.SetOrganizationFields(models.OrganizationFields{Name: "org-two"})
+			config.SaveProfile("two")
+
+			Expect(config.UseProfile("one")).To(Succeed())
+			Expect(config.CurrentProfile()).To(Equal("one"))
+			Expect(config.APIEndpoint()).To(Equal("https://api.one.example.com"))
+			Expect(config.OrganizationFields().Name).To(Equal("org-one"))
+			Expect(config.Profiles()["two"].Target).To(Equal("https://api.two.example.com"))
+		})
+
+		It("returns an error when switching to a profile that does not exist", func() {
+			Expect(config.UseProfile("missing")).NotTo(Succeed())
+			Expect(config.APIEndpoint()).To(Equal("https://api.one.example.com"))
+		})
+
+		It("deletes a profile without changing the target", func() {
+			config.SaveProfile("one")
+
+			Expect(config.DeleteProfile("one")).To(Succeed())
+			Expect(config.Profiles()).To(BeEmpty())
+			Expect(config.CurrentProfile()).To(BeEmpty())
+			Expect(config.APIEndpoint()).To(Equal("https://api.one.example.com"))
+
+			Expect(config.DeleteProfile("one")).NotTo(Succeed())
+		})
+
+		Context("when CF_PROFILE is set", func() {
+			BeforeEach(func() {
+				persistor.LoadStub = func(data configuration.DataInterface) error {
+					d := data.(*coreconfig.Data)
+					d.Target = "https://api.one.example.com"
+					d.CurrentProfile = "one"
+					d.Profiles = map[string]coreconfig.Profile{
+						"one": {Target: "https://api.one.example.com"},
+						"two": {Target: "https://api.two.example.com"},
+					}
+					return nil
+				}
+
+				os.Setenv(coreconfig.ProfileEnvVar, "two")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv(coreconfig.ProfileEnvVar)
+			})
+
+			It("targets the selected profile without switching to it", func() {
+				config = coreconfig.NewRepositoryFromPersistor(persistor, func(err error) { panic(err) })
+
+				Expect(config.CurrentProfile()).To(Equal("two"))
+				Expect(config.APIEndpoint()).To(Equal("https://api.two.example.com"))
+
+				config.SetSpaceFields(models.SpaceFields{Name: "space-two"})
+
+				data := savedData()
+				Expect(data.Target).To(Equal("https://api.one.example.com"))
+				Expect(data.CurrentProfile).To(Equal("one"))
+				Expect(data.Profiles["two"].SpaceFields.Name).To(Equal("space-two"))
+			})
+
+			It("reports once that a change of the API endpoint is not saved", func() {
+				var reportedErrs []error
+				config = coreconfig.NewRepositoryFromPersistor(persistor, func(err error) { reportedErrs = append(reportedErrs, err) })
+
+				config.SetAPIEndpoint("https://api.three.example.com")
+				config.SetAccessToken("bearer three")
+
+				Expect(reportedErrs).To(HaveLen(1))
+				Expect(reportedErrs[0]).To(MatchError("Target change to https://api.three.example.com is not saved because CF_PROFILE=two points at https://api.two.example.com"))
+				Expect(config.APIEndpoint()).To(Equal("https://api.three.example.com"))
+
+				data := savedData()
+				Expect(data.Target).To(Equal("https://api.one.example.com"))
+				Expect(data.CurrentProfile).To(Equal("one"))
+				Expect(data.Profiles["two"].Target).To(Equal("https://api.two.example.com"))
+				Expect(data.Profiles["two"].AccessToken).To(BeEmpty())
+			})
+
+			It("reports an error when the profile does not exist", func() {
+				os.Setenv(coreconfig.ProfileEnvVar, "missing")
+
+				var reportedErr error
+				config = coreconfig.NewRepositoryFromPersistor(persistor, func(err error) { reportedErr = err })
+				config.APIEndpoint()
+
+				Expect(reportedErr).To(MatchError("Target profile missing set in CF_PROFILE was not found"))
+			})
+		})
+	})
+
 	Describe("NewRepositoryFromFilepath", func() {
 		var configPath string
 
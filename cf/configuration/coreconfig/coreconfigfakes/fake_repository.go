@@ -296,9 +296,42 @@ type FakeRepository struct {
 	unSetPluginRepoArgsForCall []struct {
 		arg1 int
 	}
-	CloseStub        func()
-	closeMutex       sync.RWMutex
-	closeArgsForCall []struct{}
+	CloseStub                 func()
+	closeMutex                sync.RWMutex
+	closeArgsForCall          []struct{}
+	CurrentProfileStub        func() string
+	currentProfileMutex       sync.RWMutex
+	currentProfileArgsForCall []struct{}
+	currentProfileReturns     struct {
+		result1 string
+	}
+	ProfilesStub        func() map[string]coreconfig.Profile
+	profilesMutex       sync.RWMutex
+	profilesArgsForCall []struct{}
+	profilesReturns     struct {
+		result1 map[string]coreconfig.Profile
+	}
+	SaveProfileStub        func(arg1 string)
+	saveProfileMutex       sync.RWMutex
+	saveProfileArgsForCall []struct {
+		arg1 string
+	}
+	UseProfileStub        func(arg1 string) error
+	useProfileMutex       sync.RWMutex
+	useProfileArgsForCall []struct {
+		arg1 string
+	}
+	useProfileReturns struct {
+		result1 error
+	}
+	DeleteProfileStub        func(arg1 string) error
+	deleteProfileMutex       sync.RWMutex
+	deleteProfileArgsForCall []struct {
+		arg1 string
+	}
+	deleteProfileReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1580,6 +1613,146 @@ func (fake *FakeRepository) CloseCallCount() int {
 	return len(fake.closeArgsForCall)
 }
 
+func (fake *FakeRepository) CurrentProfile() string {
+	fake.currentProfileMutex.Lock()
+	fake.currentProfileArgsForCall = append(fake.currentProfileArgsForCall, struct{}{})
+	fake.recordInvocation("CurrentProfile", []interface{}{})
+	fake.currentProfileMutex.Unlock()
+	if fake.CurrentProfileStub != nil {
+		return fake.CurrentProfileStub()
+	} else {
+		return fake.currentProfileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CurrentProfileCallCount() int {
+	fake.currentProfileMutex.RLock()
+	defer fake.currentProfileMutex.RUnlock()
+	return len(fake.currentProfileArgsForCall)
+}
+
+func (fake *FakeRepository) CurrentProfileReturns(result1 string) {
+	fake.CurrentProfileStub = nil
+	fake.currentProfileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) Profiles() map[string]coreconfig.Profile {
+	fake.profilesMutex.Lock()
+	fake.profilesArgsForCall = append(fake.profilesArgsForCall, struct{}{})
+	fake.recordInvocation("Profiles", []interface{}{})
+	fake.profilesMutex.Unlock()
+	if fake.ProfilesStub != nil {
+		return fake.ProfilesStub()
+	} else {
+		return fake.profilesReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ProfilesCallCount() int {
+	fake.profilesMutex.RLock()
+	defer fake.profilesMutex.RUnlock()
+	return len(fake.profilesArgsForCall)
+}
+
+func (fake *FakeRepository) ProfilesReturns(result1 map[string]coreconfig.Profile) {
+	fake.ProfilesStub = nil
+	fake.profilesReturns = struct {
+		result1 map[string]coreconfig.Profile
+	}{result1}
+}
+
+func (fake *FakeRepository) SaveProfile(arg1 string) {
+	fake.saveProfileMutex.Lock()
+	fake.saveProfileArgsForCall = append(fake.saveProfileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SaveProfile", []interface{}{arg1})
+	fake.saveProfileMutex.Unlock()
+	if fake.SaveProfileStub != nil {
+		fake.SaveProfileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SaveProfileCallCount() int {
+	fake.saveProfileMutex.RLock()
+	defer fake.saveProfileMutex.RUnlock()
+	return len(fake.saveProfileArgsForCall)
+}
+
+func (fake *FakeRepository) SaveProfileArgsForCall(i int) string {
+	fake.saveProfileMutex.RLock()
+	defer fake.saveProfileMutex.RUnlock()
+	return fake.saveProfileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) UseProfile(arg1 string) error {
+	fake.useProfileMutex.Lock()
+	fake.useProfileArgsForCall = append(fake.useProfileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("UseProfile", []interface{}{arg1})
+	fake.useProfileMutex.Unlock()
+	if fake.UseProfileStub != nil {
+		return fake.UseProfileStub(arg1)
+	} else {
+		return fake.useProfileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UseProfileCallCount() int {
+	fake.useProfileMutex.RLock()
+	defer fake.useProfileMutex.RUnlock()
+	return len(fake.useProfileArgsForCall)
+}
+
+func (fake *FakeRepository) UseProfileArgsForCall(i int) string {
+	fake.useProfileMutex.RLock()
+	defer fake.useProfileMutex.RUnlock()
+	return fake.useProfileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) UseProfileReturns(result1 error) {
+	fake.UseProfileStub = nil
+	fake.useProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) DeleteProfile(arg1 string) error {
+	fake.deleteProfileMutex.Lock()
+	fake.deleteProfileArgsForCall = append(fake.deleteProfileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteProfile", []interface{}{arg1})
+	fake.deleteProfileMutex.Unlock()
+	if fake.DeleteProfileStub != nil {
+		return fake.DeleteProfileStub(arg1)
+	} else {
+		return fake.deleteProfileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) DeleteProfileCallCount() int {
+	fake.deleteProfileMutex.RLock()
+	defer fake.deleteProfileMutex.RUnlock()
+	return len(fake.deleteProfileArgsForCall)
+}
+
+func (fake *FakeRepository) DeleteProfileArgsForCall(i int) string {
+	fake.deleteProfileMutex.RLock()
+	defer fake.deleteProfileMutex.RUnlock()
+	return fake.deleteProfileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) DeleteProfileReturns(result1 error) {
+	fake.DeleteProfileStub = nil
+	fake.deleteProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1687,6 +1860,16 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.unSetPluginRepoMutex.RUnlock()
 	fake.closeMutex.RLock()
 	defer fake.closeMutex.RUnlock()
+	fake.currentProfileMutex.RLock()
+	defer fake.currentProfileMutex.RUnlock()
+	fake.profilesMutex.RLock()
+	defer fake.profilesMutex.RUnlock()
+	fake.saveProfileMutex.RLock()
+	defer fake.saveProfileMutex.RUnlock()
+	fake.useProfileMutex.RLock()
+	defer fake.useProfileMutex.RUnlock()
+	fake.deleteProfileMutex.RLock()
+	defer fake.deleteProfileMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,71 @@
+package coreconfig
+
+import "code.cloudfoundry.org/cli/cf/models"
+
+// ProfileEnvVar names the environment variable that selects a target profile
+// for a single invocation, without changing the profile in use.
+const ProfileEnvVar = "CF_PROFILE"
+
+// Profile is a named target: an API endpoint together with the tokens, SSL
+// setting and org and space used with it.
+type Profile struct {
+	Target                   string
+	APIVersion               string
+	AuthorizationEndpoint    string
+	LoggregatorEndPoint      string
+	DopplerEndPoint          string
+	UaaEndpoint              string
+	RoutingAPIEndpoint       string
+	AccessToken              string
+	SSHOAuthClient           string
+	RefreshToken             string
+	OrganizationFields       models.OrganizationFields
+	SpaceFields              models.SpaceFields
+	SSLDisabled              bool
+	MinCLIVersion            string
+	MinRecommendedCLIVersion string
+}
+
+func (p Profile) Username() string {
+	return NewTokenInfo(p.AccessToken).Username
+}
+
+// targetProfile returns the target currently held by d.
+func (d *Data) targetProfile() Profile {
+	return Profile{
+		Target:                   d.Target,
+		APIVersion:               d.APIVersion,
+		AuthorizationEndpoint:    d.AuthorizationEndpoint,
+		LoggregatorEndPoint:      d.LoggregatorEndPoint,
+		DopplerEndPoint:          d.DopplerEndPoint,
+		UaaEndpoint:              d.UaaEndpoint,
+		RoutingAPIEndpoint:       d.RoutingAPIEndpoint,
+		AccessToken:              d.AccessToken,
+		SSHOAuthClient:           d.SSHOAuthClient,
+		RefreshToken:             d.RefreshToken,
+		OrganizationFields:       d.OrganizationFields,
+		SpaceFields:              d.SpaceFields,
+		SSLDisabled:              d.SSLDisabled,
+		MinCLIVersion:            d.MinCLIVersion,
+		MinRecommendedCLIVersion: d.MinRecommendedCLIVersion,
+	}
+}
+
+// setTargetProfile makes p the target held by d.
+func (d *Data) setTargetProfile(p Profile) {
+	d.Target = p.Target
+	d.APIVersion = p.APIVersion
+	d.AuthorizationEndpoint = p.AuthorizationEndpoint
+	d.LoggregatorEndPoint = p.LoggregatorEndPoint
+	d.DopplerEndPoint = p.DopplerEndPoint
+	d.UaaEndpoint = p.UaaEndpoint
+	d.RoutingAPIEndpoint = p.RoutingAPIEndpoint
+	d.AccessToken = p.AccessToken
+	d.SSHOAuthClient = p.SSHOAuthClient
+	d.RefreshToken = p.RefreshToken
+	d.OrganizationFields = p.OrganizationFields
+	d.SpaceFields = p.SpaceFields
+	d.SSLDisabled = p.SSLDisabled
+	d.MinCLIVersion = p.MinCLIVersion
+	d.MinRecommendedCLIVersion = p.MinRecommendedCLIVersion
+}
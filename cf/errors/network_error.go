@@ -0,0 +1,15 @@
+package errors
+
+// NetworkError is returned when a request fails without a response from the
+// server, e.g. because the connection was refused or reset.
+type NetworkError struct {
+	message string
+}
+
+func NewNetworkError(message string) *NetworkError {
+	return &NetworkError{message: message}
+}
+
+func (err *NetworkError) Error() string {
+	return err.message
+}
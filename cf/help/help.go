@@ -116,6 +116,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("logout"),
 					presentCommand("passwd"),
 					presentCommand("target"),
+					presentCommand("target-profile"),
 				}, {
 					presentCommand("api"),
 					presentCommand("auth"),
@@ -130,6 +131,7 @@ func newAppPresenter() appPresenter {
 				}, {
 					presentCommand("push"),
 					presentCommand("scale"),
+					presentCommand("autoscale"),
 					presentCommand("delete"),
 					presentCommand("rename"),
 				}, {
@@ -153,6 +155,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("copy-source"),
 				}, {
 					presentCommand("create-app-manifest"),
+					presentCommand("validate-manifest"),
 				}, {
 					presentCommand("get-health-check"),
 					presentCommand("set-health-check"),
@@ -174,6 +177,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("update-service"),
 					presentCommand("delete-service"),
 					presentCommand("rename-service"),
+					presentCommand("wait-for-service"),
 				}, {
 					presentCommand("create-service-key"),
 					presentCommand("service-keys"),
@@ -200,6 +204,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("create-org"),
 					presentCommand("delete-org"),
 					presentCommand("rename-org"),
+					presentCommand("apply-org-config"),
 				},
 			},
 		}, {
@@ -212,6 +217,8 @@ func newAppPresenter() appPresenter {
 					presentCommand("create-space"),
 					presentCommand("delete-space"),
 					presentCommand("rename-space"),
+					presentCommand("export-space"),
+					presentCommand("import-space"),
 				}, {
 					presentCommand("allow-space-ssh"),
 					presentCommand("disallow-space-ssh"),
@@ -337,6 +344,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("delete-security-group"),
 					presentCommand("bind-security-group"),
 					presentCommand("unbind-security-group"),
+					presentCommand("security-group-check"),
 				}, {
 					presentCommand("bind-staging-security-group"),
 					presentCommand("staging-security-groups"),
@@ -394,6 +402,7 @@ func newAppPresenter() appPresenter {
 				{
 					presentCommand("plugins"),
 					presentCommand("install-plugin"),
+					presentCommand("update-plugins"),
 					presentCommand("uninstall-plugin"),
 				},
 			},
@@ -24,10 +24,12 @@ func GetHelpTemplate() string {
    CF_STARTUP_TIMEOUT=5               ` + T("Max wait time for app instance startup, in minutes") + `
    CF_TRACE=true                      ` + T("Print API request diagnostics to stdout") + `
    CF_TRACE=path/to/trace.log         ` + T("Append API request diagnostics to a log file") + `
+   CF_TRACE=path/to/trace.har         ` + T("Record API requests in an HTTP Archive (HAR) file") + `
    https_proxy=proxy.example.com:8080 ` + T("Enable HTTP proxying for API requests") + `
 
 {{.Title "` + T("GLOBAL OPTIONS:") + `"}}
    --help, -h                         ` + T("Show help") + `
    -v                                 ` + T("Print API request diagnostics to stdout") + `
+   --output json|yaml                 ` + T("Print tables as structured records instead of columns") + `
 `
 }
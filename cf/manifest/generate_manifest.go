@@ -3,6 +3,7 @@ package manifest
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"code.cloudfoundry.org/cli/cf/models"
 
@@ -245,3 +246,73 @@ func (m *appManifest) addApplication(name string) {
 		},
 	})
 }
+
+// GenerateManifest adds the current settings of app, which must have its
+// stack set, to the manifest held by generator.
+func GenerateManifest(generator App, app models.Application) error {
+	generator.Memory(app.Name, app.Memory)
+	generator.Instances(app.Name, app.InstanceCount)
+	generator.Stack(app.Name, app.Stack.Name)
+
+	if len(app.AppPorts) > 0 {
+		generator.AppPorts(app.Name, app.AppPorts)
+	}
+
+	if app.Command != "" {
+		generator.StartCommand(app.Name, app.Command)
+	}
+
+	if app.BuildpackURL != "" {
+		generator.BuildpackURL(app.Name, app.BuildpackURL)
+	}
+
+	if len(app.Services) > 0 {
+		for _, service := range app.Services {
+			generator.Service(app.Name, service.Name)
+		}
+	}
+
+	if app.HealthCheckTimeout > 0 {
+		generator.HealthCheckTimeout(app.Name, app.HealthCheckTimeout)
+	}
+
+	if len(app.EnvironmentVars) > 0 {
+		sorted := sortEnvVar(app.EnvironmentVars)
+		for _, envVarKey := range sorted {
+			switch app.EnvironmentVars[envVarKey].(type) {
+			default:
+				return errors.New(T("Failed to create manifest, unable to parse environment variable: ") + envVarKey)
+			case float64:
+				//json.Unmarshal turn all numbers to float64
+				value := int(app.EnvironmentVars[envVarKey].(float64))
+				generator.EnvironmentVars(app.Name, envVarKey, fmt.Sprintf("%d", value))
+			case bool:
+				generator.EnvironmentVars(app.Name, envVarKey, fmt.Sprintf("%t", app.EnvironmentVars[envVarKey].(bool)))
+			case string:
+				generator.EnvironmentVars(app.Name, envVarKey, app.EnvironmentVars[envVarKey].(string))
+			}
+		}
+	}
+
+	if len(app.Routes) > 0 {
+		for i := 0; i < len(app.Routes); i++ {
+			generator.Route(app.Name, app.Routes[i].Host, app.Routes[i].Domain.Name, app.Routes[i].Path, app.Routes[i].Port)
+		}
+	}
+
+	if app.DiskQuota != 0 {
+		generator.DiskQuota(app.Name, app.DiskQuota)
+	}
+
+	return nil
+}
+
+func sortEnvVar(vars map[string]interface{}) []string {
+	var varsAry []string
+	for k := range vars {
+		varsAry = append(varsAry, k)
+	}
+	sort.Strings(varsAry)
+
+	return varsAry
+}
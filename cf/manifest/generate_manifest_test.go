@@ -6,6 +6,7 @@ import (
 	"bytes"
 
 	. "code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -304,6 +305,58 @@ var _ = Describe("generate_manifest", func() {
 			Expect(err.Error()).To(Equal("Error saving manifest: required attribute 'instances' missing"))
 		})
 	})
+
+	Describe("GenerateManifest", func() {
+		var (
+			m   App
+			f   *bytes.Buffer
+			app models.Application
+		)
+
+		BeforeEach(func() {
+			m = NewGenerator()
+			f = &bytes.Buffer{}
+
+			app = models.Application{}
+			app.Name = "app1"
+			app.Memory = 256
+			app.DiskQuota = 512
+			app.InstanceCount = 2
+			app.Stack = &models.Stack{Name: "cflinuxfs2"}
+			app.Command = "run.sh"
+			app.BuildpackURL = "ruby_buildpack"
+			app.HealthCheckTimeout = 90
+			app.EnvironmentVars = map[string]interface{}{"COUNT": float64(3), "DEBUG": true, "NAME": "value"}
+			app.Services = []models.ServicePlanSummary{{Name: "db"}}
+			app.Routes = []models.RouteSummary{{Host: "app1", Domain: models.DomainFields{Name: "example.com"}, Path: "/api"}}
+		})
+
+		It("adds the settings of the app", func() {
+			err := GenerateManifest(m, app)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Save(f)).To(Succeed())
+
+			application := getYaml(f).Applications[0]
+			Expect(application.Name).To(Equal("app1"))
+			Expect(application.Memory).To(Equal("256M"))
+			Expect(application.DiskQuota).To(Equal("512M"))
+			Expect(application.Instances).To(Equal(2))
+			Expect(application.Stack).To(Equal("cflinuxfs2"))
+			Expect(application.Command).To(Equal("run.sh"))
+			Expect(application.Buildpack).To(Equal("ruby_buildpack"))
+			Expect(application.Timeout).To(Equal(90))
+			Expect(application.Env).To(Equal(map[string]interface{}{"COUNT": "3", "DEBUG": "true", "NAME": "value"}))
+			Expect(application.Services).To(Equal([]string{"db"}))
+			Expect(application.Routes).To(Equal([]map[string]string{{"route": "app1.example.com/api"}}))
+		})
+
+		It("returns an error for an environment variable it cannot write", func() {
+			app.EnvironmentVars = map[string]interface{}{"NESTED": map[string]interface{}{}}
+
+			err := GenerateManifest(m, app)
+			Expect(err).To(MatchError("Failed to create manifest, unable to parse environment variable: NESTED"))
+		})
+	})
 })
 
 type YManifest struct {
@@ -17,8 +17,12 @@ import (
 )
 
 type Manifest struct {
-	Path string
-	Data generic.Map
+	Path      string
+	Data      generic.Map
+	Variables Variables
+
+	// files lists the manifest file followed by the files it inherits from.
+	files []string
 }
 
 func NewEmptyManifest() (m *Manifest) {
@@ -26,7 +30,12 @@ func NewEmptyManifest() (m *Manifest) {
 }
 
 func (m Manifest) Applications() ([]models.AppParams, error) {
-	rawData, err := expandProperties(m.Data, generator.NewWordGenerator())
+	interpolatedData, err := interpolateVariables(m.Data, m.Variables)
+	if err != nil {
+		return []models.AppParams{}, err
+	}
+
+	rawData, err := expandProperties(interpolatedData, generator.NewWordGenerator())
 	if err != nil {
 		return []models.AppParams{}, err
 	}
@@ -170,6 +179,19 @@ func mapToAppParams(basePath string, yamlMap generic.Map) (models.AppParams, err
 		return models.AppParams{}, err
 	}
 
+	appParams, errs := parseAppParams(basePath, yamlMap)
+	if len(errs) > 0 {
+		message := ""
+		for _, err := range errs {
+			message = message + fmt.Sprintf("%s\n", err.Error())
+		}
+		return models.AppParams{}, errors.New(message)
+	}
+
+	return appParams, nil
+}
+
+func parseAppParams(basePath string, yamlMap generic.Map) (models.AppParams, []error) {
 	var appParams models.AppParams
 	var errs []error
 	appParams.BuildpackURL = stringValOrDefault(yamlMap, "buildpack", &errs)
@@ -217,15 +239,7 @@ func mapToAppParams(basePath string, yamlMap generic.Map) (models.AppParams, err
 		appParams.Path = &path
 	}
 
-	if len(errs) > 0 {
-		message := ""
-		for _, err := range errs {
-			message = message + fmt.Sprintf("%s\n", err.Error())
-		}
-		return models.AppParams{}, errors.New(message)
-	}
-
-	return appParams, nil
+	return appParams, errs
 }
 
 func removeDuplicatedValue(ary []string) []string {
@@ -35,7 +35,7 @@ func (repo DiskRepository) ReadManifest(inputPath string) (*Manifest, error) {
 
 	m.Path = manifestPath
 
-	mapp, err := repo.readAllYAMLFiles(manifestPath)
+	mapp, err := repo.readAllYAMLFiles(manifestPath, &m.files)
 	if err != nil {
 		return m, err
 	}
@@ -45,13 +45,15 @@ func (repo DiskRepository) ReadManifest(inputPath string) (*Manifest, error) {
 	return m, nil
 }
 
-func (repo DiskRepository) readAllYAMLFiles(path string) (mergedMap generic.Map, err error) {
+func (repo DiskRepository) readAllYAMLFiles(path string, files *[]string) (mergedMap generic.Map, err error) {
 	file, err := os.Open(filepath.Clean(path))
 	if err != nil {
 		return
 	}
 	defer file.Close()
 
+	*files = append(*files, path)
+
 	mapp, err := parseManifest(file)
 	if err != nil {
 		return
@@ -72,7 +74,7 @@ func (repo DiskRepository) readAllYAMLFiles(path string) (mergedMap generic.Map,
 		inheritedPath = filepath.Join(filepath.Dir(path), inheritedPath)
 	}
 
-	inheritedMap, err := repo.readAllYAMLFiles(inheritedPath)
+	inheritedMap, err := repo.readAllYAMLFiles(inheritedPath, files)
 	if err != nil {
 		return
 	}
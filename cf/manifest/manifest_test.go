@@ -342,6 +342,53 @@ var _ = Describe("Manifests", func() {
 		})
 	})
 
+	Context("variables", func() {
+		var m *manifest.Manifest
+
+		BeforeEach(func() {
+			m = NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"name":      "((name))",
+						"instances": "((instances))",
+						"host":      "((name))-((env))",
+						"env": generic.NewMap(map[interface{}]interface{}{
+							"GREETING": "hello from ((env))",
+						}),
+					}),
+				},
+			}))
+		})
+
+		It("replaces the variables with their values", func() {
+			m.Variables = manifest.Variables{"name": "my-app", "instances": 3, "env": "staging"}
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].Name).To(Equal("my-app"))
+			Expect(*apps[0].InstanceCount).To(Equal(3))
+			Expect(apps[0].Hosts).To(Equal([]string{"my-app-staging"}))
+			Expect((*apps[0].EnvironmentVars)["GREETING"]).To(Equal("hello from staging"))
+		})
+
+		It("accepts the string values given with --var", func() {
+			vars, err := manifest.ParseVariables([]string{"name=my-app", "instances=3", "env=staging"})
+			Expect(err).NotTo(HaveOccurred())
+			m.Variables = vars
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].InstanceCount).To(Equal(3))
+		})
+
+		It("returns an error listing every missing variable", func() {
+			m.Variables = manifest.Variables{"name": "my-app"}
+
+			_, err := m.Applications()
+			Expect(err).To(MatchError("Expected to find variables: env, instances"))
+		})
+	})
+
 	It("sets the command and buildpack to blank when their values are null in the manifest", func() {
 		m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
 			"applications": []interface{}{
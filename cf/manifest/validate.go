@@ -0,0 +1,281 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/utils/generic"
+	"code.cloudfoundry.org/cli/utils/words/generator"
+)
+
+// ValidationError is a problem found in a manifest, located at the file and
+// line it was found in. Line is 0 when the location is not known and AppName
+// is empty for problems outside of an application.
+type ValidationError struct {
+	Path    string
+	Line    int
+	AppName string
+	Err     error
+}
+
+func (e ValidationError) Error() string {
+	location := e.Path
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d", e.Path, e.Line)
+	}
+
+	if e.AppName == "" {
+		return fmt.Sprintf("%s: %s", location, e.Err.Error())
+	}
+	return fmt.Sprintf("%s: %s: %s", location, e.AppName, e.Err.Error())
+}
+
+// AppValidator checks the parameters of a single application, e.g.
+// PushActor.ValidateAppParams.
+type AppValidator func(models.AppParams) []error
+
+var knownKeys = map[string]bool{
+	"app-ports":         true,
+	"buildpack":         true,
+	"command":           true,
+	"disk_quota":        true,
+	"domain":            true,
+	"domains":           true,
+	"env":               true,
+	"health-check-type": true,
+	"host":              true,
+	"hosts":             true,
+	"instances":         true,
+	"memory":            true,
+	"name":              true,
+	"no-hostname":       true,
+	"no-route":          true,
+	"path":              true,
+	"random-route":      true,
+	"routes":            true,
+	"services":          true,
+	"stack":             true,
+	"timeout":           true,
+}
+
+// Validate runs every check that pushing the manifest would run and reports
+// all problems instead of stopping at the first one. It also reports keys
+// that push would silently ignore.
+func (m Manifest) Validate(validators ...AppValidator) []ValidationError {
+	locator := newLineLocator(m.Path, m.files)
+
+	interpolatedData, err := interpolateVariables(m.Data, m.Variables)
+	if err != nil {
+		return []ValidationError{{Path: m.Path, Err: err}}
+	}
+
+	rawData, err := expandProperties(interpolatedData, generator.NewWordGenerator())
+	if err != nil {
+		return []ValidationError{{Path: m.Path, Err: err}}
+	}
+	data := generic.NewMap(rawData)
+
+	var errs []ValidationError
+	generic.Each(data, func(key, _ interface{}) {
+		name := fmt.Sprint(key)
+		if !knownKeys[name] && name != "applications" && name != "inherit" {
+			path, line := locator.locate("", name)
+			errs = append(errs, ValidationError{Path: path, Line: line, Err: unknownKeyError(name)})
+		}
+	})
+
+	appMaps, err := m.getAppMaps(data)
+	if err != nil {
+		return append(errs, ValidationError{Path: m.Path, Err: err})
+	}
+
+	// the applications of inherited manifests are part of the merged list,
+	// so their keys are checked as well
+	var ownAppMaps []generic.Map
+	if appsData, ok := data.Get("applications").([]interface{}); ok {
+		for _, appData := range appsData {
+			ownAppMaps = append(ownAppMaps, generic.NewMap(appData))
+		}
+	}
+
+	for i, appMap := range appMaps {
+		appName, _ := appMap.Get("name").(string)
+		appError := func(key string, err error) ValidationError {
+			path, line := locator.locate(appName, key)
+			return ValidationError{Path: path, Line: line, AppName: appName, Err: err}
+		}
+
+		if i < len(ownAppMaps) {
+			generic.Each(ownAppMaps[i], func(key, _ interface{}) {
+				name := fmt.Sprint(key)
+				if !knownKeys[name] && name != "inherit" {
+					errs = append(errs, appError(name, unknownKeyError(name)))
+				}
+			})
+		}
+
+		hasNulls := false
+		generic.Each(appMap, func(key, value interface{}) {
+			if value == nil && key != "command" && key != "buildpack" {
+				hasNulls = true
+				errs = append(errs, appError(fmt.Sprint(key), errors.New(T("{{.PropertyName}} should not be null", map[string]interface{}{"PropertyName": key}))))
+			}
+		})
+		if hasNulls {
+			continue
+		}
+
+		appParams, paramErrs := parseAppParams(filepath.Dir(m.Path), appMap)
+		for _, err := range paramErrs {
+			errs = append(errs, appError("", err))
+		}
+		if len(paramErrs) > 0 {
+			continue
+		}
+
+		for _, validator := range validators {
+			for _, err := range validator(appParams) {
+				errs = append(errs, appError("", err))
+			}
+		}
+	}
+
+	sort.Sort(byLocation(errs))
+	return errs
+}
+
+// byLocation orders validation errors by file and line, so that they are
+// reported the same way on every run even though they are found while
+// iterating over maps.
+type byLocation []ValidationError
+
+func (errs byLocation) Len() int      { return len(errs) }
+func (errs byLocation) Swap(i, j int) { errs[i], errs[j] = errs[j], errs[i] }
+func (errs byLocation) Less(i, j int) bool {
+	switch {
+	case errs[i].Path != errs[j].Path:
+		return errs[i].Path < errs[j].Path
+	case errs[i].Line != errs[j].Line:
+		return errs[i].Line < errs[j].Line
+	case errs[i].AppName != errs[j].AppName:
+		return errs[i].AppName < errs[j].AppName
+	default:
+		return errs[i].Err.Error() < errs[j].Err.Error()
+	}
+}
+
+func unknownKeyError(key string) error {
+	return errors.New(T("Unknown key '{{.Key}}' is ignored", map[string]interface{}{"Key": key}))
+}
+
+// lineLocator finds the lines of keys in the manifest files by scanning their
+// text, as the parsed YAML does not keep track of positions.
+type lineLocator struct {
+	path  string
+	files []string
+	lines map[string][]string
+}
+
+func newLineLocator(path string, files []string) lineLocator {
+	if len(files) == 0 && path != "" {
+		files = []string{path}
+	}
+
+	lines := map[string][]string{}
+	for _, file := range files {
+		contents, err := ioutil.ReadFile(filepath.Clean(file))
+		if err != nil {
+			continue
+		}
+		lines[file] = strings.Split(string(contents), "\n")
+	}
+
+	return lineLocator{path: path, files: files, lines: lines}
+}
+
+// locate returns the file and line of the key within the given application,
+// of the application itself when key is empty, or of a top level key when
+// appName is empty. Keys not found within the application are looked up at
+// the top level, where global properties are declared.
+func (l lineLocator) locate(appName string, key string) (string, int) {
+	for _, file := range l.files {
+		lines := l.lines[file]
+
+		if appName != "" {
+			appLine, indent := findAppLine(lines, appName)
+			if appLine < 0 {
+				continue
+			}
+			if key == "" {
+				return file, appLine + 1
+			}
+
+			keyRegex := regexp.MustCompile(`^\s*(-\s+)?` + regexp.QuoteMeta(key) + `\s*:`)
+			for i := appLine; i < len(lines); i++ {
+				if i > appLine && endsListItem(lines[i], indent) {
+					break
+				}
+				if keyRegex.MatchString(lines[i]) {
+					return file, i + 1
+				}
+			}
+		}
+	}
+
+	if key != "" {
+		keyRegex := regexp.MustCompile(`^` + regexp.QuoteMeta(key) + `\s*:`)
+		for _, file := range l.files {
+			for i, line := range l.lines[file] {
+				if keyRegex.MatchString(line) {
+					return file, i + 1
+				}
+			}
+		}
+	}
+
+	return l.path, 0
+}
+
+var appNameRegex = regexp.MustCompile(`^(\s*)(-\s+)?name\s*:\s*["']?([^"'#]*?)["']?\s*(#.*)?$`)
+
+// findAppLine returns the index of the line declaring the application's name
+// and the indentation of the list item holding the application.
+func findAppLine(lines []string, appName string) (int, int) {
+	for i, line := range lines {
+		match := appNameRegex.FindStringSubmatch(line)
+		if match == nil || match[3] != appName {
+			continue
+		}
+
+		if match[2] != "" {
+			return i, len(match[1])
+		}
+
+		for j := i - 1; j >= 0; j-- {
+			trimmed := strings.TrimLeft(lines[j], " ")
+			if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+				return i, len(lines[j]) - len(trimmed)
+			}
+		}
+		return i, len(match[1])
+	}
+
+	return -1, 0
+}
+
+// endsListItem returns true for lines that end the list item indented by
+// indent, i.e. the next item or anything indented less.
+func endsListItem(line string, indent int) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return false
+	}
+	return len(line)-len(trimmed) <= indent
+}
@@ -0,0 +1,147 @@
+package manifest_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate", func() {
+	var (
+		dir          string
+		manifestPath string
+		parentPath   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "validate-manifest")
+		Expect(err).NotTo(HaveOccurred())
+
+		parentPath = filepath.Join(dir, "parent.yml")
+		err = ioutil.WriteFile(parentPath, []byte(`---
+memory: 256M
+insatnces: 2
+`), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		manifestPath = filepath.Join(dir, "manifest.yml")
+		err = ioutil.WriteFile(manifestPath, []byte(`---
+inherit: parent.yml
+applications:
+- name: good-app
+  instances: 1
+- name: bad-app
+  instances: many
+  helth-check-type: port
+- name: null-app
+  stack: ~
+`), 0600)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("reports every problem with its file, line and application", func() {
+		m, err := manifest.NewDiskRepository().ReadManifest(manifestPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		problems := m.Validate()
+		Expect(problems).To(HaveLen(4))
+
+		Expect(problems[0].Path).To(Equal(manifestPath))
+		Expect(problems[0].Line).To(Equal(6))
+		Expect(problems[0].AppName).To(Equal("bad-app"))
+		Expect(problems[0].Err.Error()).To(ContainSubstring("many"))
+
+		Expect(problems[1].Path).To(Equal(manifestPath))
+		Expect(problems[1].Line).To(Equal(8))
+		Expect(problems[1].AppName).To(Equal("bad-app"))
+		Expect(problems[1].Err).To(MatchError("Unknown key 'helth-check-type' is ignored"))
+
+		Expect(problems[2].Path).To(Equal(manifestPath))
+		Expect(problems[2].Line).To(Equal(10))
+		Expect(problems[2].AppName).To(Equal("null-app"))
+		Expect(problems[2].Err).To(MatchError("stack should not be null"))
+
+		Expect(problems[3].Path).To(Equal(parentPath))
+		Expect(problems[3].Line).To(Equal(3))
+		Expect(problems[3].AppName).To(BeEmpty())
+		Expect(problems[3].Err).To(MatchError("Unknown key 'insatnces' is ignored"))
+
+		Expect(problems[1].Error()).To(Equal(manifestPath + ":8: bad-app: Unknown key 'helth-check-type' is ignored"))
+	})
+
+	It("reports unknown keys of the applications in inherited manifests", func() {
+		err := ioutil.WriteFile(parentPath, []byte(`---
+applications:
+- name: parent-app
+  tiemout: 10
+`), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		m, err := manifest.NewDiskRepository().ReadManifest(manifestPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		problems := m.Validate()
+		Expect(problems).To(ContainElement(manifest.ValidationError{
+			Path:    parentPath,
+			Line:    4,
+			AppName: "parent-app",
+			Err:     errors.New("Unknown key 'tiemout' is ignored"),
+		}))
+	})
+
+	It("reports the problems in the same order on every run", func() {
+		err := ioutil.WriteFile(manifestPath, []byte(`---
+applications:
+- name: null-app
+  stack: ~
+  memory: ~
+  disk_quota: ~
+  instances: ~
+  tiemout: 10
+  helth-check-type: port
+`), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		m, err := manifest.NewDiskRepository().ReadManifest(manifestPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		first := m.Validate()
+		Expect(first).To(HaveLen(6))
+		for i := 0; i < 10; i++ {
+			Expect(m.Validate()).To(Equal(first))
+		}
+		for i := 1; i < len(first); i++ {
+			Expect(first[i-1].Line).To(BeNumerically("<=", first[i].Line))
+		}
+	})
+
+	It("runs the given validators on every valid application", func() {
+		m, err := manifest.NewDiskRepository().ReadManifest(manifestPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		var validated []string
+		problems := m.Validate(func(app models.AppParams) []error {
+			validated = append(validated, *app.Name)
+			return []error{errors.New("invalid")}
+		})
+
+		Expect(validated).To(Equal([]string{"good-app"}))
+		Expect(problems).To(ContainElement(manifest.ValidationError{
+			Path:    manifestPath,
+			Line:    4,
+			AppName: "good-app",
+			Err:     errors.New("invalid"),
+		}))
+	})
+})
@@ -0,0 +1,141 @@
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/utils/generic"
+	"gopkg.in/yaml.v2"
+)
+
+// Variables holds the values substituted for ((placeholder)) variables in a
+// manifest.
+type Variables map[string]interface{}
+
+var variableRegex = regexp.MustCompile(`\(\(([\w-]+)\)\)`)
+
+// ParseVariables parses variables given as KEY=VALUE pairs. Values are kept
+// as the literal string given, so that '1.10' or 'on' are not read as a number
+// or a boolean.
+func ParseVariables(pairs []string) (Variables, error) {
+	vars := Variables{}
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.New(T("Invalid variable '{{.Variable}}'. Expected KEY=VALUE",
+				map[string]interface{}{"Variable": pair}))
+		}
+
+		vars[parts[0]] = parts[1]
+	}
+
+	return vars, nil
+}
+
+// ReadVariablesFiles reads variables from YAML files. Files given later
+// override the values of files given earlier.
+func ReadVariablesFiles(paths []string) (Variables, error) {
+	vars := Variables{}
+	for _, path := range paths {
+		contents, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+
+		fileVars := map[string]interface{}{}
+		err = yaml.Unmarshal(contents, &fileVars)
+		if err != nil {
+			return nil, errors.New(T("Invalid variables file {{.Path}}: {{.Err}}",
+				map[string]interface{}{"Path": path, "Err": err.Error()}))
+		}
+
+		for key, value := range fileVars {
+			vars[key] = value
+		}
+	}
+
+	return vars, nil
+}
+
+// Merge returns the variables of both sets, preferring the values of other.
+func (vars Variables) Merge(other Variables) Variables {
+	merged := Variables{}
+	for key, value := range vars {
+		merged[key] = value
+	}
+	for key, value := range other {
+		merged[key] = value
+	}
+	return merged
+}
+
+// interpolateVariables replaces every ((placeholder)) in the values of input.
+// A value consisting only of a placeholder takes the variable's value as is;
+// placeholders embedded in a longer string are replaced by its text.
+func interpolateVariables(input interface{}, vars Variables) (interface{}, error) {
+	missing := map[string]bool{}
+	output := interpolate(input, vars, missing)
+
+	if len(missing) > 0 {
+		var names []string
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, errors.New(T("Expected to find variables: {{.VariableNames}}",
+			map[string]interface{}{"VariableNames": strings.Join(names, ", ")}))
+	}
+
+	return output, nil
+}
+
+func interpolate(input interface{}, vars Variables, missing map[string]bool) interface{} {
+	switch input := input.(type) {
+	case string:
+		if match := variableRegex.FindStringSubmatch(input); match != nil && match[0] == input {
+			value, ok := vars[match[1]]
+			if !ok {
+				missing[match[1]] = true
+				return input
+			}
+			return value
+		}
+
+		return variableRegex.ReplaceAllStringFunc(input, func(placeholder string) string {
+			name := variableRegex.FindStringSubmatch(placeholder)[1]
+			value, ok := vars[name]
+			if !ok {
+				missing[name] = true
+				return placeholder
+			}
+			return fmt.Sprint(value)
+		})
+	case []interface{}:
+		outputSlice := make([]interface{}, len(input))
+		for index, item := range input {
+			outputSlice[index] = interpolate(item, vars, missing)
+		}
+		return outputSlice
+	case map[interface{}]interface{}:
+		outputMap := make(map[interface{}]interface{})
+		for key, value := range input {
+			outputMap[key] = interpolate(value, vars, missing)
+		}
+		return outputMap
+	case generic.Map:
+		outputMap := generic.NewMap()
+		generic.Each(input, func(key, value interface{}) {
+			outputMap.Set(key, interpolate(value, vars, missing))
+		})
+		return outputMap
+	default:
+		return input
+	}
+}
@@ -0,0 +1,88 @@
+package manifest_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/manifest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Variables", func() {
+	Describe("ParseVariables", func() {
+		It("parses KEY=VALUE pairs", func() {
+			vars, err := manifest.ParseVariables([]string{"name=my-app", "instances=2", "no-route=true", "command=a=b"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vars).To(Equal(manifest.Variables{
+				"name":      "my-app",
+				"instances": "2",
+				"no-route":  "true",
+				"command":   "a=b",
+			}))
+		})
+
+		It("keeps the values as literal strings", func() {
+			vars, err := manifest.ParseVariables([]string{"version=1.10", "feature=on", "mask=0x1F", "list=[a, b]", "map=a: b"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vars).To(Equal(manifest.Variables{
+				"version": "1.10",
+				"feature": "on",
+				"mask":    "0x1F",
+				"list":    "[a, b]",
+				"map":     "a: b",
+			}))
+		})
+
+		It("returns an error when a pair has no value", func() {
+			_, err := manifest.ParseVariables([]string{"name"})
+			Expect(err).To(MatchError("Invalid variable 'name'. Expected KEY=VALUE"))
+		})
+	})
+
+	Describe("ReadVariablesFiles", func() {
+		var paths []string
+
+		BeforeEach(func() {
+			paths = nil
+			for _, contents := range []string{"name: my-app\nmemory: 256M\n", "memory: 1G\n"} {
+				file, err := ioutil.TempFile("", "vars-file")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = file.WriteString(contents)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(file.Close()).To(Succeed())
+				paths = append(paths, file.Name())
+			}
+		})
+
+		AfterEach(func() {
+			for _, path := range paths {
+				os.Remove(path)
+			}
+		})
+
+		It("merges the files, preferring later files", func() {
+			vars, err := manifest.ReadVariablesFiles(paths)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vars).To(Equal(manifest.Variables{
+				"name":   "my-app",
+				"memory": "1G",
+			}))
+		})
+
+		It("returns an error when a file does not exist", func() {
+			_, err := manifest.ReadVariablesFiles([]string{"/does/not/exist.yml"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Merge", func() {
+		It("prefers the values of the given variables", func() {
+			vars := manifest.Variables{"a": "1", "b": "2"}.Merge(manifest.Variables{"b": "3"})
+			Expect(vars).To(Equal(manifest.Variables{"a": "1", "b": "3"}))
+		})
+	})
+})
@@ -5,14 +5,14 @@ type SecurityGroupFields struct {
 	Name     string
 	GUID     string
 	SpaceURL string `json:"spaces_url,omitempty"`
-	Rules    []map[string]interface{}
+	Rules    []SecurityGroupRule
 }
 
 // represents the JSON that we send up to CC when the user creates / updates a record
 type SecurityGroupParams struct {
-	Name  string                   `json:"name,omitempty"`
-	GUID  string                   `json:"guid,omitempty"`
-	Rules []map[string]interface{} `json:"rules"`
+	Name  string              `json:"name,omitempty"`
+	GUID  string              `json:"guid,omitempty"`
+	Rules []SecurityGroupRule `json:"rules"`
 }
 
 // represents a fully instantiated model returned by the CC (e.g.: with its attributes and the fields for its child objects)
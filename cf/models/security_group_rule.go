@@ -0,0 +1,264 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+// Protocols of a security group rule
+const (
+	SecurityGroupProtocolTCP  = "tcp"
+	SecurityGroupProtocolUDP  = "udp"
+	SecurityGroupProtocolICMP = "icmp"
+	SecurityGroupProtocolAll  = "all"
+)
+
+// SecurityGroupRule is one rule of a security group. Ports is only used by
+// the tcp and udp protocols, Type and Code only by icmp, where -1 stands
+// for any type or code.
+type SecurityGroupRule struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports,omitempty"`
+	Type        *int   `json:"type,omitempty"`
+	Code        *int   `json:"code,omitempty"`
+	Log         bool   `json:"log,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Validate returns a message for every problem with the rule, or nothing
+// when the rule is valid.
+func (rule SecurityGroupRule) Validate() []string {
+	problems := []string{}
+
+	switch rule.Protocol {
+	case "":
+		problems = append(problems, T("protocol is missing"))
+	case SecurityGroupProtocolTCP, SecurityGroupProtocolUDP, SecurityGroupProtocolICMP, SecurityGroupProtocolAll:
+	default:
+		problems = append(problems, T("protocol {{.Protocol}} is not one of tcp, udp, icmp or all",
+			map[string]interface{}{"Protocol": strconv.Quote(rule.Protocol)}))
+	}
+
+	if rule.Destination == "" {
+		problems = append(problems, T("destination is missing"))
+	} else if problem := validateDestination(rule.Destination); problem != "" {
+		problems = append(problems, problem)
+	}
+
+	switch rule.Protocol {
+	case SecurityGroupProtocolTCP, SecurityGroupProtocolUDP:
+		if rule.Ports == "" {
+			problems = append(problems, T("ports are required for protocol {{.Protocol}}",
+				map[string]interface{}{"Protocol": rule.Protocol}))
+		} else {
+			problems = append(problems, validatePorts(rule.Ports)...)
+		}
+	case SecurityGroupProtocolICMP, SecurityGroupProtocolAll:
+		if rule.Ports != "" {
+			problems = append(problems, T("ports cannot be used with protocol {{.Protocol}}",
+				map[string]interface{}{"Protocol": rule.Protocol}))
+		}
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *int
+	}{{"type", rule.Type}, {"code", rule.Code}} {
+		switch {
+		case rule.Protocol == SecurityGroupProtocolICMP && field.value == nil:
+			problems = append(problems, T("{{.Field}} is required for protocol icmp",
+				map[string]interface{}{"Field": field.name}))
+		case rule.Protocol == SecurityGroupProtocolICMP && (*field.value < -1 || *field.value > 255):
+			problems = append(problems, T("{{.Field}} {{.Value}} is not between -1 and 255",
+				map[string]interface{}{"Field": field.name, "Value": *field.value}))
+		case rule.Protocol != SecurityGroupProtocolICMP && rule.Protocol != "" && field.value != nil:
+			problems = append(problems, T("{{.Field}} can only be used with protocol icmp",
+				map[string]interface{}{"Field": field.name}))
+		}
+	}
+
+	if rule.Log && rule.Protocol != SecurityGroupProtocolTCP && rule.Protocol != "" {
+		problems = append(problems, T("log can only be used with protocol tcp"))
+	}
+
+	return problems
+}
+
+// Allows reports whether the rule lets traffic of protocol, tcp or udp,
+// reach port on the IPv4 address ip.
+func (rule SecurityGroupRule) Allows(protocol string, ip net.IP, port int) bool {
+	if rule.Protocol != SecurityGroupProtocolAll && rule.Protocol != protocol {
+		return false
+	}
+
+	if !destinationContains(rule.Destination, ip.To4()) {
+		return false
+	}
+
+	return rule.Protocol == SecurityGroupProtocolAll || portsContain(rule.Ports, port)
+}
+
+// Map returns the fields of the rule that are set, keyed by their JSON name.
+func (rule SecurityGroupRule) Map() map[string]interface{} {
+	fields := map[string]interface{}{
+		"protocol":    rule.Protocol,
+		"destination": rule.Destination,
+	}
+	if rule.Ports != "" {
+		fields["ports"] = rule.Ports
+	}
+	if rule.Type != nil {
+		fields["type"] = *rule.Type
+	}
+	if rule.Code != nil {
+		fields["code"] = *rule.Code
+	}
+	if rule.Log {
+		fields["log"] = rule.Log
+	}
+	if rule.Description != "" {
+		fields["description"] = rule.Description
+	}
+	return fields
+}
+
+// String describes the rule on one line, such as "tcp 10.0.0.0/8 ports 443".
+func (rule SecurityGroupRule) String() string {
+	buffer := new(bytes.Buffer)
+	fmt.Fprintf(buffer, "%s %s", rule.Protocol, rule.Destination)
+
+	fields := rule.Map()
+	names := []string{}
+	for name := range fields {
+		if name != "protocol" && name != "destination" && name != "description" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(buffer, " %s %v", name, fields[name])
+	}
+	return buffer.String()
+}
+
+// validateDestination accepts an IPv4 address, a CIDR block or a range of
+// addresses such as 10.0.0.1-10.0.0.255.
+func validateDestination(destination string) string {
+	invalid := T("destination {{.Destination}} is not an IPv4 address, CIDR block or address range",
+		map[string]interface{}{"Destination": strconv.Quote(destination)})
+
+	if strings.Contains(destination, "/") {
+		ip, _, err := net.ParseCIDR(destination)
+		if err != nil || ip.To4() == nil {
+			return invalid
+		}
+		return ""
+	}
+
+	if strings.Contains(destination, "-") {
+		bounds := strings.SplitN(destination, "-", 2)
+		first, last := parseIPv4(bounds[0]), parseIPv4(bounds[1])
+		if first == nil || last == nil {
+			return invalid
+		}
+		if bytes.Compare(first, last) > 0 {
+			return T("destination {{.Destination}} is a reversed range",
+				map[string]interface{}{"Destination": strconv.Quote(destination)})
+		}
+		return ""
+	}
+
+	if parseIPv4(destination) == nil {
+		return invalid
+	}
+	return ""
+}
+
+func parseIPv4(address string) net.IP {
+	ip := net.ParseIP(strings.TrimSpace(address))
+	if ip == nil {
+		return nil
+	}
+	return ip.To4()
+}
+
+// validatePorts accepts a comma separated list of ports and port ranges,
+// such as 80,443,8080-8090.
+func validatePorts(ports string) []string {
+	problems := []string{}
+
+	for _, entry := range strings.Split(ports, ",") {
+		entry = strings.TrimSpace(entry)
+		bounds := strings.SplitN(entry, "-", 2)
+
+		numbers := []int{}
+		for _, bound := range bounds {
+			port, err := strconv.Atoi(strings.TrimSpace(bound))
+			if err != nil || port < 1 || port > 65535 {
+				numbers = nil
+				break
+			}
+			numbers = append(numbers, port)
+		}
+
+		switch {
+		case numbers == nil:
+			problems = append(problems, T("ports {{.Ports}} is not a port or port range between 1 and 65535",
+				map[string]interface{}{"Ports": strconv.Quote(entry)}))
+		case len(numbers) == 2 && numbers[0] > numbers[1]:
+			problems = append(problems, T("ports {{.Ports}} is a reversed range",
+				map[string]interface{}{"Ports": strconv.Quote(entry)}))
+		}
+	}
+
+	return problems
+}
+
+func destinationContains(destination string, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	if strings.Contains(destination, "/") {
+		_, network, err := net.ParseCIDR(destination)
+		return err == nil && network.Contains(ip)
+	}
+
+	if strings.Contains(destination, "-") {
+		bounds := strings.SplitN(destination, "-", 2)
+		first, last := parseIPv4(bounds[0]), parseIPv4(bounds[1])
+		return first != nil && last != nil && bytes.Compare(first, ip) <= 0 && bytes.Compare(ip, last) <= 0
+	}
+
+	return ip.Equal(parseIPv4(destination))
+}
+
+func portsContain(ports string, port int) bool {
+	for _, entry := range strings.Split(ports, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(entry), "-", 2)
+		first, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			continue
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				continue
+			}
+		}
+
+		if first <= port && port <= last {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package models_test
+
+import (
+	"net"
+
+	. "code.cloudfoundry.org/cli/cf/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecurityGroupRule", func() {
+	number := func(n int) *int {
+		return &n
+	}
+
+	DescribeTable("Validate accepts",
+		func(rule SecurityGroupRule) {
+			Expect(rule.Validate()).To(BeEmpty())
+		},
+		Entry("a tcp rule with a port", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443"}),
+		Entry("a udp rule with a list of ports and ranges", SecurityGroupRule{Protocol: "udp", Destination: "10.0.0.0/8", Ports: "53, 8080-8090"}),
+		Entry("a tcp rule that is logged", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "1-65535", Log: true}),
+		Entry("an address range", SecurityGroupRule{Protocol: "all", Destination: "10.0.0.1-10.0.0.255"}),
+		Entry("an icmp rule for any type and code", SecurityGroupRule{Protocol: "icmp", Destination: "0.0.0.0/0", Type: number(-1), Code: number(-1)}),
+		Entry("an icmp rule for echo replies", SecurityGroupRule{Protocol: "icmp", Destination: "0.0.0.0/0", Type: number(0), Code: number(0)}),
+	)
+
+	DescribeTable("Validate rejects",
+		func(rule SecurityGroupRule, problems ...string) {
+			Expect(rule.Validate()).To(Equal(problems))
+		},
+		Entry("a rule without a protocol or destination", SecurityGroupRule{},
+			"protocol is missing", "destination is missing"),
+		Entry("an unknown protocol", SecurityGroupRule{Protocol: "TCP", Destination: "10.0.0.1"},
+			`protocol "TCP" is not one of tcp, udp, icmp or all`),
+		Entry("an invalid CIDR block", SecurityGroupRule{Protocol: "all", Destination: "10.0.0.0/33"},
+			`destination "10.0.0.0/33" is not an IPv4 address, CIDR block or address range`),
+		Entry("an IPv6 address", SecurityGroupRule{Protocol: "all", Destination: "::1"},
+			`destination "::1" is not an IPv4 address, CIDR block or address range`),
+		Entry("a host name", SecurityGroupRule{Protocol: "all", Destination: "example.com"},
+			`destination "example.com" is not an IPv4 address, CIDR block or address range`),
+		Entry("a reversed address range", SecurityGroupRule{Protocol: "all", Destination: "10.0.1.0-10.0.0.255"},
+			`destination "10.0.1.0-10.0.0.255" is a reversed range`),
+		Entry("a tcp rule without ports", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1"},
+			"ports are required for protocol tcp"),
+		Entry("a reversed port range", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "80,9090-8080"},
+			`ports "9090-8080" is a reversed range`),
+		Entry("ports out of range", SecurityGroupRule{Protocol: "udp", Destination: "10.0.0.1", Ports: "0,65536,http"},
+			`ports "0" is not a port or port range between 1 and 65535`,
+			`ports "65536" is not a port or port range between 1 and 65535`,
+			`ports "http" is not a port or port range between 1 and 65535`),
+		Entry("ports on an all rule", SecurityGroupRule{Protocol: "all", Destination: "10.0.0.1", Ports: "443"},
+			"ports cannot be used with protocol all"),
+		Entry("an icmp type and code on a tcp rule", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443", Type: number(8), Code: number(0)},
+			"type can only be used with protocol icmp", "code can only be used with protocol icmp"),
+		Entry("an icmp rule without a type and code", SecurityGroupRule{Protocol: "icmp", Destination: "10.0.0.1"},
+			"type is required for protocol icmp", "code is required for protocol icmp"),
+		Entry("an icmp type out of range", SecurityGroupRule{Protocol: "icmp", Destination: "10.0.0.1", Type: number(256), Code: number(-2)},
+			"type 256 is not between -1 and 255", "code -2 is not between -1 and 255"),
+		Entry("a logged udp rule", SecurityGroupRule{Protocol: "udp", Destination: "10.0.0.1", Ports: "53", Log: true},
+			"log can only be used with protocol tcp"),
+	)
+
+	DescribeTable("Allows",
+		func(rule SecurityGroupRule, protocol string, ip string, port int, allowed bool) {
+			Expect(rule.Allows(protocol, net.ParseIP(ip), port)).To(Equal(allowed))
+		},
+		Entry("an address and port in the rule", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "5432"}, "tcp", "10.0.0.1", 5432, true),
+		Entry("another address", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "5432"}, "tcp", "10.0.0.2", 5432, false),
+		Entry("another port", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "5432"}, "tcp", "10.0.0.1", 5433, false),
+		Entry("another protocol", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1", Ports: "53"}, "udp", "10.0.0.1", 53, false),
+		Entry("an address in a CIDR block", SecurityGroupRule{Protocol: "udp", Destination: "10.0.0.0/8", Ports: "53"}, "udp", "10.255.0.1", 53, true),
+		Entry("an address outside a CIDR block", SecurityGroupRule{Protocol: "udp", Destination: "10.0.0.0/8", Ports: "53"}, "udp", "11.0.0.1", 53, false),
+		Entry("an address in a range", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1-10.0.0.9", Ports: "80"}, "tcp", "10.0.0.9", 80, true),
+		Entry("an address outside a range", SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.1-10.0.0.9", Ports: "80"}, "tcp", "10.0.0.10", 80, false),
+		Entry("a port in a list of ranges", SecurityGroupRule{Protocol: "tcp", Destination: "0.0.0.0/0", Ports: "80, 8080-8090"}, "tcp", "1.2.3.4", 8085, true),
+		Entry("any traffic for protocol all", SecurityGroupRule{Protocol: "all", Destination: "10.0.0.1"}, "udp", "10.0.0.1", 1234, true),
+		Entry("no traffic for protocol icmp", SecurityGroupRule{Protocol: "icmp", Destination: "10.0.0.1", Type: number(-1), Code: number(-1)}, "tcp", "10.0.0.1", 80, false),
+	)
+
+	Describe("Map", func() {
+		It("only has the fields that are set", func() {
+			rule := SecurityGroupRule{Protocol: "icmp", Destination: "10.0.0.1", Type: number(0), Code: number(-1)}
+			Expect(rule.Map()).To(Equal(map[string]interface{}{
+				"protocol":    "icmp",
+				"destination": "10.0.0.1",
+				"type":        0,
+				"code":        -1,
+			}))
+		})
+	})
+
+	Describe("String", func() {
+		It("describes the rule on one line", func() {
+			rule := SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "443", Log: true, Description: "web"}
+			Expect(rule.String()).To(Equal("tcp 10.0.0.0/8 log true ports 443"))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package net
 import (
 	"encoding/json"
 	"strconv"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -35,6 +36,7 @@ func NewCloudControllerGateway(config coreconfig.Reader, clock func() time.Time,
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
 		warnings:        &[]string{},
+		warningsMutex:   &sync.Mutex{},
 		Clock:           clock,
 		ui:              ui,
 		logger:          logger,
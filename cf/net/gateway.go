@@ -14,6 +14,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf"
@@ -65,6 +66,7 @@ type Gateway struct {
 	trustedCerts    []tls.Certificate
 	config          coreconfig.Reader
 	warnings        *[]string
+	warningsMutex   *sync.Mutex
 	Clock           func() time.Time
 	transport       *http.Transport
 	ui              terminal.UI
@@ -285,50 +287,63 @@ func (gateway Gateway) PerformRequestForJSONResponse(request *Request, response
 }
 
 func (gateway Gateway) PerformPollingRequestForJSONResponse(endpoint string, request *Request, response interface{}, timeout time.Duration) (http.Header, error) {
+	jobURL, headers, err := gateway.PerformAsyncRequestForJSONResponse(request, response)
+	if err != nil || jobURL == "" {
+		return headers, err
+	}
+
+	err = gateway.WaitForJob(endpoint+jobURL, request.HTTPReq.Header.Get("Authorization"), timeout)
+
+	return headers, err
+}
+
+// PerformAsyncRequestForJSONResponse performs the request asynchronously and
+// returns the URL of the job that the server started for it, which is empty
+// when the server did not start a job. Use WaitForJob to wait for the job.
+func (gateway Gateway) PerformAsyncRequestForJSONResponse(request *Request, response interface{}) (string, http.Header, error) {
 	query := request.HTTPReq.URL.Query()
 	query.Add("async", "true")
 	request.HTTPReq.URL.RawQuery = query.Encode()
 
 	bytes, headers, rawResponse, err := gateway.performRequestForResponseBytes(request)
 	if err != nil {
-		return headers, err
+		return "", headers, err
 	}
 	defer rawResponse.Body.Close()
 
 	if rawResponse.StatusCode > 203 || strings.TrimSpace(string(bytes)) == "" {
-		return headers, nil
+		return "", headers, nil
 	}
 
 	err = json.Unmarshal(bytes, &response)
 	if err != nil {
-		return headers, fmt.Errorf("%s: %s", T("Invalid JSON response from server"), err.Error())
+		return "", headers, fmt.Errorf("%s: %s", T("Invalid JSON response from server"), err.Error())
 	}
 
 	asyncResource := &AsyncResource{}
 	err = json.Unmarshal(bytes, &asyncResource)
 	if err != nil {
-		return headers, fmt.Errorf("%s: %s", T("Invalid async response from server"), err.Error())
+		return "", headers, fmt.Errorf("%s: %s", T("Invalid async response from server"), err.Error())
 	}
 
 	jobURL := asyncResource.Metadata.URL
-	if jobURL == "" {
-		return headers, nil
-	}
-
 	if !strings.Contains(jobURL, "/jobs/") {
-		return headers, nil
+		return "", headers, nil
 	}
 
-	err = gateway.waitForJob(endpoint+jobURL, request.HTTPReq.Header.Get("Authorization"), timeout)
-
-	return headers, err
+	return jobURL, headers, nil
 }
 
 func (gateway Gateway) Warnings() []string {
+	gateway.warningsMutex.Lock()
+	defer gateway.warningsMutex.Unlock()
+
 	return *gateway.warnings
 }
 
-func (gateway Gateway) waitForJob(jobURL, accessToken string, timeout time.Duration) error {
+// WaitForJob polls the job at jobURL until it has finished or failed, or
+// until timeout has passed when it is not zero.
+func (gateway Gateway) WaitForJob(jobURL, accessToken string, timeout time.Duration) error {
 	startTime := gateway.Clock()
 	for true {
 		if gateway.Clock().Sub(startTime) > timeout && timeout != 0 {
@@ -437,10 +452,12 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 
 	header := http.CanonicalHeaderKey("X-Cf-Warnings")
 	rawWarnings := response.Header[header]
+	gateway.warningsMutex.Lock()
 	for _, rawWarning := range rawWarnings {
 		warning, _ := url.QueryUnescape(rawWarning)
 		*gateway.warnings = append(*gateway.warnings, warning)
 	}
+	gateway.warningsMutex.Unlock()
 
 	return response, err
 }
@@ -37,6 +37,10 @@ func (p RequestDumper) DumpRequest(req *http.Request) {
 			p.printer.Println(T("[MULTIPART/FORM-DATA CONTENT HIDDEN]"))
 		}
 	}
+
+	if recorder, ok := p.printer.(trace.HTTPRecorder); ok {
+		recorder.RecordRequest(req)
+	}
 }
 
 func (p RequestDumper) DumpResponse(res *http.Response) {
@@ -46,4 +50,8 @@ func (p RequestDumper) DumpResponse(res *http.Response) {
 	} else {
 		p.printer.Printf("\n%s [%s]\n%s\n", terminal.HeaderColor(T("RESPONSE:")), time.Now().Format(time.RFC3339), trace.Sanitize(string(dumpedResponse)))
 	}
+
+	if recorder, ok := p.printer.(trace.HTTPRecorder); ok {
+		recorder.RecordResponse(res)
+	}
 }
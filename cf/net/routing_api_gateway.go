@@ -3,6 +3,7 @@ package net
 import (
 	"encoding/json"
 	"net/http"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -32,6 +33,7 @@ func NewRoutingAPIGateway(config coreconfig.Reader, clock func() time.Time, ui t
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
 		warnings:        &[]string{},
+		warningsMutex:   &sync.Mutex{},
 		Clock:           clock,
 		ui:              ui,
 		logger:          logger,
@@ -2,6 +2,7 @@ package net
 
 import (
 	"encoding/json"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -32,6 +33,7 @@ func NewUAAGateway(config coreconfig.Reader, ui terminal.UI, logger trace.Printe
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
 		warnings:        &[]string{},
+		warningsMutex:   &sync.Mutex{},
 		Clock:           time.Now,
 		ui:              ui,
 		logger:          logger,
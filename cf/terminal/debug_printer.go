@@ -1,6 +1,10 @@
 package terminal
 
 import (
+	"bufio"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -13,4 +17,34 @@ type DebugPrinter struct {
 
 func (p DebugPrinter) Print(title, dump string) {
 	p.Logger.Printf("\n%s [%s]\n%s\n", HeaderColor(T(title)), time.Now().Format(time.RFC3339), trace.Sanitize(dump))
+
+	if recorder, ok := p.Logger.(trace.HTTPRecorder); ok {
+		recordWebsocketDump(recorder, title, dump)
+	}
+}
+
+// recordWebsocketDump reads back the websocket handshake dumps of the noaa
+// and loggregator consumers, which only give the handshake as text.
+func recordWebsocketDump(recorder trace.HTTPRecorder, title, dump string) {
+	reader := bufio.NewReader(strings.NewReader(dump + "\n"))
+
+	switch title {
+	case "WEBSOCKET REQUEST:":
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			return
+		}
+
+		// The consumers put the full endpoint URL in the Host header
+		if endpoint, err := url.Parse(req.Host + req.RequestURI); err == nil && endpoint.Scheme != "" {
+			req.URL = endpoint
+		}
+		recorder.RecordRequest(req)
+	case "WEBSOCKET RESPONSE:":
+		res, err := http.ReadResponse(reader, nil)
+		if err != nil {
+			return
+		}
+		recorder.RecordResponse(res)
+	}
 }
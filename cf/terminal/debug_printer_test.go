@@ -0,0 +1,58 @@
+package terminal_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/trace"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+
+	. "code.cloudfoundry.org/cli/cf/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DebugPrinter", func() {
+	type recordingPrinter struct {
+		*tracefakes.FakePrinter
+		*tracefakes.FakeHTTPRecorder
+	}
+
+	var (
+		printer  *tracefakes.FakePrinter
+		recorder *tracefakes.FakeHTTPRecorder
+		debug    DebugPrinter
+	)
+
+	BeforeEach(func() {
+		printer = new(tracefakes.FakePrinter)
+		recorder = new(tracefakes.FakeHTTPRecorder)
+		debug = DebugPrinter{Logger: trace.CombinePrinters([]trace.Printer{
+			recordingPrinter{FakePrinter: printer, FakeHTTPRecorder: recorder},
+		})}
+	})
+
+	It("prints the sanitized dump", func() {
+		debug.Print("WEBSOCKET REQUEST:", "GET /apps/app-guid/stream HTTP/1.1\nAuthorization: bearer secret\n")
+
+		Expect(printer.PrintfCallCount()).To(Equal(1))
+		_, args := printer.PrintfArgsForCall(0)
+		Expect(args[2]).NotTo(ContainSubstring("secret"))
+	})
+
+	It("records websocket handshakes", func() {
+		debug.Print("WEBSOCKET REQUEST:",
+			"GET /apps/app-guid/stream HTTP/1.1\n"+
+				"Host: wss://doppler.example.com:443\n"+
+				"Upgrade: websocket\nConnection: Upgrade\nSec-WebSocket-Version: 13\nSec-WebSocket-Key: [HIDDEN]\n"+
+				"Authorization: bearer secret\n")
+		debug.Print("WEBSOCKET RESPONSE:", "HTTP/1.1 101 Switching Protocols\nUpgrade: websocket\n")
+
+		Expect(recorder.RecordRequestCallCount()).To(Equal(1))
+		req := recorder.RecordRequestArgsForCall(0)
+		Expect(req.URL.String()).To(Equal("wss://doppler.example.com:443/apps/app-guid/stream"))
+		Expect(req.Header.Get("Upgrade")).To(Equal("websocket"))
+
+		Expect(recorder.RecordResponseCallCount()).To(Equal(1))
+		res := recorder.RecordResponseArgsForCall(0)
+		Expect(res.StatusCode).To(Equal(101))
+		Expect(res.Request).To(BeNil())
+	})
+})
@@ -0,0 +1,106 @@
+package terminal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// OutputFormat is the format in which tables are rendered.
+type OutputFormat string
+
+const (
+	TextOutput OutputFormat = ""
+	JSONOutput OutputFormat = "json"
+	YAMLOutput OutputFormat = "yaml"
+)
+
+// UserAskedForOutputFormat holds the format requested through the global
+// --output flag. All tables printed through a UITable honor it.
+var UserAskedForOutputFormat = TextOutput
+
+// ParseOutputFormat converts the value given to the --output flag into an
+// OutputFormat.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch OutputFormat(strings.ToLower(value)) {
+	case JSONOutput:
+		return JSONOutput, nil
+	case YAMLOutput:
+		return YAMLOutput, nil
+	default:
+		return TextOutput, errors.New("Output format must be either 'json' or 'yaml'")
+	}
+}
+
+// Structured returns true for the formats that render tables as records
+// instead of padded columns.
+func (f OutputFormat) Structured() bool {
+	return f == JSONOutput || f == YAMLOutput
+}
+
+// Record is a single table row keyed by the field names of its table. The
+// fields keep the column order of the table when marshalled.
+type Record []RecordField
+
+type RecordField struct {
+	Name  string
+	Value string
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString("{")
+	for i, field := range r {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+
+		name, err := json.Marshal(field.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+func (r Record) MarshalYAML() (interface{}, error) {
+	slice := yaml.MapSlice{}
+	for _, field := range r {
+		slice = append(slice, yaml.MapItem{Key: field.Name, Value: field.Value})
+	}
+	return slice, nil
+}
+
+// MarshalRecords renders the records in the given structured format.
+func MarshalRecords(format OutputFormat, records []Record) ([]byte, error) {
+	if records == nil {
+		records = []Record{}
+	}
+
+	switch format {
+	case JSONOutput:
+		return json.MarshalIndent(records, "", "  ")
+	case YAMLOutput:
+		return yaml.Marshal(records)
+	default:
+		return nil, errors.New("Output format must be either 'json' or 'yaml'")
+	}
+}
+
+// FieldName turns a table header into a field name by lowercasing it and
+// joining its words with underscores.
+func FieldName(header string) string {
+	return strings.Join(strings.Fields(strings.ToLower(Decolorize(header))), "_")
+}
@@ -13,6 +13,7 @@ import (
 type Table struct {
 	ui            UI
 	headers       []string
+	fieldNames    []string
 	headerPrinted bool
 	columnWidth   []int
 	rowHeight     []int
@@ -62,6 +63,14 @@ func (t *Table) SetTransformer(columnIndex int, tr Transformer) {
 	t.transformer[columnIndex] = tr
 }
 
+// SetFieldNames specifies the names under which the columns of the
+// table appear in structured output. Unlike the headers they are not
+// translated, so scripts can rely on them. Without them the field
+// names are derived from the headers.
+func (t *Table) SetFieldNames(names ...string) {
+	t.fieldNames = names
+}
+
 // Add extends the table by another row.
 func (t *Table) Add(row ...string) {
 	t.rows = append(t.rows, row)
@@ -116,6 +125,36 @@ func (t *Table) PrintTo(result io.Writer) error {
 	return nil
 }
 
+// Records returns the collected rows of the table as records keyed by
+// the field names of the table, stripped of any colorization. Like
+// PrintTo, retrieving the records clears the table.
+func (t *Table) Records() []Record {
+	names := make([]string, len(t.headers))
+	for i, header := range t.headers {
+		if i < len(t.fieldNames) && t.fieldNames[i] != "" {
+			names[i] = t.fieldNames[i]
+		} else {
+			names[i] = FieldName(header)
+		}
+	}
+
+	records := []Record{}
+	for _, row := range t.rows {
+		record := Record{}
+		for columnIndex := range names {
+			var value string
+			if columnIndex < len(row) {
+				value = strings.TrimSpace(Decolorize(row[columnIndex]))
+			}
+			record = append(record, RecordField{Name: names[columnIndex], Value: value})
+		}
+		records = append(records, record)
+	}
+
+	t.rows = [][]string{}
+	return records
+}
+
 // calculateMaxSize iterates over the collected rows of the specified
 // table, and their strings, determining the height of each row (in
 // lines), and the width of each column (in characters). The results
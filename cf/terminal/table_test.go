@@ -265,4 +265,80 @@ var _ = Describe("Table", func() {
 			))
 		})
 	})
+
+	Describe("Records", func() {
+		It("returns the rows keyed by field names derived from the headers", func() {
+			table = NewTable([]string{"name", "requested state"})
+			table.Add("app-1", "started")
+
+			Expect(table.Records()).To(Equal([]Record{
+				{{Name: "name", Value: "app-1"}, {Name: "requested_state", Value: "started"}},
+			}))
+		})
+
+		It("uses the field names when they are set", func() {
+			table = NewTable([]string{"nom", "état demandé"})
+			table.SetFieldNames("name", "requested_state")
+			table.Add("app-1", "started")
+
+			Expect(table.Records()).To(Equal([]Record{
+				{{Name: "name", Value: "app-1"}, {Name: "requested_state", Value: "started"}},
+			}))
+		})
+
+		It("removes the colorization from the values", func() {
+			table.Add(HeaderColor("a"), "b", "c")
+
+			records := table.Records()
+			Expect(records[0][0].Value).To(Equal("a"))
+		})
+
+		It("clears the table", func() {
+			table.Add("a", "b", "c")
+			table.Records()
+
+			Expect(table.Records()).To(BeEmpty())
+		})
+	})
+
+	Describe("MarshalRecords", func() {
+		var records []Record
+
+		BeforeEach(func() {
+			records = []Record{
+				{{Name: "name", Value: "app-1"}, {Name: "disk", Value: "1G"}},
+			}
+		})
+
+		It("renders the records as json keeping the column order", func() {
+			output, err := MarshalRecords(JSONOutput, records)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(MatchJSON(`[{"name": "app-1", "disk": "1G"}]`))
+			Expect(string(output)).To(MatchRegexp(`"name"(.|\n)*"disk"`))
+		})
+
+		It("renders the records as yaml keeping the column order", func() {
+			output, err := MarshalRecords(YAMLOutput, records)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(Equal("- name: app-1\n  disk: 1G\n"))
+		})
+
+		It("renders an empty list when there are no records", func() {
+			output, err := MarshalRecords(JSONOutput, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(Equal("[]"))
+		})
+	})
+
+	Describe("ParseOutputFormat", func() {
+		It("accepts json and yaml", func() {
+			Expect(ParseOutputFormat("json")).To(Equal(JSONOutput))
+			Expect(ParseOutputFormat("YAML")).To(Equal(YAMLOutput))
+		})
+
+		It("returns an error for other formats", func() {
+			_, err := ParseOutputFormat("xml")
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -3,6 +3,7 @@ package terminal
 import (
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -54,6 +55,7 @@ type Printer interface {
 type terminalUI struct {
 	stdin   io.Reader
 	stdout  io.Writer
+	stderr  io.Writer
 	printer Printer
 	logger  trace.Printer
 }
@@ -62,6 +64,7 @@ func NewUI(r io.Reader, w io.Writer, printer Printer, logger trace.Printer) UI {
 	return &terminalUI{
 		stdin:   r,
 		stdout:  w,
+		stderr:  os.Stderr,
 		printer: printer,
 		logger:  logger,
 	}
@@ -91,6 +94,17 @@ func (ui *terminalUI) PrintCapturingNoOutput(message string, args ...interface{}
 }
 
 func (ui *terminalUI) Say(message string, args ...interface{}) {
+	// With structured output stdout only carries the records of the
+	// tables, everything else is moved out of the way of the consumer.
+	if UserAskedForOutputFormat.Structured() {
+		if len(args) == 0 {
+			fmt.Fprintf(ui.stderr, "%s\n", message)
+		} else {
+			fmt.Fprintf(ui.stderr, message+"\n", args...)
+		}
+		return
+	}
+
 	if len(args) == 0 {
 		_, _ = ui.printer.Printf("%s\n", message)
 	} else {
@@ -247,29 +261,46 @@ func (ui *terminalUI) ShowConfiguration(config coreconfig.Reader) error {
 }
 
 func (ui *terminalUI) LoadingIndication() {
+	if UserAskedForOutputFormat.Structured() {
+		fmt.Fprint(ui.stderr, ".")
+		return
+	}
 	_, _ = ui.printer.Print(".")
 }
 
 func (ui *terminalUI) Table(headers []string) *UITable {
 	return &UITable{
-		UI:    ui,
-		Table: NewTable(headers),
+		UI:      ui,
+		Table:   NewTable(headers),
+		printer: ui.printer,
 	}
 }
 
 type UITable struct {
 	UI    UI
 	Table *Table
+
+	printer Printer
 }
 
 func (u *UITable) Add(row ...string) {
 	u.Table.Add(row...)
 }
 
+// SetFieldNames specifies the stable names of the columns used when
+// the table is printed as structured output.
+func (u *UITable) SetFieldNames(names ...string) {
+	u.Table.SetFieldNames(names...)
+}
+
 // Print formats the table and then prints it to the UI specified at
 // the time of the construction. Afterwards the table is cleared,
 // becoming ready for another round of rows and printing.
 func (u *UITable) Print() error {
+	if UserAskedForOutputFormat.Structured() {
+		return u.printRecords(UserAskedForOutputFormat)
+	}
+
 	result := &bytes.Buffer{}
 	t := u.Table
 
@@ -299,6 +330,25 @@ func (u *UITable) Print() error {
 	return nil
 }
 
+// printRecords prints the rows of the table as records in the given
+// structured format. The records bypass Say, which is redirected away
+// from stdout while structured output is requested.
+func (u *UITable) printRecords(format OutputFormat) error {
+	data, err := MarshalRecords(format, u.Table.Records())
+	if err != nil {
+		return err
+	}
+
+	output := strings.TrimSuffix(string(data), "\n")
+	if u.printer != nil {
+		_, err = u.printer.Printf("%s\n", output)
+		return err
+	}
+
+	u.UI.Say("%s", output)
+	return nil
+}
+
 func (ui *terminalUI) NotifyUpdateIfNeeded(config coreconfig.Reader) {
 	if !config.IsMinCLIVersion(cf.Version) {
 		ui.Say("")
@@ -81,6 +81,30 @@ var _ = Describe("UI", func() {
 		})
 	})
 
+	Describe("Printing tables with a structured output format", func() {
+		BeforeEach(func() {
+			UserAskedForOutputFormat = JSONOutput
+		})
+
+		AfterEach(func() {
+			UserAskedForOutputFormat = TextOutput
+		})
+
+		It("prints only the records of the table to stdout", func() {
+			output := io_helpers.CaptureOutput(func() {
+				ui := NewUI(os.Stdin, os.Stdout, NewTeePrinter(os.Stdout), fakeLogger)
+				ui.Say("Getting apps...")
+
+				table := ui.Table([]string{"name", "state"})
+				table.SetFieldNames("name", "requested_state")
+				table.Add("app-1", "started")
+				Expect(table.Print()).To(Succeed())
+			})
+
+			Expect(strings.Join(output, "\n")).To(MatchJSON(`[{"name": "app-1", "requested_state": "started"}]`))
+		})
+	})
+
 	Describe("Asking user for input", func() {
 		It("allows string with whitespaces", func() {
 			_ = io_helpers.CaptureOutput(func() {
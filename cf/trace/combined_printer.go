@@ -1,5 +1,7 @@
 package trace
 
+import "net/http"
+
 type combinedPrinter []Printer
 
 func CombinePrinters(printers []Printer) Printer {
@@ -33,3 +35,19 @@ func (p combinedPrinter) WritesToConsole() bool {
 
 	return false
 }
+
+func (p combinedPrinter) RecordRequest(req *http.Request) {
+	for _, printer := range p {
+		if recorder, ok := printer.(HTTPRecorder); ok {
+			recorder.RecordRequest(req)
+		}
+	}
+}
+
+func (p combinedPrinter) RecordResponse(res *http.Response) {
+	for _, printer := range p {
+		if recorder, ok := printer.(HTTPRecorder); ok {
+			recorder.RecordResponse(res)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package trace_test
 
 import (
+	"net/http"
+
 	. "code.cloudfoundry.org/cli/cf/trace"
 
 	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
@@ -61,4 +63,35 @@ var _ = Describe("CombinePrinters", func() {
 		Expect(printer1.PrintlnArgsForCall(0)).To(Equal(expectedArgs))
 		Expect(printer2.PrintlnArgsForCall(0)).To(Equal(expectedArgs))
 	})
+
+	Context("when some of the printers record HTTP", func() {
+		type recordingPrinter struct {
+			*tracefakes.FakePrinter
+			*tracefakes.FakeHTTPRecorder
+		}
+
+		var recorder *tracefakes.FakeHTTPRecorder
+
+		BeforeEach(func() {
+			recorder = new(tracefakes.FakeHTTPRecorder)
+			printer = CombinePrinters([]Printer{
+				printer1,
+				recordingPrinter{FakePrinter: printer2, FakeHTTPRecorder: recorder},
+			})
+		})
+
+		It("passes requests and responses on to them", func() {
+			req, err := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+			Expect(err).NotTo(HaveOccurred())
+			res := &http.Response{Request: req}
+
+			printer.(HTTPRecorder).RecordRequest(req)
+			printer.(HTTPRecorder).RecordResponse(res)
+
+			Expect(recorder.RecordRequestCallCount()).To(Equal(1))
+			Expect(recorder.RecordRequestArgsForCall(0)).To(Equal(req))
+			Expect(recorder.RecordResponseCallCount()).To(Equal(1))
+			Expect(recorder.RecordResponseArgsForCall(0)).To(Equal(res))
+		})
+	})
 })
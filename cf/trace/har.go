@@ -0,0 +1,452 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"code.cloudfoundry.org/cli/cf"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/gofileutils/fileutils"
+)
+
+//go:generate counterfeiter . HTTPRecorder
+
+// HTTPRecorder is implemented by printers that keep a structured record of
+// HTTP requests and their responses rather than a text dump.
+type HTTPRecorder interface {
+	RecordRequest(*http.Request)
+	RecordResponse(*http.Response)
+}
+
+// IsHARPath reports whether a trace path names an HTTP Archive file.
+func IsHARPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".har")
+}
+
+// HAR is an HTTP Archive, as described by the HAR 1.2 specification.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type HARContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+type pendingHAREntry struct {
+	request   *http.Request
+	entry     HAREntry
+	started   time.Time
+	completed bool
+}
+
+// HARRecorder writes the requests and responses it is given to an HTTP
+// Archive file. The file is valid after every request and response, so it is
+// complete even when the CLI exits early. The entries are in the order of
+// their requests. An entry is written once for good when it and every entry
+// before it have a response; the entries after the first one still waiting
+// for a response are rewritten behind them. The entries of an archive that
+// is already in the file are kept, so that the traces of several commands
+// are collected in one file, as they are in a text trace file.
+type HARRecorder struct {
+	mutex   sync.Mutex
+	file    *os.File
+	tail    string
+	offset  int64
+	written int
+	pending []pendingHAREntry
+}
+
+func NewHARRecorder(path string) (*HARRecorder, error) {
+	empty, err := json.MarshalIndent(HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: cf.Name, Version: cf.Version},
+		Entries: []HAREntry{},
+	}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	// the entries are written between the opening bracket of the empty
+	// entries list and the rest of the document
+	split := bytes.LastIndex(empty, []byte("[]")) + 1
+	header := empty[:split]
+
+	buffer := &bytes.Buffer{}
+	buffer.Write(header)
+	written, err := writeHAREntries(buffer, 0, readHAREntries(path))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := fileutils.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = file.Write(buffer.Bytes())
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	recorder := &HARRecorder{
+		file:    file,
+		tail:    "\n    " + string(empty[split:]) + "\n",
+		offset:  int64(buffer.Len()),
+		written: written,
+	}
+
+	err = recorder.write()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return recorder, nil
+}
+
+// readHAREntries returns the entries of the archive in the file at path. A
+// file that does not hold an archive is replaced, so it has no entries.
+func readHAREntries(path string) []HAREntry {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var har HAR
+	if json.Unmarshal(contents, &har) != nil {
+		return nil
+	}
+	return har.Log.Entries
+}
+
+func (r *HARRecorder) Print(v ...interface{})                 {}
+func (r *HARRecorder) Printf(format string, v ...interface{}) {}
+func (r *HARRecorder) Println(v ...interface{})               {}
+
+func (r *HARRecorder) WritesToConsole() bool {
+	return false
+}
+
+// RecordRequest adds an entry for req. The entry has no response until
+// RecordResponse is called with the response to req. A request made to follow
+// a redirect completes the entry of the request that was redirected.
+func (r *HARRecorder) RecordRequest(req *http.Request) {
+	entry := HAREntry{
+		Request: harRequest(req),
+		Response: HARResponse{
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if req.Response != nil {
+		r.complete(req.Response.Request, req.Response)
+	}
+
+	started := time.Now()
+	entry.StartedDateTime = started.Format(time.RFC3339Nano)
+	r.pending = append(r.pending, pendingHAREntry{
+		request: req,
+		entry:   entry,
+		started: started,
+	})
+
+	_ = r.write()
+}
+
+// RecordResponse completes the entry of the request res answers. A response
+// without a request, such as one read back from a websocket handshake dump,
+// completes the oldest websocket request still waiting for a response.
+func (r *HARRecorder) RecordResponse(res *http.Response) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.complete(res.Request, res) {
+		_ = r.write()
+	}
+}
+
+// complete gives the entry of req that is waiting for a response res as its
+// response.
+func (r *HARRecorder) complete(req *http.Request, res *http.Response) bool {
+	for i, pending := range r.pending {
+		if pending.completed {
+			continue
+		}
+		if pending.request != req && (req != nil || !isWebsocketRequest(pending.request)) {
+			continue
+		}
+
+		elapsed := float64(time.Since(pending.started)) / float64(time.Millisecond)
+		r.pending[i].entry.Response = harResponse(res)
+		r.pending[i].entry.Time = elapsed
+		r.pending[i].entry.Timings = HARTimings{Wait: elapsed}
+		r.pending[i].completed = true
+		return true
+	}
+
+	return false
+}
+
+// write appends the completed entries that no longer follow an entry waiting
+// for a response to the ones already in the file, and rewrites the other
+// entries and the end of the document after them.
+func (r *HARRecorder) write() error {
+	settled := 0
+	for settled < len(r.pending) && r.pending[settled].completed {
+		settled++
+	}
+
+	var entries []HAREntry
+	for _, p := range r.pending {
+		entries = append(entries, p.entry)
+	}
+
+	buffer := &bytes.Buffer{}
+	written, err := writeHAREntries(buffer, r.written, entries[:settled])
+	if err != nil {
+		return err
+	}
+	settledSize := buffer.Len()
+
+	_, err = writeHAREntries(buffer, written, entries[settled:])
+	if err != nil {
+		return err
+	}
+	buffer.WriteString(r.tail)
+
+	_, err = r.file.WriteAt(buffer.Bytes(), r.offset)
+	if err != nil {
+		return err
+	}
+	err = r.file.Truncate(r.offset + int64(buffer.Len()))
+	if err != nil {
+		return err
+	}
+
+	r.offset += int64(settledSize)
+	r.written = written
+	r.pending = r.pending[settled:]
+	return nil
+}
+
+// writeHAREntries writes entries as items of the entries list that already
+// holds count items, and returns the number of items it holds afterwards.
+func writeHAREntries(buffer *bytes.Buffer, count int, entries []HAREntry) (int, error) {
+	for _, entry := range entries {
+		contents, err := json.MarshalIndent(entry, "      ", "  ")
+		if err != nil {
+			return count, err
+		}
+
+		if count > 0 {
+			buffer.WriteString(",")
+		}
+		buffer.WriteString("\n      ")
+		buffer.Write(contents)
+		count++
+	}
+	return count, nil
+}
+
+func harRequest(req *http.Request) HARRequest {
+	reqURL := req.URL.String()
+	if parsedURL, err := url.Parse(Sanitize(reqURL)); err == nil {
+		reqURL = parsedURL.String()
+	}
+
+	harReq := HARRequest{
+		Method:      req.Method,
+		URL:         reqURL,
+		HTTPVersion: req.Proto,
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(req.Header),
+		QueryString: []HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    req.ContentLength,
+	}
+
+	if parsedURL, err := url.Parse(reqURL); err == nil {
+		harReq.QueryString = harNameValues(parsedURL.Query())
+	}
+
+	if req.Body == nil {
+		return harReq
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	if strings.Contains(contentType, "multipart/form-data") {
+		harReq.PostData = &HARPostData{
+			MimeType: contentType,
+			Comment:  T("[MULTIPART/FORM-DATA CONTENT HIDDEN]"),
+		}
+		return harReq
+	}
+
+	body := readBody(&req.Body)
+	harReq.BodySize = int64(len(body))
+	if len(body) > 0 {
+		harReq.PostData = &HARPostData{
+			MimeType: contentType,
+			Text:     Sanitize(string(body)),
+		}
+	}
+
+	return harReq
+}
+
+func harResponse(res *http.Response) HARResponse {
+	harRes := HARResponse{
+		Status:      res.StatusCode,
+		StatusText:  strings.TrimPrefix(res.Status, strconv.Itoa(res.StatusCode)+" "),
+		HTTPVersion: res.Proto,
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(res.Header),
+		RedirectURL: res.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    -1,
+		Content: HARContent{
+			MimeType: res.Header.Get("Content-Type"),
+		},
+	}
+
+	if res.Body == nil {
+		return harRes
+	}
+
+	body := readBody(&res.Body)
+	harRes.BodySize = int64(len(body))
+	harRes.Content.Size = int64(len(body))
+	if utf8.Valid(body) {
+		harRes.Content.Text = Sanitize(string(body))
+	} else {
+		harRes.Content.Text = base64.StdEncoding.EncodeToString(body)
+		harRes.Content.Encoding = "base64"
+	}
+
+	return harRes
+}
+
+func harHeaders(header http.Header) []HARNameValue {
+	headers := harNameValues(header)
+	for i, h := range headers {
+		sanitized := Sanitize(h.Name + ": " + h.Value)
+		headers[i].Value = strings.TrimPrefix(sanitized, h.Name+": ")
+	}
+	return headers
+}
+
+func harNameValues(values map[string][]string) []HARNameValue {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	nameValues := []HARNameValue{}
+	for _, name := range names {
+		for _, value := range values[name] {
+			nameValues = append(nameValues, HARNameValue{Name: name, Value: value})
+		}
+	}
+	return nameValues
+}
+
+// readBody reads all of body and replaces it with a reader over what was
+// read, so that the body can still be read by its owner.
+func readBody(body *io.ReadCloser) []byte {
+	contents, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	*body = ioutil.NopCloser(bytes.NewReader(contents))
+	if err != nil {
+		return nil
+	}
+	return contents
+}
+
+func isWebsocketRequest(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Upgrade"), "websocket")
+}
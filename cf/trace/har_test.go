@@ -0,0 +1,245 @@
+package trace_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "code.cloudfoundry.org/cli/cf/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HARRecorder", func() {
+	var (
+		dir      string
+		path     string
+		recorder *HARRecorder
+	)
+
+	readHAR := func() HAR {
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		var har HAR
+		Expect(json.Unmarshal(contents, &har)).To(Succeed())
+		return har
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "har-test")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "traces", "trace.har")
+
+		recorder, err = NewHARRecorder(path)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("writes an empty archive when it is created", func() {
+		har := readHAR()
+		Expect(har.Log.Version).To(Equal("1.2"))
+		Expect(har.Log.Creator.Name).To(Equal("cf"))
+		Expect(har.Log.Entries).To(BeEmpty())
+	})
+
+	It("keeps the entries of an archive already in the file", func() {
+		req, err := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+		recorder.RecordRequest(req)
+		recorder.RecordResponse(&http.Response{StatusCode: 200, Proto: "HTTP/1.1", Header: http.Header{}, Request: req})
+
+		recorder, err = NewHARRecorder(path)
+		Expect(err).NotTo(HaveOccurred())
+		req, err = http.NewRequest("GET", "https://api.example.com/v2/apps", nil)
+		Expect(err).NotTo(HaveOccurred())
+		recorder.RecordRequest(req)
+
+		har := readHAR()
+		Expect(har.Log.Entries).To(HaveLen(2))
+		Expect(har.Log.Entries[0].Request.URL).To(Equal("https://api.example.com/v2/info"))
+		Expect(har.Log.Entries[0].Response.Status).To(Equal(200))
+		Expect(har.Log.Entries[1].Request.URL).To(Equal("https://api.example.com/v2/apps"))
+	})
+
+	It("replaces a file that does not hold an archive", func() {
+		Expect(ioutil.WriteFile(path, []byte("REQUEST: GET /v2/info"), 0600)).To(Succeed())
+
+		var err error
+		recorder, err = NewHARRecorder(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(readHAR().Log.Entries).To(BeEmpty())
+	})
+
+	It("ignores text traces", func() {
+		recorder.Print("Hello World")
+		recorder.Printf("Hello %s", "World")
+		recorder.Println("Hello World")
+
+		Expect(readHAR().Log.Entries).To(BeEmpty())
+		Expect(recorder.WritesToConsole()).To(BeFalse())
+	})
+
+	It("records a request and its response", func() {
+		req, err := http.NewRequest("POST", "https://api.example.com/v2/apps?q=name:app", strings.NewReader(`{"name":"app"}`))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "bearer secret-token")
+
+		recorder.RecordRequest(req)
+
+		har := readHAR()
+		Expect(har.Log.Entries).To(HaveLen(1))
+		Expect(har.Log.Entries[0].Response.Status).To(Equal(0))
+
+		body, err := ioutil.ReadAll(req.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(Equal(`{"name":"app"}`))
+
+		res := &http.Response{
+			Status:     "201 Created",
+			StatusCode: 201,
+			Proto:      "HTTP/1.1",
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"metadata":{"guid":"app-guid"}}`)),
+			Request:    req,
+		}
+		recorder.RecordResponse(res)
+
+		har = readHAR()
+		Expect(har.Log.Entries).To(HaveLen(1))
+		entry := har.Log.Entries[0]
+
+		Expect(entry.StartedDateTime).NotTo(BeEmpty())
+		Expect(entry.Time).To(BeNumerically(">=", 0))
+		Expect(entry.Timings.Wait).To(Equal(entry.Time))
+
+		Expect(entry.Request.Method).To(Equal("POST"))
+		Expect(entry.Request.URL).To(Equal("https://api.example.com/v2/apps?q=name:app"))
+		Expect(entry.Request.QueryString).To(ConsistOf(HARNameValue{Name: "q", Value: "name:app"}))
+		Expect(entry.Request.Headers).To(ContainElement(HARNameValue{Name: "Authorization", Value: PrivateDataPlaceholder()}))
+		Expect(entry.Request.PostData.Text).To(Equal(`{"name":"app"}`))
+
+		Expect(entry.Response.Status).To(Equal(201))
+		Expect(entry.Response.StatusText).To(Equal("Created"))
+		Expect(entry.Response.Content.MimeType).To(Equal("application/json"))
+		Expect(entry.Response.Content.Text).To(Equal(`{"metadata":{"guid":"app-guid"}}`))
+
+		body, err = ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("app-guid"))
+	})
+
+	It("redacts tokens and passwords in bodies", func() {
+		req, err := http.NewRequest("POST", "https://uaa.example.com/oauth/token", strings.NewReader("grant_type=password&password=secret&username=admin"))
+		Expect(err).NotTo(HaveOccurred())
+		recorder.RecordRequest(req)
+
+		recorder.RecordResponse(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"access_token":"secret-token","token_type":"bearer"}`)),
+			Request:    req,
+		})
+
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).NotTo(ContainSubstring("secret"))
+	})
+
+	It("records only the metadata of multipart bodies", func() {
+		req, err := http.NewRequest("PUT", "https://api.example.com/v2/apps/app-guid/bits", strings.NewReader("--boundary\r\nfile contents\r\n--boundary--"))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=boundary")
+
+		recorder.RecordRequest(req)
+
+		postData := readHAR().Log.Entries[0].Request.PostData
+		Expect(postData.MimeType).To(Equal("multipart/form-data; boundary=boundary"))
+		Expect(postData.Text).To(BeEmpty())
+		Expect(postData.Comment).To(Equal("[MULTIPART/FORM-DATA CONTENT HIDDEN]"))
+		Expect(readHAR().Log.Entries[0].Request.BodySize).To(Equal(req.ContentLength))
+	})
+
+	It("pairs a response without a request with the oldest websocket request", func() {
+		apiReq, err := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+		recorder.RecordRequest(apiReq)
+
+		wsReq, err := http.NewRequest("GET", "wss://doppler.example.com:443/apps/app-guid/stream", nil)
+		Expect(err).NotTo(HaveOccurred())
+		wsReq.Header.Set("Upgrade", "websocket")
+		recorder.RecordRequest(wsReq)
+
+		recorder.RecordResponse(&http.Response{Status: "101 Switching Protocols", StatusCode: 101})
+
+		entries := readHAR().Log.Entries
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Response.Status).To(Equal(0))
+		Expect(entries[1].Response.Status).To(Equal(101))
+		Expect(entries[1].Response.StatusText).To(Equal("Switching Protocols"))
+	})
+
+	It("completes the entry of a redirected request with the redirect response", func() {
+		req, err := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+		recorder.RecordRequest(req)
+
+		redirect := &http.Response{
+			Status:     "302 Found",
+			StatusCode: 302,
+			Header:     http.Header{"Location": []string{"https://login.example.com/info"}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}
+		redirectedReq, err := http.NewRequest("GET", "https://login.example.com/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+		redirectedReq.Response = redirect
+		recorder.RecordRequest(redirectedReq)
+
+		recorder.RecordResponse(&http.Response{
+			Status:     "200 OK",
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			Request:    redirectedReq,
+		})
+
+		entries := readHAR().Log.Entries
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Request.URL).To(Equal("https://api.example.com/v2/info"))
+		Expect(entries[0].Response.Status).To(Equal(302))
+		Expect(entries[0].Response.RedirectURL).To(Equal("https://login.example.com/info"))
+		Expect(entries[1].Request.URL).To(Equal("https://login.example.com/info"))
+		Expect(entries[1].Response.Status).To(Equal(200))
+	})
+
+	It("keeps the archive valid while entries are added and completed", func() {
+		var requests []*http.Request
+		for i := 0; i < 20; i++ {
+			req, err := http.NewRequest("GET", "https://api.example.com/v2/apps", nil)
+			Expect(err).NotTo(HaveOccurred())
+			recorder.RecordRequest(req)
+			requests = append(requests, req)
+			Expect(readHAR().Log.Entries).To(HaveLen(i + 1))
+		}
+
+		for i, req := range requests {
+			recorder.RecordResponse(&http.Response{StatusCode: 200 + i, Request: req})
+			Expect(readHAR().Log.Entries).To(HaveLen(20))
+		}
+
+		entries := readHAR().Log.Entries
+		for i, entry := range entries {
+			Expect(entry.Response.Status).To(Equal(200 + i))
+		}
+	})
+})
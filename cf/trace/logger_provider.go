@@ -21,10 +21,10 @@ func NewLogger(writer io.Writer, verbose bool, cfTrace, configTrace string) Prin
 		LoggingToStdout = LoggingToStdout || b
 
 		if path != "" && err != nil {
-			file, err := fileutils.Open(path)
+			printer, err := newFilePrinter(path)
 
 			if err == nil {
-				printers = append(printers, NewWriterPrinter(file, false))
+				printers = append(printers, printer)
 			} else {
 				stdoutLogger.Printf(T("CF_TRACE ERROR CREATING LOG FILE {{.Path}}:\n{{.Err}}",
 					map[string]interface{}{"Path": path, "Err": err}))
@@ -40,3 +40,16 @@ func NewLogger(writer io.Writer, verbose bool, cfTrace, configTrace string) Prin
 
 	return CombinePrinters(printers)
 }
+
+func newFilePrinter(path string) (Printer, error) {
+	if IsHARPath(path) {
+		return NewHARRecorder(path)
+	}
+
+	file, err := fileutils.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWriterPrinter(file, false), nil
+}
@@ -1,7 +1,9 @@
 package trace_test
 
 import (
+	"encoding/json"
 	"io/ioutil"
+	"path/filepath"
 	"runtime"
 
 	. "code.cloudfoundry.org/cli/cf/trace"
@@ -181,4 +183,25 @@ var _ = Describe("NewLogger", func() {
 			Expect(buffer).To(gbytes.Say("Hello World"))
 		}
 	})
+
+	It("returns a logger that records HTTP requests in a HAR file when CF_TRACE ends in .har", func() {
+		fileutils.TempDir("trace_test", func(dir string, err error) {
+			Expect(err).NotTo(HaveOccurred())
+			path := filepath.Join(dir, "trace.har")
+
+			logger := NewLogger(buffer, false, path, "")
+			logger.Print("Hello World")
+
+			Expect(buffer).NotTo(gbytes.Say("Hello World"))
+
+			contents, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).NotTo(ContainSubstring("Hello World"))
+
+			var har HAR
+			Expect(json.Unmarshal(contents, &har)).To(Succeed())
+			Expect(har.Log.Version).To(Equal("1.2"))
+			Expect(har.Log.Entries).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,96 @@
+// This file was generated by counterfeiter
+package tracefakes
+
+import (
+	"net/http"
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/trace"
+)
+
+type FakeHTTPRecorder struct {
+	RecordRequestStub        func(arg1 *http.Request)
+	recordRequestMutex       sync.RWMutex
+	recordRequestArgsForCall []struct {
+		arg1 *http.Request
+	}
+	RecordResponseStub        func(arg1 *http.Response)
+	recordResponseMutex       sync.RWMutex
+	recordResponseArgsForCall []struct {
+		arg1 *http.Response
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHTTPRecorder) RecordRequest(arg1 *http.Request) {
+	fake.recordRequestMutex.Lock()
+	fake.recordRequestArgsForCall = append(fake.recordRequestArgsForCall, struct {
+		arg1 *http.Request
+	}{arg1})
+	fake.recordInvocation("RecordRequest", []interface{}{arg1})
+	fake.recordRequestMutex.Unlock()
+	if fake.RecordRequestStub != nil {
+		fake.RecordRequestStub(arg1)
+	}
+}
+
+func (fake *FakeHTTPRecorder) RecordRequestCallCount() int {
+	fake.recordRequestMutex.RLock()
+	defer fake.recordRequestMutex.RUnlock()
+	return len(fake.recordRequestArgsForCall)
+}
+
+func (fake *FakeHTTPRecorder) RecordRequestArgsForCall(i int) *http.Request {
+	fake.recordRequestMutex.RLock()
+	defer fake.recordRequestMutex.RUnlock()
+	return fake.recordRequestArgsForCall[i].arg1
+}
+
+func (fake *FakeHTTPRecorder) RecordResponse(arg1 *http.Response) {
+	fake.recordResponseMutex.Lock()
+	fake.recordResponseArgsForCall = append(fake.recordResponseArgsForCall, struct {
+		arg1 *http.Response
+	}{arg1})
+	fake.recordInvocation("RecordResponse", []interface{}{arg1})
+	fake.recordResponseMutex.Unlock()
+	if fake.RecordResponseStub != nil {
+		fake.RecordResponseStub(arg1)
+	}
+}
+
+func (fake *FakeHTTPRecorder) RecordResponseCallCount() int {
+	fake.recordResponseMutex.RLock()
+	defer fake.recordResponseMutex.RUnlock()
+	return len(fake.recordResponseArgsForCall)
+}
+
+func (fake *FakeHTTPRecorder) RecordResponseArgsForCall(i int) *http.Response {
+	fake.recordResponseMutex.RLock()
+	defer fake.recordResponseMutex.RUnlock()
+	return fake.recordResponseArgsForCall[i].arg1
+}
+
+func (fake *FakeHTTPRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordRequestMutex.RLock()
+	defer fake.recordRequestMutex.RUnlock()
+	fake.recordResponseMutex.RLock()
+	defer fake.recordResponseMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeHTTPRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ trace.HTTPRecorder = new(FakeHTTPRecorder)
@@ -52,6 +52,18 @@ type Quota struct {
 	Quota string `positional-arg-name:"QUOTA" required:"true" description:"The organization quota"`
 }
 
+type OrgConfigFile struct {
+	Path string `positional-arg-name:"FILE" required:"true" description:"Path to the YAML or JSON file of the desired orgs"`
+}
+
+type SpaceBundleDir struct {
+	Path string `positional-arg-name:"DIR" required:"true" description:"Directory of the space bundle"`
+}
+
+type AppOrSpace struct {
+	AppOrSpace string `positional-arg-name:"APP_OR_SPACE" required:"true" description:"The application or space name"`
+}
+
 type SecurityGroup struct {
 	ServiceGroup string `positional-arg-name:"SECURITY_GROUP" required:"true" description:"The security group"`
 }
@@ -274,3 +286,16 @@ type InstallPluginArgs struct {
 	LocalPath string `positional-arg-name:"LOCAL_PATH/TO/PLUGIN" description:"The local path to the plugin, if the plugin exists locally"`
 	URL       string `positional-arg-name:"URL" description:"The URL to the plugin, if the plugin exists online"`
 }
+
+type UpdatePluginsArgs struct {
+	PluginName string `positional-arg-name:"PLUGIN_NAME" description:"The plugin name, unless --all is given"`
+}
+
+type LogsArgs struct {
+	AppNames []string `positional-arg-name:"APP_NAME" description:"The application names, unless --space is given"`
+}
+
+type TargetProfileArgs struct {
+	Action string `positional-arg-name:"ACTION" required:"true" description:"One of save, use, list or delete"`
+	Name   string `positional-arg-name:"NAME" description:"The profile name, required by save, use and delete"`
+}
@@ -11,10 +11,20 @@ import (
 
 	"github.com/fatih/color"
 
+	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/utils/config"
 	"github.com/nicksnyder/go-i18n/i18n"
 )
 
+// OutputFormat is the format in which DisplayTable renders its table.
+type OutputFormat string
+
+const (
+	TextOutput OutputFormat = ""
+	JSONOutput OutputFormat = "json"
+	YAMLOutput OutputFormat = "yaml"
+)
+
 const (
 	red   color.Attribute = color.FgRed
 	green                 = color.FgGreen
@@ -53,6 +63,10 @@ type UI struct {
 	// Err is the error buffer
 	Err io.Writer
 
+	// OutputFormat selects structured output for tables. When it is set all
+	// other output is sent to Err, leaving Out to the records.
+	OutputFormat OutputFormat
+
 	colorEnabled config.ColorSetting
 
 	translate i18n.TranslateFunc
@@ -85,8 +99,15 @@ func NewTestUI(out io.Writer, err io.Writer) UI {
 	}
 }
 
-// DisplayTable presents a two dimentional array of strings as a table to UI.Out
+// DisplayTable presents a two dimentional array of strings as a table to
+// UI.Out. When a structured OutputFormat is set, the first row names the
+// fields and the remaining rows are displayed as records.
 func (ui UI) DisplayTable(prefix string, table [][]string) {
+	if ui.OutputFormat == JSONOutput || ui.OutputFormat == YAMLOutput {
+		ui.displayRecords(table)
+		return
+	}
+
 	tw := tabwriter.NewWriter(ui.Out, 0, 1, 4, ' ', 0)
 
 	for _, row := range table {
@@ -103,7 +124,7 @@ func (ui UI) DisplayTable(prefix string, table [][]string) {
 // pre-configured langauge. Only the first map in keys is used.
 func (ui UI) DisplayText(formattedString string, keys ...map[string]interface{}) {
 	translatedValue := ui.translate(formattedString, ui.templateValuesFromKeys(keys))
-	fmt.Fprintf(ui.Out, "%s\n", translatedValue)
+	fmt.Fprintf(ui.textOut(), "%s\n", translatedValue)
 }
 
 // DisplayTextWithKeyTranslations translates the keys listed in
@@ -114,12 +135,12 @@ func (ui UI) DisplayTextWithKeyTranslations(formattedString string, keysToTransl
 	for _, key := range keysToTranslate {
 		templateValues[key] = ui.translate(templateValues[key].(string))
 	}
-	fmt.Fprintf(ui.Out, "%s\n", ui.translate(formattedString, templateValues))
+	fmt.Fprintf(ui.textOut(), "%s\n", ui.translate(formattedString, templateValues))
 }
 
 // DisplayNewline outputs a newline to UI.Out.
 func (ui UI) DisplayNewline() {
-	fmt.Fprintf(ui.Out, "\n")
+	fmt.Fprintf(ui.textOut(), "\n")
 }
 
 // DisplayPair outputs the "attribute: formattedString" pair to UI.Out. keys
@@ -127,13 +148,13 @@ func (ui UI) DisplayNewline() {
 // translated directly.
 func (ui UI) DisplayPair(attribute string, formattedString string, keys ...map[string]interface{}) {
 	translatedValue := ui.translate(formattedString, ui.templateValuesFromKeys(keys))
-	fmt.Fprintf(ui.Out, "%s: %s\n", ui.translate(attribute), translatedValue)
+	fmt.Fprintf(ui.textOut(), "%s: %s\n", ui.translate(attribute), translatedValue)
 }
 
 // DisplayHelpHeader translates and then bolds the help header. Sends output to
 // UI.Out.
 func (ui UI) DisplayHelpHeader(text string) {
-	fmt.Fprintf(ui.Out, "%s\n", ui.colorize(ui.translate(text), defaultFgColor, true))
+	fmt.Fprintf(ui.textOut(), "%s\n", ui.colorize(ui.translate(text), defaultFgColor, true))
 }
 
 // DisplayHeaderFlavorText outputs the translated text, with cyan color keys,
@@ -145,13 +166,13 @@ func (ui UI) DisplayHeaderFlavorText(formattedString string, keys ...map[string]
 	}
 
 	translatedValue := ui.translate(formattedString, templateValues)
-	fmt.Fprintf(ui.Out, "%s\n", translatedValue)
+	fmt.Fprintf(ui.textOut(), "%s\n", translatedValue)
 }
 
 // DisplayOK outputs a green translated "OK" message to UI.Out.
 func (ui UI) DisplayOK() {
 	translatedFormatString := ui.translate("OK", nil)
-	fmt.Fprintf(ui.Out, "%s\n", ui.colorize(translatedFormatString, green, true))
+	fmt.Fprintf(ui.textOut(), "%s\n", ui.colorize(translatedFormatString, green, true))
 }
 
 // DisplayErrorMessage combines the err template with the key maps and then
@@ -163,7 +184,7 @@ func (ui UI) DisplayErrorMessage(err string, keys ...map[string]interface{}) {
 	fmt.Fprintf(ui.Err, "%s\n", translatedValue)
 
 	translatedFormatString := ui.translate("FAILED", nil)
-	fmt.Fprintf(ui.Out, "%s\n", ui.colorize(translatedFormatString, red, true))
+	fmt.Fprintf(ui.textOut(), "%s\n", ui.colorize(translatedFormatString, red, true))
 }
 
 // DisplayError outputs the error to UI.Err and outputs a red translated
@@ -173,7 +194,45 @@ func (ui UI) DisplayError(originalErr TranslatableError) {
 	fmt.Fprintf(ui.Err, "%s\n", err.Error())
 
 	translatedFormatString := ui.translate("FAILED", nil)
-	fmt.Fprintf(ui.Out, "%s\n", ui.colorize(translatedFormatString, red, true))
+	fmt.Fprintf(ui.textOut(), "%s\n", ui.colorize(translatedFormatString, red, true))
+}
+
+func (ui UI) displayRecords(table [][]string) {
+	records := []terminal.Record{}
+	if len(table) > 0 {
+		names := make([]string, len(table[0]))
+		for i, column := range table[0] {
+			names[i] = terminal.FieldName(column)
+		}
+
+		for _, row := range table[1:] {
+			record := terminal.Record{}
+			for i, name := range names {
+				var value string
+				if i < len(row) {
+					value = row[i]
+				}
+				record = append(record, terminal.RecordField{Name: name, Value: value})
+			}
+			records = append(records, record)
+		}
+	}
+
+	output, err := terminal.MarshalRecords(terminal.OutputFormat(ui.OutputFormat), records)
+	if err != nil {
+		fmt.Fprintf(ui.Err, "%s\n", err.Error())
+		return
+	}
+
+	fmt.Fprintf(ui.Out, "%s\n", strings.TrimSuffix(string(output), "\n"))
+}
+
+// textOut is where everything but the records of a table is written to.
+func (ui UI) textOut() io.Writer {
+	if ui.OutputFormat == JSONOutput || ui.OutputFormat == YAMLOutput {
+		return ui.Err
+	}
+	return ui.Out
 }
 
 func (ui UI) templateValuesFromKeys(keys []map[string]interface{}) map[string]interface{} {
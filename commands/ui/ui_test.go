@@ -195,6 +195,60 @@ var _ = Describe("UI", func() {
 		})
 	})
 
+	Describe("DisplayTable", func() {
+		var table [][]string
+
+		BeforeEach(func() {
+			table = [][]string{
+				{"name", "requested state"},
+				{"app-1", "started"},
+				{"app-2", "stopped"},
+			}
+		})
+
+		It("displays the rows as padded columns", func() {
+			ui.DisplayTable("", table)
+			Expect(ui.Out).To(Say("name\\s+requested state\n"))
+			Expect(ui.Out).To(Say("app-1\\s+started\n"))
+			Expect(ui.Out).To(Say("app-2\\s+stopped\n"))
+		})
+
+		Context("when the output format is json", func() {
+			BeforeEach(func() {
+				ui.OutputFormat = JSONOutput
+			})
+
+			It("displays the rows as records keyed by the header", func() {
+				ui.DisplayTable("", table)
+				Expect(ui.Out).To(Say(`"name": "app-1",\s+"requested_state": "started"`))
+				Expect(ui.Out).To(Say(`"name": "app-2",\s+"requested_state": "stopped"`))
+			})
+
+			It("displays an empty list when the table has no rows", func() {
+				ui.DisplayTable("", table[:1])
+				Expect(ui.Out).To(Say(`^\[\]\n`))
+			})
+
+			It("displays other text to Err", func() {
+				ui.DisplayOK()
+				Expect(ui.Err).To(Say("OK"))
+				Expect(ui.Out).NotTo(Say("OK"))
+			})
+		})
+
+		Context("when the output format is yaml", func() {
+			BeforeEach(func() {
+				ui.OutputFormat = YAMLOutput
+			})
+
+			It("displays the rows as records keyed by the header", func() {
+				ui.DisplayTable("", table)
+				Expect(ui.Out).To(Say("- name: app-1\n  requested_state: started\n"))
+				Expect(ui.Out).To(Say("- name: app-2\n  requested_state: stopped\n"))
+			})
+		})
+	})
+
 	Describe("DisplayErrorMessage", func() {
 		Context("when only a string is passed in", func() {
 			It("displays the string to Err and outputs FAILED to Out", func() {
@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type ApplyOrgConfigCommand struct {
+	RequiredArgs    flags.OrgConfigFile `positional-args:"yes"`
+	Prune           bool                `long:"prune" description:"Also remove roles, security group bindings and spaces that are not in the file"`
+	Force           bool                `short:"f" description:"Force removals without confirmation"`
+	usage           interface{}         `usage:"CF_NAME apply-org-config FILE [--prune] [-f]\n\n   The file, in YAML or JSON, lists the desired orgs:\n\n   orgs:\n   - name: engineering\n     quota: large\n     managers: [alice]\n     billing_managers: []\n     auditors: []\n     spaces:\n     - name: development\n       quota: small\n       managers: [alice]\n       developers: [bob, carol]\n       auditors: []\n       security_groups: [databases]\n\nTIP:\n   Quotas and security groups are only assigned, create them first with 'CF_NAME create-quota', 'CF_NAME create-space-quota' and 'CF_NAME create-security-group'.\n   A role or security group list that is left out is not changed. Orgs that are not in the file are never changed.\n   The plan is printed before it is applied. Use --prune to also remove what is not in the file, which asks for confirmation unless -f is given."`
+	relatedCommands interface{}         `related_commands:"orgs, spaces, set-quota, set-space-quota, set-org-role, set-space-role, bind-security-group"`
+}
+
+func (_ ApplyOrgConfigCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ApplyOrgConfigCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,30 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type AutoscaleCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	Min             int           `long:"min" description:"Minimum number of instances"`
+	Max             int           `long:"max" description:"Maximum number of instances"`
+	CPUThreshold    int           `long:"cpu-threshold" description:"Average CPU usage of the instances, in percent, to scale the app to"`
+	Interval        string        `long:"interval" description:"Time between two polls of the instance stats, such as 30s or 1m (Default: 30s)"`
+	Cooldown        string        `long:"cooldown" description:"Time to wait after scaling the app before scaling it again, such as 5m (Default: 5m)"`
+	Once            bool          `long:"once" description:"Poll the instance stats and scale the app once, then exit"`
+	usage           interface{}   `usage:"CF_NAME autoscale APP_NAME --min MIN_INSTANCES --max MAX_INSTANCES --cpu-threshold PERCENT [--interval DURATION] [--cooldown DURATION] [--once]\n\nTIP:\n   The instance count is set so that the average CPU usage of the running instances is at most the threshold.\n   Without --once, the app is polled and scaled until the command is interrupted. With --once, schedule the command, for example with cron, at intervals no shorter than the cooldown."`
+	relatedCommands interface{}   `related_commands:"app, scale"`
+}
+
+func (_ AutoscaleCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ AutoscaleCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
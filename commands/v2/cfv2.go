@@ -4,6 +4,7 @@ var Commands commandList
 
 type commandList struct {
 	VerboseOrVersion                   bool                                      `short:"v" long:"version" description:"verbose and version flag"`
+	Output                             string                                    `long:"output" choice:"json" choice:"yaml" description:"Print tables as structured records instead of columns"`
 	App                                AppCommand                                `command:"app" description:"Display health and status for app"`
 	Help                               HelpCommand                               `command:"help" alias:"h" description:"Show help"`
 	Version                            VersionCommand                            `command:"version" description:"Print the version"`
@@ -11,11 +12,13 @@ type commandList struct {
 	Logout                             LogoutCommand                             `command:"logout" alias:"lo" description:"Log user out"`
 	Passwd                             PasswdCommand                             `command:"passwd" alias:"pw" description:"Change user password"`
 	Target                             TargetCommand                             `command:"target" alias:"t" description:"Set or view the targeted org or space"`
+	TargetProfile                      TargetProfileCommand                      `command:"target-profile" description:"Save, switch between, list or delete named targets"`
 	Api                                ApiCommand                                `command:"api" description:"Set or view target api url"`
 	Auth                               AuthCommand                               `command:"auth" description:"Authenticate user non-interactively"`
 	Apps                               AppsCommand                               `command:"apps" alias:"a" description:"List all apps in the target space"`
 	Push                               PushCommand                               `command:"push" alias:"p" description:"Push a new app or sync changes to an existing app"`
 	Scale                              ScaleCommand                              `command:"scale" description:"Change or view the instance count, disk space limit, and memory limit for an app"`
+	Autoscale                          AutoscaleCommand                          `command:"autoscale" description:"Scale the instance count of an app to its CPU usage"`
 	Delete                             DeleteCommand                             `command:"delete" alias:"d" description:"Delete an app"`
 	Rename                             RenameCommand                             `command:"rename" description:"Rename an app"`
 	Start                              StartCommand                              `command:"start" alias:"st" description:"Start an app"`
@@ -25,7 +28,7 @@ type commandList struct {
 	RestartAppInstance                 RestartAppInstanceCommand                 `command:"restart-app-instance" description:"Terminate the running application Instance at the given index and instantiate a new instance of the application with the same index"`
 	Events                             EventsCommand                             `command:"events" description:"Show recent app events"`
 	Files                              FilesCommand                              `command:"files" alias:"f" description:"Print out a list of files in a directory or the contents of a specific file of an app running on the DEA backend"`
-	Logs                               LogsCommand                               `command:"logs" description:"Tail or show recent logs for an app"`
+	Logs                               LogsCommand                               `command:"logs" description:"Tail or show recent logs for one or more apps"`
 	Env                                EnvCommand                                `command:"env" alias:"e" description:"Show all env variables for an app"`
 	SetEnv                             SetEnvCommand                             `command:"set-env" alias:"se" description:"Set an env variable for an app"`
 	UnsetEnv                           UnsetEnvCommand                           `command:"unset-env" description:"Remove an env variable"`
@@ -33,6 +36,7 @@ type commandList struct {
 	Stack                              StackCommand                              `command:"stack" description:"Show information for a stack (a stack is a pre-built file system, including an operating system, that can run apps)"`
 	CopySource                         CopySourceCommand                         `command:"copy-source" description:"Copies the source code of an application to another existing application (and restarts that application)"`
 	CreateAppManifest                  CreateAppManifestCommand                  `command:"create-app-manifest" description:"Create an app manifest for an app that has been pushed successfully"`
+	ValidateManifest                   ValidateManifestCommand                   `command:"validate-manifest" description:"Check a manifest for errors without pushing it"`
 	GetHealthCheck                     GetHealthCheckCommand                     `command:"get-health-check" description:"Get the health_check_type value of an app"`
 	SetHealthCheck                     SetHealthCheckCommand                     `command:"set-health-check" description:"Set health_check_type flag to either 'port' or 'none'"`
 	EnableSSH                          EnableSSHCommand                          `command:"enable-ssh" description:"Enable ssh for the application"`
@@ -46,6 +50,7 @@ type commandList struct {
 	UpdateService                      UpdateServiceCommand                      `command:"update-service" description:"Update a service instance"`
 	DeleteService                      DeleteServiceCommand                      `command:"delete-service" alias:"ds" description:"Delete a service instance"`
 	RenameService                      RenameServiceCommand                      `command:"rename-service" description:"Rename a service instance"`
+	WaitForService                     WaitForServiceCommand                     `command:"wait-for-service" description:"Wait for the last operation on a service instance to finish"`
 	CreateServiceKey                   CreateServiceKeyCommand                   `command:"create-service-key" alias:"csk" description:"Create key for a service instance"`
 	ServiceKeys                        ServiceKeysCommand                        `command:"service-keys" alias:"sk" description:"List keys for a service instance"`
 	ServiceKey                         ServiceKeyCommand                         `command:"service-key" description:"Show service key info"`
@@ -61,11 +66,14 @@ type commandList struct {
 	CreateOrg                          CreateOrgCommand                          `command:"create-org" alias:"co" description:"Create an org"`
 	DeleteOrg                          DeleteOrgCommand                          `command:"delete-org" description:"Delete an org"`
 	RenameOrg                          RenameOrgCommand                          `command:"rename-org" description:"Rename an org"`
+	ApplyOrgConfig                     ApplyOrgConfigCommand                     `command:"apply-org-config" description:"Create or update orgs, spaces, quota assignments, user roles and security group bindings to match a file"`
 	Spaces                             SpacesCommand                             `command:"spaces" description:"List all spaces in an org"`
 	Space                              SpaceCommand                              `command:"space" description:"Show space info"`
 	CreateSpace                        CreateSpaceCommand                        `command:"create-space" description:"Create a space"`
 	DeleteSpace                        DeleteSpaceCommand                        `command:"delete-space" description:"Delete a space"`
 	RenameSpace                        RenameSpaceCommand                        `command:"rename-space" description:"Rename a space"`
+	ExportSpace                        ExportSpaceCommand                        `command:"export-space" description:"Export the apps, services, routes and security groups of the targeted space to a directory"`
+	ImportSpace                        ImportSpaceCommand                        `command:"import-space" description:"Recreate a space exported with export-space in the targeted space"`
 	AllowSpaceSSH                      AllowSpaceSSHCommand                      `command:"allow-space-ssh" description:"Allow SSH access for the space"`
 	DisallowSpaceSSH                   DisallowSpaceSSHCommand                   `command:"disallow-space-ssh" description:"Disallow SSH access for the space"`
 	SpaceSSHAllowed                    SpaceSSHAllowedCommand                    `command:"space-ssh-allowed" description:"Reports whether SSH is allowed in a space"`
@@ -126,6 +134,7 @@ type commandList struct {
 	EnableServiceAccess                EnableServiceAccessCommand                `command:"enable-service-access" description:"Enable access to a service or service plan for one or all orgs"`
 	DisableServiceAccess               DisableServiceAccessCommand               `command:"disable-service-access" description:"Disable access to a service or service plan for one or all orgs"`
 	SecurityGroup                      SecurityGroupCommand                      `command:"security-group" description:"Show a single security group"`
+	SecurityGroupCheck                 SecurityGroupCheckCommand                 `command:"security-group-check" description:"Check whether the security groups of an app or space allow traffic to a destination"`
 	SecurityGroups                     SecurityGroupsCommand                     `command:"security-groups" description:"List all security groups"`
 	CreateSecurityGroup                CreateSecurityGroupCommand                `command:"create-security-group" description:"Create a security group"`
 	UpdateSecurityGroup                UpdateSecurityGroupCommand                `command:"update-security-group" description:"Update a security group"`
@@ -156,5 +165,6 @@ type commandList struct {
 	RepoPlugins                        RepoPluginsCommand                        `command:"repo-plugins" description:"List all available plugins in specified repository or in all added repositories"`
 	Plugins                            PluginsCommand                            `command:"plugins" description:"List all available plugin commands"`
 	InstallPlugin                      InstallPluginCommand                      `command:"install-plugin" description:"Install CLI plugin"`
+	UpdatePlugins                      UpdatePluginsCommand                      `command:"update-plugins" description:"Update installed plugins to the latest version in the added plugin repositories"`
 	UninstallPlugin                    UninstallPluginCommand                    `command:"uninstall-plugin" description:"Uninstall the plugin defined in command argument"`
 }
@@ -11,7 +11,7 @@ type ConfigCommand struct {
 	AsyncTimeout int         `long:"async-timeout" description:"Timeout for async HTTP requests"`
 	Color        string      `long:"color" description:"Enable or disable color"`
 	Locale       string      `long:"locale" description:"Set default locale. If LOCALE is 'CLEAR', previous locale is deleted."`
-	Trace        string      `long:"trace" description:"Trace HTTP requests"`
+	Trace        string      `long:"trace" description:"Trace HTTP requests. A path ending in .har records them in an HTTP Archive (HAR) file"`
 	usage        interface{} `usage:"CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)]"`
 }
 
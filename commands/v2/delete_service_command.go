@@ -11,8 +11,9 @@ import (
 type DeleteServiceCommand struct {
 	RequiredArgs    flags.ServiceInstance `positional-args:"yes"`
 	Force           bool                  `short:"f" description:"Force deletion without confirmation"`
-	usage           interface{}           `usage:"CF_NAME delete-service SERVICE_INSTANCE [-f]"`
-	relatedCommands interface{}           `related_commands:"unbind-service, services"`
+	Wait            bool                  `long:"wait" description:"Wait until the operation has finished, and fail if it fails. The timeout is set with 'config --async-timeout' (Default: 60 minutes)"`
+	usage           interface{}           `usage:"CF_NAME delete-service SERVICE_INSTANCE [-f] [--wait]"`
+	relatedCommands interface{}           `related_commands:"unbind-service, services, wait-for-service"`
 }
 
 func (_ DeleteServiceCommand) Setup(config commands.Config, ui commands.UI) error {
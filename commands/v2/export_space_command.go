@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type ExportSpaceCommand struct {
+	RequiredArgs    flags.SpaceBundleDir `positional-args:"yes"`
+	usage           interface{}          `usage:"CF_NAME export-space DIR\n\nTIP:\n   DIR gets a space.json file describing the space, and an apps directory with a manifest for each app.\n   The bundle includes service credentials and environment variables, so store it securely.\n   Use 'CF_NAME import-space DIR' to recreate the space in the targeted space of any foundation."`
+	relatedCommands interface{}          `related_commands:"import-space, create-app-manifest, space"`
+}
+
+func (_ ExportSpaceCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ExportSpaceCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
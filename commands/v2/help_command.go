@@ -170,6 +170,12 @@ func (cmd HelpCommand) displayCommonCommands() {
 			"ENVName":     "-v",
 			"Description": "Print API request diagnostics to stdout",
 		})
+	cmd.UI.DisplayTextWithKeyTranslations(prefix+"{{.ENVName}}                 {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--output json|yaml",
+			"Description": "Print tables as structured records instead of columns",
+		})
 	cmd.UI.DisplayNewline()
 	cmd.UI.DisplayText("'cf help -a' lists all commands with short descriptions. See 'cf help <command>' to read about a specific command.")
 }
@@ -273,6 +279,12 @@ func (cmd HelpCommand) displayHelpFooter() {
 			"ENVName":     "-v",
 			"Description": "Print API request diagnostics to stdout",
 		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                 {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--output json|yaml",
+			"Description": "Print tables as structured records instead of columns",
+		})
 }
 
 func (cmd HelpCommand) displayCommand() error {
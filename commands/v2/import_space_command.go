@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type ImportSpaceCommand struct {
+	RequiredArgs    flags.SpaceBundleDir `positional-args:"yes"`
+	EnvVarGroups    bool                 `long:"env-var-groups" description:"Also set the running and staging environment variable groups, which apply to every app in the foundation"`
+	usage           interface{}          `usage:"CF_NAME import-space DIR [--env-var-groups]\n\nTIP:\n   Security groups, service instances, apps and routes that already exist are left as they are, so an import that failed can be run again.\n   Apps are created stopped and without bits. Push each app with its manifest from DIR/apps to start it."`
+	relatedCommands interface{}          `related_commands:"export-space, push, create-space, target"`
+}
+
+func (_ ImportSpaceCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ImportSpaceCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -4,7 +4,7 @@ var HelpCategoryList = []HelpCategory{
 	{
 		CategoryName: "GETTING STARTED:",
 		CommandList: [][]string{
-			{"help", "version", "login", "logout", "passwd", "target"},
+			{"help", "version", "login", "logout", "passwd", "target", "target-profile"},
 			{"api", "auth"},
 		},
 	},
@@ -12,12 +12,12 @@ var HelpCategoryList = []HelpCategory{
 		CategoryName: "APPS:",
 		CommandList: [][]string{
 			{"apps", "app"},
-			{"push", "scale", "delete", "rename"},
+			{"push", "scale", "autoscale", "delete", "rename"},
 			{"start", "stop", "restart", "restage", "restart-app-instance"},
 			{"events", "files", "logs"},
 			{"env", "set-env", "unset-env"},
 			{"stacks", "stack"},
-			{"copy-source", "create-app-manifest"},
+			{"copy-source", "create-app-manifest", "validate-manifest"},
 			{"get-health-check", "set-health-check", "enable-ssh", "disable-ssh", "ssh-enabled", "ssh"},
 		},
 	},
@@ -25,7 +25,7 @@ var HelpCategoryList = []HelpCategory{
 		CategoryName: "SERVICES:",
 		CommandList: [][]string{
 			{"marketplace", "services", "service"},
-			{"create-service", "update-service", "delete-service", "rename-service"},
+			{"create-service", "update-service", "delete-service", "rename-service", "wait-for-service"},
 			{"create-service-key", "service-keys", "service-key", "delete-service-key"},
 			{"bind-service", "unbind-service"},
 			{"bind-route-service", "unbind-route-service"},
@@ -36,14 +36,14 @@ var HelpCategoryList = []HelpCategory{
 		CategoryName: "ORGS:",
 		CommandList: [][]string{
 			{"orgs", "org"},
-			{"create-org", "delete-org", "rename-org"},
+			{"create-org", "delete-org", "rename-org", "apply-org-config"},
 		},
 	},
 	{
 		CategoryName: "SPACES:",
 		CommandList: [][]string{
 			{"spaces", "space"},
-			{"create-space", "delete-space", "rename-space"},
+			{"create-space", "delete-space", "rename-space", "export-space", "import-space"},
 			{"allow-space-ssh", "disallow-space-ssh", "space-ssh-allowed"},
 		},
 	},
@@ -102,7 +102,7 @@ var HelpCategoryList = []HelpCategory{
 	{
 		CategoryName: "SECURITY GROUP:",
 		CommandList: [][]string{
-			{"security-group", "security-groups", "create-security-group", "update-security-group", "delete-security-group", "bind-security-group", "unbind-security-group"},
+			{"security-group", "security-groups", "create-security-group", "update-security-group", "delete-security-group", "bind-security-group", "unbind-security-group", "security-group-check"},
 			{"bind-staging-security-group", "staging-security-groups", "unbind-staging-security-group"},
 			{"bind-running-security-group", "running-security-groups", "unbind-running-security-group"},
 		},
@@ -134,7 +134,7 @@ var HelpCategoryList = []HelpCategory{
 	{
 		CategoryName: "ADD/REMOVE PLUGIN:",
 		CommandList: [][]string{
-			{"plugins", "install-plugin", "uninstall-plugin"},
+			{"plugins", "install-plugin", "update-plugins", "uninstall-plugin"},
 		},
 	},
 }
@@ -9,10 +9,16 @@ import (
 )
 
 type LogsCommand struct {
-	RequiredArgs    flags.AppName `positional-args:"yes"`
-	Recent          bool          `long:"recent" description:"Dump recent logs instead of tailing"`
-	usage           interface{}   `usage:"CF_NAME logs APP_NAME"`
-	relatedCommands interface{}   `related_commands:"app, apps, ssh"`
+	OptionalArgs    flags.LogsArgs `positional-args:"yes"`
+	Recent          bool           `long:"recent" description:"Dump recent logs instead of tailing"`
+	Space           bool           `long:"space" description:"Show the logs of every app in the targeted space"`
+	Source          []string       `long:"source" description:"Only show logs from the given source type: APP, RTR, STG or CELL (can be given multiple times)"`
+	Instance        int            `long:"instance" description:"Only show logs from the app instance with the given index"`
+	Since           string         `long:"since" description:"With --recent, only show logs written after this time, given as a duration such as 15m or as an RFC 3339 timestamp"`
+	Until           string         `long:"until" description:"With --recent, only show logs written before this time, given as a duration such as 15m or as an RFC 3339 timestamp"`
+	Grep            string         `long:"grep" description:"Only show logs whose message matches the given regular expression"`
+	usage           interface{}    `usage:"CF_NAME logs APP_NAME [APP_NAME...] [--recent] [--source SOURCE_TYPE] [--instance INDEX] [--since TIME] [--until TIME] [--grep REGEX]\n   CF_NAME logs --space [--recent] [--source SOURCE_TYPE] [--instance INDEX] [--since TIME] [--until TIME] [--grep REGEX]\n\nTIP:\n   Use the global --output json flag to print each log message as a JSON object on a line of its own"`
+	relatedCommands interface{}    `related_commands:"app, apps, ssh"`
 }
 
 func (_ LogsCommand) Setup(config commands.Config, ui commands.UI) error {
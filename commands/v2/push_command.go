@@ -23,12 +23,20 @@ type PushCommand struct {
 	NoManifest           bool        `long:"no-manifest" description:"Ignore manifest file"`
 	NoRoute              bool        `long:"no-route" description:"Do not map a route to this app and remove routes from previous pushes of this app"`
 	NoStart              bool        `long:"no-start" description:"Do not start an app after pushing"`
+	HonorGitIgnore       bool        `long:"honor-gitignore" description:"Also exclude the files matched by .gitignore files from the upload"`
+	ShowIgnored          bool        `long:"show-ignored" description:"List the files excluded from the upload by .cfignore and .gitignore files"`
+	NoCache              bool        `long:"no-cache" description:"Ignore the local cache of file hashes and known resources, rechecking every app file"`
+	DryRun               bool        `long:"dry-run" description:"Show the changes the push would make without making them"`
 	DirectoryPath        string      `short:"p" description:"Path to app directory or to a zip file of the contents of the app directory"` //TODO: Custom Directory flag that does validation
 	RandomRoute          bool        `long:"random-route" description:"Create a random route for this app"`
 	RoutePath            string      `long:"route-path" description:"Path for the route"`
+	Parallel             int         `long:"parallel" description:"Number of apps from the manifest to upload and stage at the same time"`
+	Strategy             string      `long:"strategy" description:"Deployment strategy for existing apps, 'blue-green' replaces the app without downtime"`
+	Vars                 []string    `long:"var" description:"Variable key value pair for variable substitution in the manifest (e.g. name=app1), flag can be specified multiple times"`
+	VarsFiles            []string    `long:"vars-file" description:"Path to a YAML file with variables for substitution in the manifest, flag can be specified multiple times"`
 	Stack                string      `short:"s" description:"Stack to use (a stack is a pre-built file system, including an operating system, that can run apps)"`
 	ApplicationStartTime int         `short:"t" description:"Maximum time (in seconds) for CLI to wait for application start, other server side timeouts may apply"`
-	usage                interface{} `usage:"Push a single app (with or without a manifest):\n   CF_NAME push APP_NAME [-b BUILDPACK_NAME] [-c COMMAND] [-d DOMAIN] [-f MANIFEST_PATH] [--docker-image DOCKER_IMAGE]\n   [-i NUM_INSTANCES] [-k DISK] [-m MEMORY] [--hostname HOST] [-p PATH] [-s STACK] [-t TIMEOUT] [-u HEALTH_CHECK_TYPE] [--route-path ROUTE_PATH]\n   [--no-hostname] [--no-manifest] [--no-route] [--no-start] [--random-route]\n\n   Push multiple apps with a manifest:\n   cf push [-f MANIFEST_PATH]"`
+	usage                interface{} `usage:"Push a single app (with or without a manifest):\n   CF_NAME push APP_NAME [-b BUILDPACK_NAME] [-c COMMAND] [-d DOMAIN] [-f MANIFEST_PATH] [--docker-image DOCKER_IMAGE]\n   [-i NUM_INSTANCES] [-k DISK] [-m MEMORY] [--hostname HOST] [-p PATH] [-s STACK] [-t TIMEOUT] [-u HEALTH_CHECK_TYPE] [--route-path ROUTE_PATH]\n   [--strategy STRATEGY] [--var KEY=VALUE] [--vars-file VARS_FILE_PATH]\n   [--no-hostname] [--no-manifest] [--no-route] [--no-start] [--no-cache] [--random-route] [--dry-run]\n   [--honor-gitignore] [--show-ignored]\n\n   Push multiple apps with a manifest:\n   cf push [-f MANIFEST_PATH] [--var KEY=VALUE] [--vars-file VARS_FILE_PATH] [--parallel NUM_APPS]"`
 	relatedCommands      interface{} `related_commands:"apps, create-app-manifest, logs, ssh, start"`
 }
 
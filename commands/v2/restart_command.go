@@ -10,7 +10,9 @@ import (
 
 type RestartCommand struct {
 	RequiredArgs    flags.AppName `positional-args:"yes"`
-	usage           interface{}   `usage:"CF_NAME restart APP_NAME"`
+	Rolling         bool          `long:"rolling" description:"Restart the instances in batches, waiting for each batch to be running again before restarting the next"`
+	BatchSize       int           `long:"batch-size" description:"With --rolling, the number of instances to restart at a time (Default: 1)"`
+	usage           interface{}   `usage:"CF_NAME restart APP_NAME [--rolling [--batch-size NUM_INSTANCES]]"`
 	relatedCommands interface{}   `related_commands:"restage, restart-app-instance"`
 }
 
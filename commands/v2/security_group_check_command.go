@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type SecurityGroupCheckCommand struct {
+	RequiredArgs    flags.AppOrSpace `positional-args:"yes"`
+	Destination     string           `long:"destination" description:"Host or IPv4 address and port the traffic is sent to, such as db.example.com:5432"`
+	Protocol        string           `long:"protocol" description:"Protocol of the traffic, tcp or udp (Default: tcp)"`
+	usage           interface{}      `usage:"CF_NAME security-group-check APP_OR_SPACE --destination HOST:PORT [--protocol PROTOCOL]\n\nTIP:\n   The argument is looked up as an app in the targeted space, then as a space in the targeted org.\n   The running and staging default security groups are checked along with the groups bound to the space, and every rule that allows the traffic is listed."`
+	relatedCommands interface{}      `related_commands:"security-group, security-groups, running-security-groups, staging-security-groups"`
+}
+
+func (_ SecurityGroupCheckCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ SecurityGroupCheckCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
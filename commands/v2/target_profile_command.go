@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type TargetProfileCommand struct {
+	RequiredArgs    flags.TargetProfileArgs `positional-args:"yes"`
+	usage           interface{}             `usage:"CF_NAME target-profile save NAME\n   CF_NAME target-profile use NAME\n   CF_NAME target-profile list\n   CF_NAME target-profile delete NAME\n\nTIP:\n   A profile holds the API endpoint, tokens, SSL setting and targeted org and space. Set CF_PROFILE=NAME to use a profile for a single command without switching to it"`
+	relatedCommands interface{}             `related_commands:"api, login, target"`
+}
+
+func (_ TargetProfileCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ TargetProfileCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,27 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type UpdatePluginsCommand struct {
+	OptionalArgs    flags.UpdatePluginsArgs `positional-args:"yes"`
+	All             bool                    `long:"all" description:"Update all installed plugins"`
+	Force           bool                    `short:"f" description:"Force update of plugins without confirmation"`
+	AllowUnsigned   bool                    `long:"allow-unsigned" description:"Install the plugin even if its binary is not signed by a trusted publisher"`
+	usage           interface{}             `usage:"CF_NAME update-plugins (--all | PLUGIN_NAME) [-f] [--allow-unsigned]\n\n   Prompts for confirmation unless '-f' is provided. A plugin whose new version fails to start is restored to its installed version.\n\nEXAMPLES:\n   CF_NAME update-plugins --all\n   CF_NAME update-plugins plugin-echo -f"`
+	relatedCommands interface{}             `related_commands:"add-plugin-repo, install-plugin, plugins, repo-plugins"`
+}
+
+func (_ UpdatePluginsCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ UpdatePluginsCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+)
+
+type ValidateManifestCommand struct {
+	PathToManifest  string      `short:"f" description:"Path to manifest"`
+	Vars            []string    `long:"var" description:"Variable key value pair for variable substitution in the manifest (e.g. name=app1), flag can be specified multiple times"`
+	VarsFiles       []string    `long:"vars-file" description:"Path to a YAML file with variables for substitution in the manifest, flag can be specified multiple times"`
+	usage           interface{} `usage:"CF_NAME validate-manifest [-f MANIFEST_PATH] [--var KEY=VALUE] [--vars-file VARS_FILE_PATH]"`
+	relatedCommands interface{} `related_commands:"create-app-manifest, push"`
+}
+
+func (_ ValidateManifestCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ValidateManifestCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
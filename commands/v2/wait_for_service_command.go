@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type WaitForServiceCommand struct {
+	RequiredArgs    flags.ServiceInstance `positional-args:"yes"`
+	Timeout         int                   `long:"timeout" description:"Minutes to wait before failing (Default: the timeout set with 'config --async-timeout', or 60)"`
+	usage           interface{}           `usage:"CF_NAME wait-for-service SERVICE_INSTANCE [--timeout MINUTES]\n\nTIP:\n   The command fails when the operation fails or does not finish in time, so scripts can wait for a service instance to be provisioned before binding it.\n   Waiting for a delete succeeds once the service instance is gone."`
+	relatedCommands interface{}           `related_commands:"service, create-service, update-service, delete-service"`
+}
+
+func (_ WaitForServiceCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ WaitForServiceCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,58 @@
+/**
+	* A plugin with a persistent session, calling the CLI from several
+	* goroutines over a single connection.
+**/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/cli/plugin"
+)
+
+type PersistentSession struct {
+}
+
+func (c *PersistentSession) Run(cliConnection plugin.CliConnection, args []string) {
+	outputs := make([]string, 5)
+
+	var wg sync.WaitGroup
+	for i := range outputs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			output, err := cliConnection.CliCommandWithoutTerminalOutput("app", fmt.Sprintf("app-%d", i))
+			if err != nil {
+				outputs[i] = err.Error()
+				return
+			}
+			outputs[i] = strings.Join(output, " ")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, output := range outputs {
+		fmt.Println(output)
+	}
+}
+
+func (c *PersistentSession) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{
+		Name: "PersistentSession",
+		Commands: []plugin.Command{
+			{
+				Name:     "persistent-session",
+				HelpText: "calls the CLI concurrently over one connection",
+			},
+		},
+		PersistentSession: true,
+	}
+}
+
+func main() {
+	plugin.Start(new(PersistentSession))
+}
@@ -113,6 +113,10 @@ func executionWrapper(cmd flags.Commander, args []string) error {
 		if err != nil {
 			return err
 		}
+		// The tables of help lay out its text, they are not records.
+		if _, isHelp := cmd.(*v2.HelpCommand); !isHelp {
+			commandUI.OutputFormat = ui.OutputFormat(v2.Commands.Output)
+		}
 
 		err = extendedCmd.Setup(cfConfig, commandUI)
 		if err != nil {
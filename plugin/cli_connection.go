@@ -3,9 +3,11 @@ package plugin
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/rpc"
 	"os"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/plugin/models"
@@ -13,6 +15,12 @@ import (
 
 type cliConnection struct {
 	cliServerPort string
+
+	// persistent is set for plugins with PersistentSession in their
+	// metadata. All calls then share client, which is dialed on first use.
+	persistent  bool
+	client      *rpc.Client
+	clientMutex sync.Mutex
 }
 
 func NewCliConnection(cliServerPort string) *cliConnection {
@@ -22,6 +30,10 @@ func NewCliConnection(cliServerPort string) *cliConnection {
 }
 
 func (c *cliConnection) withClientDo(f func(client *rpc.Client) error) error {
+	if c.persistent {
+		return c.withPersistentClientDo(f)
+	}
+
 	client, err := rpc.Dial("tcp", "127.0.0.1:"+c.cliServerPort)
 	if err != nil {
 		return err
@@ -31,6 +43,45 @@ func (c *cliConnection) withClientDo(f func(client *rpc.Client) error) error {
 	return f(client)
 }
 
+// withPersistentClientDo calls f with the connection shared by all calls of
+// the session. A connection that was lost is dialed again on the next call;
+// the failed call is not retried, as it may not be safe to repeat.
+func (c *cliConnection) withPersistentClientDo(f func(client *rpc.Client) error) error {
+	c.clientMutex.Lock()
+	if c.client == nil {
+		client, err := rpc.Dial("tcp", "127.0.0.1:"+c.cliServerPort)
+		if err != nil {
+			c.clientMutex.Unlock()
+			return err
+		}
+		c.client = client
+	}
+	client := c.client
+	c.clientMutex.Unlock()
+
+	err := f(client)
+	if err == rpc.ErrShutdown || err == io.ErrUnexpectedEOF {
+		c.clientMutex.Lock()
+		if c.client == client {
+			c.client = nil
+		}
+		c.clientMutex.Unlock()
+		client.Close()
+	}
+
+	return err
+}
+
+func (c *cliConnection) closePersistentClient() {
+	c.clientMutex.Lock()
+	defer c.clientMutex.Unlock()
+
+	if c.client != nil {
+		c.client.Close()
+		c.client = nil
+	}
+}
+
 func (c *cliConnection) sendPluginMetadataToCliServer(metadata PluginMetadata) {
 	var success bool
 
@@ -74,6 +125,10 @@ func (c *cliConnection) CliCommand(args ...string) ([]string, error) {
 }
 
 func (c *cliConnection) callCliCommand(silently bool, args ...string) ([]string, error) {
+	if c.persistent {
+		return c.callCliCommandWithOutput(silently, args...)
+	}
+
 	var (
 		success                  bool
 		cmdOutput                []string
@@ -109,6 +164,25 @@ func (c *cliConnection) callCliCommand(silently bool, args ...string) ([]string,
 	return cmdOutput, nil
 }
 
+// callCliCommandWithOutput runs the command and gets its output in one call,
+// which keeps the output of concurrent commands apart.
+func (c *cliConnection) callCliCommandWithOutput(silently bool, args ...string) ([]string, error) {
+	var result plugin_models.CallCoreCommand_Result
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.CallCoreCommandWithOutput", plugin_models.CallCoreCommand_Args{Args: args, Silently: silently}, &result)
+	})
+	if err != nil {
+		return []string{}, err
+	}
+
+	if !result.Success {
+		return []string{}, errors.New("Error executing cli core command")
+	}
+
+	return result.Output, nil
+}
+
 func (c *cliConnection) pingCLI() {
 	//call back to cf saying we have been setup
 	var connErr error
@@ -381,3 +455,147 @@ func (c *cliConnection) GetService(serviceInstance string) (plugin_models.GetSer
 
 	return result, err
 }
+
+// StartApp starts the app and waits for it to run, like cf start. It and the
+// other app lifecycle methods return a *plugin_models.OperationError when the
+// operation fails.
+func (c *cliConnection) StartApp(appName string) error {
+	return c.callAppOperation("CliRpcCmd.StartApp", appName)
+}
+
+func (c *cliConnection) StopApp(appName string) error {
+	return c.callAppOperation("CliRpcCmd.StopApp", appName)
+}
+
+func (c *cliConnection) RestartApp(appName string) error {
+	return c.callAppOperation("CliRpcCmd.RestartApp", appName)
+}
+
+func (c *cliConnection) ScaleApp(appName string, options plugin_models.ScaleAppOptions) error {
+	return c.callAppOperation("CliRpcCmd.ScaleApp", plugin_models.ScaleApp_Args{AppName: appName, Options: options})
+}
+
+func (c *cliConnection) SetEnv(appName string, name string, value string) error {
+	return c.callAppOperation("CliRpcCmd.SetEnv", []string{appName, name, value})
+}
+
+func (c *cliConnection) BindService(appName string, serviceInstance string) error {
+	return c.callAppOperation("CliRpcCmd.BindService", []string{appName, serviceInstance})
+}
+
+func (c *cliConnection) UnbindService(appName string, serviceInstance string) error {
+	return c.callAppOperation("CliRpcCmd.UnbindService", []string{appName, serviceInstance})
+}
+
+func (c *cliConnection) MapRoute(appName string, domain string, options plugin_models.RouteOptions) error {
+	return c.callAppOperation("CliRpcCmd.MapRoute", plugin_models.Route_Args{AppName: appName, Domain: domain, Options: options})
+}
+
+func (c *cliConnection) UnmapRoute(appName string, domain string, options plugin_models.RouteOptions) error {
+	return c.callAppOperation("CliRpcCmd.UnmapRoute", plugin_models.Route_Args{AppName: appName, Domain: domain, Options: options})
+}
+
+func (c *cliConnection) callAppOperation(method string, args interface{}) error {
+	var result plugin_models.OperationResult
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call(method, args, &result)
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.Err != nil {
+		return result.Err
+	}
+
+	return nil
+}
+
+func (c *cliConnection) GetRecentLogs(appName string) ([]plugin_models.LogMessage, error) {
+	var result plugin_models.RecentLogs_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetRecentLogs", appName, &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return result.Messages, nil
+}
+
+// TailLogs streams the logs of the app until stop is closed or the stream
+// ends. The messages channel is closed when streaming stops. An error that
+// ends the stream is sent on the error channel first.
+func (c *cliConnection) TailLogs(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error, error) {
+	client, err := rpc.Dial("tcp", "127.0.0.1:"+c.cliServerPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result plugin_models.LogStream_Model
+	err = client.Call("CliRpcCmd.TailLogs", appName, &result)
+	if err == nil && result.Err != nil {
+		err = result.Err
+	}
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+
+	messages := make(chan plugin_models.LogMessage)
+	errs := make(chan error, 1)
+	go streamLogs(client, stop, messages, errs)
+
+	return messages, errs, nil
+}
+
+func streamLogs(client *rpc.Client, stop <-chan struct{}, messages chan<- plugin_models.LogMessage, errs chan<- error) {
+	defer client.Close()
+	defer close(errs)
+	defer close(messages)
+
+	stopLogs := func() {
+		var success bool
+		_ = client.Call("CliRpcCmd.StopLogs", "", &success)
+	}
+
+	for {
+		select {
+		case <-stop:
+			stopLogs()
+			return
+		default:
+		}
+
+		var batch plugin_models.LogStream_Model
+		err := client.Call("CliRpcCmd.NextLogs", "", &batch)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		for _, msg := range batch.Messages {
+			select {
+			case messages <- msg:
+			case <-stop:
+				stopLogs()
+				return
+			}
+		}
+
+		if batch.Err != nil {
+			errs <- batch.Err
+			return
+		}
+
+		if batch.Closed {
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package plugin_models
+
+// Codes of an OperationError. They do not change with the locale of the CLI.
+const (
+	OperationErrorInvalidArgument = "InvalidArgument"
+	OperationErrorNotLoggedIn     = "NotLoggedIn"
+	OperationErrorNoTargetedSpace = "NoTargetedSpace"
+	OperationErrorNotAuthorized   = "NotAuthorized"
+	OperationErrorNotFound        = "NotFound"
+	OperationErrorAlreadyExists   = "AlreadyExists"
+	OperationErrorAPIError        = "APIError"
+	OperationErrorNetworkError    = "NetworkError"
+	OperationErrorFailed          = "Failed"
+)
+
+// OperationError is returned by the app lifecycle methods of CliConnection
+// when the operation fails. Code is one of the OperationError constants.
+// StatusCode and APIErrorCode are set when the Cloud Controller or UAA
+// rejected a request. Message is the localized message the CLI would print.
+type OperationError struct {
+	Operation    string
+	Code         string
+	StatusCode   int
+	APIErrorCode string
+	ResourceType string
+	ResourceName string
+	Message      string
+}
+
+func (e *OperationError) Error() string {
+	return e.Message
+}
+
+// OperationResult is the reply of the app lifecycle RPC methods. Err is nil
+// when the operation succeeded.
+type OperationResult struct {
+	Err *OperationError
+}
+
+// ScaleAppOptions holds the new limits of an app. A nil Instances and empty
+// Memory and DiskQuota leave the current values unchanged. Memory and
+// DiskQuota take the same values as cf scale, e.g. 256M or 1G.
+type ScaleAppOptions struct {
+	Instances *int
+	Memory    string
+	DiskQuota string
+}
+
+type ScaleApp_Args struct {
+	AppName string
+	Options ScaleAppOptions
+}
+
+// RouteOptions identify a route on a domain. Hostname and Path are used for
+// HTTP routes, Port for TCP routes.
+type RouteOptions struct {
+	Hostname string
+	Path     string
+	Port     int
+}
+
+type Route_Args struct {
+	AppName string
+	Domain  string
+	Options RouteOptions
+}
@@ -0,0 +1,14 @@
+package plugin_models
+
+type CallCoreCommand_Args struct {
+	Args     []string
+	Silently bool
+}
+
+// CallCoreCommand_Result holds the output of a core command run by
+// CallCoreCommandWithOutput. Success is false when the command does not
+// exist or failed.
+type CallCoreCommand_Result struct {
+	Success bool
+	Output  []string
+}
@@ -0,0 +1,35 @@
+package plugin_models
+
+import "time"
+
+// Streams of a LogMessage.
+const (
+	LogStreamOut = "OUT"
+	LogStreamErr = "ERR"
+)
+
+// LogMessage is a log line of an app, as returned by GetRecentLogs and
+// TailLogs.
+type LogMessage struct {
+	AppGuid        string
+	SourceType     string
+	SourceInstance string
+	Timestamp      time.Time
+	// Stream is LogStreamOut or LogStreamErr.
+	Stream  string
+	Message string
+}
+
+// RecentLogs_Model is the reply of the GetRecentLogs RPC method.
+type RecentLogs_Model struct {
+	Messages []LogMessage
+	Err      *OperationError
+}
+
+// LogStream_Model is the reply of the TailLogs and NextLogs RPC methods.
+// Closed is set once the stream has ended and has no more messages.
+type LogStream_Model struct {
+	Messages []LogMessage
+	Closed   bool
+	Err      *OperationError
+}
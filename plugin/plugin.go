@@ -42,6 +42,17 @@ type CliConnection interface {
 	GetService(string) (plugin_models.GetService_Model, error)
 	GetOrg(string) (plugin_models.GetOrg_Model, error)
 	GetSpace(string) (plugin_models.GetSpace_Model, error)
+	StartApp(string) error
+	StopApp(string) error
+	RestartApp(string) error
+	ScaleApp(string, plugin_models.ScaleAppOptions) error
+	SetEnv(string, string, string) error
+	BindService(string, string) error
+	UnbindService(string, string) error
+	MapRoute(string, string, plugin_models.RouteOptions) error
+	UnmapRoute(string, string, plugin_models.RouteOptions) error
+	GetRecentLogs(string) ([]plugin_models.LogMessage, error)
+	TailLogs(string, <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error, error)
 }
 
 type VersionType struct {
@@ -55,6 +66,10 @@ type PluginMetadata struct {
 	Version       VersionType
 	MinCliVersion VersionType
 	Commands      []Command
+
+	// PersistentSession makes the CliConnection keep one connection to the
+	// CLI for all its calls, which may then be made from several goroutines.
+	PersistentSession bool
 }
 
 type Usage struct {
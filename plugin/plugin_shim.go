@@ -27,6 +27,9 @@ func Start(cmd Plugin) {
 			}
 		}
 
+		cliConnection.persistent = cmd.GetMetadata().PersistentSession
+		defer cliConnection.closePersistentClient()
+
 		cmd.Run(cliConnection, os.Args[2:])
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/plugin/models"
 	"code.cloudfoundry.org/cli/testhelpers/rpcserver"
 	"code.cloudfoundry.org/cli/testhelpers/rpcserver/rpcserverfakes"
 	. "github.com/onsi/ginkgo"
@@ -80,6 +81,37 @@ var _ = Describe("Command", func() {
 					})
 				})
 			})
+
+			Context("when the plugin declares a persistent session", func() {
+				var persistentPluginPath = filepath.Join("..", "fixtures", "plugins", "persistent_session.exe")
+
+				BeforeEach(func() {
+					rpcHandlers.CallCoreCommandWithOutputStub = func(args plugin_models.CallCoreCommand_Args, result *plugin_models.CallCoreCommand_Result) error {
+						result.Success = true
+						result.Output = []string{"output of", args.Args[1]}
+						return nil
+					}
+				})
+
+				It("makes all calls over one connection and keeps the output of concurrent commands apart", func() {
+					args := []string{ts.Port(), "persistent-session"}
+					session, err := Start(exec.Command(persistentPluginPath, args...), GinkgoWriter, GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(session, 5).Should(Exit(0))
+					Expect(session).To(gbytes.Say("output of app-0\noutput of app-1\noutput of app-2\noutput of app-3\noutput of app-4"))
+
+					Expect(rpcHandlers.CallCoreCommandWithOutputCallCount()).To(Equal(5))
+					Expect(rpcHandlers.CallCoreCommandCallCount()).To(Equal(0))
+					for i := 0; i < 5; i++ {
+						callArgs, _ := rpcHandlers.CallCoreCommandWithOutputArgsForCall(i)
+						Expect(callArgs.Silently).To(BeTrue())
+					}
+
+					// one connection to ping the CLI and one for the session
+					Expect(ts.ConnectionCount()).To(Equal(2))
+				})
+			})
 		})
 	})
 
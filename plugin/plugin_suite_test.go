@@ -13,5 +13,6 @@ import (
 func TestPlugin(t *testing.T) {
 	RegisterFailHandler(Fail)
 	pluginbuilder.BuildTestBinary(filepath.Join("..", "fixtures", "plugins"), "test_1")
+	pluginbuilder.BuildTestBinary(filepath.Join("..", "fixtures", "plugins"), "persistent_session")
 	RunSpecs(t, "Plugin Suite")
 }
@@ -216,6 +216,107 @@ type FakeCliConnection struct {
 		result1 plugin_models.GetSpace_Model
 		result2 error
 	}
+	StartAppStub        func(arg1 string) error
+	startAppMutex       sync.RWMutex
+	startAppArgsForCall []struct {
+		arg1 string
+	}
+	startAppReturns struct {
+		result1 error
+	}
+	StopAppStub        func(arg1 string) error
+	stopAppMutex       sync.RWMutex
+	stopAppArgsForCall []struct {
+		arg1 string
+	}
+	stopAppReturns struct {
+		result1 error
+	}
+	RestartAppStub        func(arg1 string) error
+	restartAppMutex       sync.RWMutex
+	restartAppArgsForCall []struct {
+		arg1 string
+	}
+	restartAppReturns struct {
+		result1 error
+	}
+	ScaleAppStub        func(arg1 string, arg2 plugin_models.ScaleAppOptions) error
+	scaleAppMutex       sync.RWMutex
+	scaleAppArgsForCall []struct {
+		arg1 string
+		arg2 plugin_models.ScaleAppOptions
+	}
+	scaleAppReturns struct {
+		result1 error
+	}
+	SetEnvStub        func(arg1 string, arg2 string, arg3 string) error
+	setEnvMutex       sync.RWMutex
+	setEnvArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	setEnvReturns struct {
+		result1 error
+	}
+	BindServiceStub        func(arg1 string, arg2 string) error
+	bindServiceMutex       sync.RWMutex
+	bindServiceArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	bindServiceReturns struct {
+		result1 error
+	}
+	UnbindServiceStub        func(arg1 string, arg2 string) error
+	unbindServiceMutex       sync.RWMutex
+	unbindServiceArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	unbindServiceReturns struct {
+		result1 error
+	}
+	MapRouteStub        func(arg1 string, arg2 string, arg3 plugin_models.RouteOptions) error
+	mapRouteMutex       sync.RWMutex
+	mapRouteArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 plugin_models.RouteOptions
+	}
+	mapRouteReturns struct {
+		result1 error
+	}
+	UnmapRouteStub        func(arg1 string, arg2 string, arg3 plugin_models.RouteOptions) error
+	unmapRouteMutex       sync.RWMutex
+	unmapRouteArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 plugin_models.RouteOptions
+	}
+	unmapRouteReturns struct {
+		result1 error
+	}
+	GetRecentLogsStub        func(appName string) ([]plugin_models.LogMessage, error)
+	getRecentLogsMutex       sync.RWMutex
+	getRecentLogsArgsForCall []struct {
+		appName string
+	}
+	getRecentLogsReturns struct {
+		result1 []plugin_models.LogMessage
+		result2 error
+	}
+	TailLogsStub        func(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error, error)
+	tailLogsMutex       sync.RWMutex
+	tailLogsArgsForCall []struct {
+		appName string
+		stop    <-chan struct{}
+	}
+	tailLogsReturns struct {
+		result1 <-chan plugin_models.LogMessage
+		result2 <-chan error
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -988,6 +1089,382 @@ func (fake *FakeCliConnection) GetSpaceReturns(result1 plugin_models.GetSpace_Mo
 	}{result1, result2}
 }
 
+func (fake *FakeCliConnection) StartApp(arg1 string) error {
+	fake.startAppMutex.Lock()
+	fake.startAppArgsForCall = append(fake.startAppArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("StartApp", []interface{}{arg1})
+	fake.startAppMutex.Unlock()
+	if fake.StartAppStub != nil {
+		return fake.StartAppStub(arg1)
+	} else {
+		return fake.startAppReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) StartAppCallCount() int {
+	fake.startAppMutex.RLock()
+	defer fake.startAppMutex.RUnlock()
+	return len(fake.startAppArgsForCall)
+}
+
+func (fake *FakeCliConnection) StartAppArgsForCall(i int) string {
+	fake.startAppMutex.RLock()
+	defer fake.startAppMutex.RUnlock()
+	return fake.startAppArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) StartAppReturns(result1 error) {
+	fake.StartAppStub = nil
+	fake.startAppReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCliConnection) StopApp(arg1 string) error {
+	fake.stopAppMutex.Lock()
+	fake.stopAppArgsForCall = append(fake.stopAppArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("StopApp", []interface{}{arg1})
+	fake.stopAppMutex.Unlock()
+	if fake.StopAppStub != nil {
+		return fake.StopAppStub(arg1)
+	} else {
+		return fake.stopAppReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) StopAppCallCount() int {
+	fake.stopAppMutex.RLock()
+	defer fake.stopAppMutex.RUnlock()
+	return len(fake.stopAppArgsForCall)
+}
+
+func (fake *FakeCliConnection) StopAppArgsForCall(i int) string {
+	fake.stopAppMutex.RLock()
+	defer fake.stopAppMutex.RUnlock()
+	return fake.stopAppArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) StopAppReturns(result1 error) {
+	fake.StopAppStub = nil
+	fake.stopAppReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCliConnection) RestartApp(arg1 string) error {
+	fake.restartAppMutex.Lock()
+	fake.restartAppArgsForCall = append(fake.restartAppArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RestartApp", []interface{}{arg1})
+	fake.restartAppMutex.Unlock()
+	if fake.RestartAppStub != nil {
+		return fake.RestartAppStub(arg1)
+	} else {
+		return fake.restartAppReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) RestartAppCallCount() int {
+	fake.restartAppMutex.RLock()
+	defer fake.restartAppMutex.RUnlock()
+	return len(fake.restartAppArgsForCall)
+}
+
+func (fake *FakeCliConnection) RestartAppArgsForCall(i int) string {
+	fake.restartAppMutex.RLock()
+	defer fake.restartAppMutex.RUnlock()
+	return fake.restartAppArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) RestartAppReturns(result1 error) {
+	fake.RestartAppStub = nil
+	fake.restartAppReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCliConnection) ScaleApp(arg1 string, arg2 plugin_models.ScaleAppOptions) error {
+	fake.scaleAppMutex.Lock()
+	fake.scaleAppArgsForCall = append(fake.scaleAppArgsForCall, struct {
+		arg1 string
+		arg2 plugin_models.ScaleAppOptions
+	}{arg1, arg2})
+	fake.recordInvocation("ScaleApp", []interface{}{arg1, arg2})
+	fake.scaleAppMutex.Unlock()
+	if fake.ScaleAppStub != nil {
+		return fake.ScaleAppStub(arg1, arg2)
+	} else {
+		return fake.scaleAppReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) ScaleAppCallCount() int {
+	fake.scaleAppMutex.RLock()
+	defer fake.scaleAppMutex.RUnlock()
+	return len(fake.scaleAppArgsForCall)
+}
+
+func (fake *FakeCliConnection) ScaleAppArgsForCall(i int) (string, plugin_models.ScaleAppOptions) {
+	fake.scaleAppMutex.RLock()
+	defer fake.scaleAppMutex.RUnlock()
+	return fake.scaleAppArgsForCall[i].arg1, fake.scaleAppArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnection) ScaleAppReturns(result1 error) {
+	fake.ScaleAppStub = nil
+	fake.scaleAppReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCliConnection) SetEnv(arg1 string, arg2 string, arg3 string) error {
+	fake.setEnvMutex.Lock()
+	fake.setEnvArgsForCall = append(fake.setEnvArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SetEnv", []interface{}{arg1, arg2, arg3})
+	fake.setEnvMutex.Unlock()
+	if fake.SetEnvStub != nil {
+		return fake.SetEnvStub(arg1, arg2, arg3)
+	} else {
+		return fake.setEnvReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) SetEnvCallCount() int {
+	fake.setEnvMutex.RLock()
+	defer fake.setEnvMutex.RUnlock()
+	return len(fake.setEnvArgsForCall)
+}
+
+func (fake *FakeCliConnection) SetEnvArgsForCall(i int) (string, string, string) {
+	fake.setEnvMutex.RLock()
+	defer fake.setEnvMutex.RUnlock()
+	return fake.setEnvArgsForCall[i].arg1, fake.setEnvArgsForCall[i].arg2, fake.setEnvArgsForCall[i].arg3
+}
+
+func (fake *FakeCliConnection) SetEnvReturns(result1 error) {
+	fake.SetEnvStub = nil
+	fake.setEnvReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCliConnection) BindService(arg1 string, arg2 string) error {
+	fake.bindServiceMutex.Lock()
+	fake.bindServiceArgsForCall = append(fake.bindServiceArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("BindService", []interface{}{arg1, arg2})
+	fake.bindServiceMutex.Unlock()
+	if fake.BindServiceStub != nil {
+		return fake.BindServiceStub(arg1, arg2)
+	} else {
+		return fake.bindServiceReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) BindServiceCallCount() int {
+	fake.bindServiceMutex.RLock()
+	defer fake.bindServiceMutex.RUnlock()
+	return len(fake.bindServiceArgsForCall)
+}
+
+func (fake *FakeCliConnection) BindServiceArgsForCall(i int) (string, string) {
+	fake.bindServiceMutex.RLock()
+	defer fake.bindServiceMutex.RUnlock()
+	return fake.bindServiceArgsForCall[i].arg1, fake.bindServiceArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnection) BindServiceReturns(result1 error) {
+	fake.BindServiceStub = nil
+	fake.bindServiceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCliConnection) UnbindService(arg1 string, arg2 string) error {
+	fake.unbindServiceMutex.Lock()
+	fake.unbindServiceArgsForCall = append(fake.unbindServiceArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("UnbindService", []interface{}{arg1, arg2})
+	fake.unbindServiceMutex.Unlock()
+	if fake.UnbindServiceStub != nil {
+		return fake.UnbindServiceStub(arg1, arg2)
+	} else {
+		return fake.unbindServiceReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) UnbindServiceCallCount() int {
+	fake.unbindServiceMutex.RLock()
+	defer fake.unbindServiceMutex.RUnlock()
+	return len(fake.unbindServiceArgsForCall)
+}
+
+func (fake *FakeCliConnection) UnbindServiceArgsForCall(i int) (string, string) {
+	fake.unbindServiceMutex.RLock()
+	defer fake.unbindServiceMutex.RUnlock()
+	return fake.unbindServiceArgsForCall[i].arg1, fake.unbindServiceArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnection) UnbindServiceReturns(result1 error) {
+	fake.UnbindServiceStub = nil
+	fake.unbindServiceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCliConnection) MapRoute(arg1 string, arg2 string, arg3 plugin_models.RouteOptions) error {
+	fake.mapRouteMutex.Lock()
+	fake.mapRouteArgsForCall = append(fake.mapRouteArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 plugin_models.RouteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("MapRoute", []interface{}{arg1, arg2, arg3})
+	fake.mapRouteMutex.Unlock()
+	if fake.MapRouteStub != nil {
+		return fake.MapRouteStub(arg1, arg2, arg3)
+	} else {
+		return fake.mapRouteReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) MapRouteCallCount() int {
+	fake.mapRouteMutex.RLock()
+	defer fake.mapRouteMutex.RUnlock()
+	return len(fake.mapRouteArgsForCall)
+}
+
+func (fake *FakeCliConnection) MapRouteArgsForCall(i int) (string, string, plugin_models.RouteOptions) {
+	fake.mapRouteMutex.RLock()
+	defer fake.mapRouteMutex.RUnlock()
+	return fake.mapRouteArgsForCall[i].arg1, fake.mapRouteArgsForCall[i].arg2, fake.mapRouteArgsForCall[i].arg3
+}
+
+func (fake *FakeCliConnection) MapRouteReturns(result1 error) {
+	fake.MapRouteStub = nil
+	fake.mapRouteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCliConnection) UnmapRoute(arg1 string, arg2 string, arg3 plugin_models.RouteOptions) error {
+	fake.unmapRouteMutex.Lock()
+	fake.unmapRouteArgsForCall = append(fake.unmapRouteArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 plugin_models.RouteOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("UnmapRoute", []interface{}{arg1, arg2, arg3})
+	fake.unmapRouteMutex.Unlock()
+	if fake.UnmapRouteStub != nil {
+		return fake.UnmapRouteStub(arg1, arg2, arg3)
+	} else {
+		return fake.unmapRouteReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) UnmapRouteCallCount() int {
+	fake.unmapRouteMutex.RLock()
+	defer fake.unmapRouteMutex.RUnlock()
+	return len(fake.unmapRouteArgsForCall)
+}
+
+func (fake *FakeCliConnection) UnmapRouteArgsForCall(i int) (string, string, plugin_models.RouteOptions) {
+	fake.unmapRouteMutex.RLock()
+	defer fake.unmapRouteMutex.RUnlock()
+	return fake.unmapRouteArgsForCall[i].arg1, fake.unmapRouteArgsForCall[i].arg2, fake.unmapRouteArgsForCall[i].arg3
+}
+
+func (fake *FakeCliConnection) UnmapRouteReturns(result1 error) {
+	fake.UnmapRouteStub = nil
+	fake.unmapRouteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCliConnection) GetRecentLogs(appName string) ([]plugin_models.LogMessage, error) {
+	fake.getRecentLogsMutex.Lock()
+	fake.getRecentLogsArgsForCall = append(fake.getRecentLogsArgsForCall, struct {
+		appName string
+	}{appName})
+	fake.recordInvocation("GetRecentLogs", []interface{}{appName})
+	fake.getRecentLogsMutex.Unlock()
+	if fake.GetRecentLogsStub != nil {
+		return fake.GetRecentLogsStub(appName)
+	} else {
+		return fake.getRecentLogsReturns.result1, fake.getRecentLogsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetRecentLogsCallCount() int {
+	fake.getRecentLogsMutex.RLock()
+	defer fake.getRecentLogsMutex.RUnlock()
+	return len(fake.getRecentLogsArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetRecentLogsArgsForCall(i int) string {
+	fake.getRecentLogsMutex.RLock()
+	defer fake.getRecentLogsMutex.RUnlock()
+	return fake.getRecentLogsArgsForCall[i].appName
+}
+
+func (fake *FakeCliConnection) GetRecentLogsReturns(result1 []plugin_models.LogMessage, result2 error) {
+	fake.GetRecentLogsStub = nil
+	fake.getRecentLogsReturns = struct {
+		result1 []plugin_models.LogMessage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) TailLogs(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error, error) {
+	fake.tailLogsMutex.Lock()
+	fake.tailLogsArgsForCall = append(fake.tailLogsArgsForCall, struct {
+		appName string
+		stop    <-chan struct{}
+	}{appName, stop})
+	fake.recordInvocation("TailLogs", []interface{}{appName, stop})
+	fake.tailLogsMutex.Unlock()
+	if fake.TailLogsStub != nil {
+		return fake.TailLogsStub(appName, stop)
+	} else {
+		return fake.tailLogsReturns.result1, fake.tailLogsReturns.result2, fake.tailLogsReturns.result3
+	}
+}
+
+func (fake *FakeCliConnection) TailLogsCallCount() int {
+	fake.tailLogsMutex.RLock()
+	defer fake.tailLogsMutex.RUnlock()
+	return len(fake.tailLogsArgsForCall)
+}
+
+func (fake *FakeCliConnection) TailLogsArgsForCall(i int) (string, <-chan struct{}) {
+	fake.tailLogsMutex.RLock()
+	defer fake.tailLogsMutex.RUnlock()
+	return fake.tailLogsArgsForCall[i].appName, fake.tailLogsArgsForCall[i].stop
+}
+
+func (fake *FakeCliConnection) TailLogsReturns(result1 <-chan plugin_models.LogMessage, result2 <-chan error, result3 error) {
+	fake.TailLogsStub = nil
+	fake.tailLogsReturns = struct {
+		result1 <-chan plugin_models.LogMessage
+		result2 <-chan error
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeCliConnection) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1045,6 +1522,28 @@ func (fake *FakeCliConnection) Invocations() map[string][][]interface{} {
 	defer fake.getOrgMutex.RUnlock()
 	fake.getSpaceMutex.RLock()
 	defer fake.getSpaceMutex.RUnlock()
+	fake.startAppMutex.RLock()
+	defer fake.startAppMutex.RUnlock()
+	fake.stopAppMutex.RLock()
+	defer fake.stopAppMutex.RUnlock()
+	fake.restartAppMutex.RLock()
+	defer fake.restartAppMutex.RUnlock()
+	fake.scaleAppMutex.RLock()
+	defer fake.scaleAppMutex.RUnlock()
+	fake.setEnvMutex.RLock()
+	defer fake.setEnvMutex.RUnlock()
+	fake.bindServiceMutex.RLock()
+	defer fake.bindServiceMutex.RUnlock()
+	fake.unbindServiceMutex.RLock()
+	defer fake.unbindServiceMutex.RUnlock()
+	fake.mapRouteMutex.RLock()
+	defer fake.mapRouteMutex.RUnlock()
+	fake.unmapRouteMutex.RLock()
+	defer fake.unmapRouteMutex.RUnlock()
+	fake.getRecentLogsMutex.RLock()
+	defer fake.getRecentLogsMutex.RUnlock()
+	fake.tailLogsMutex.RLock()
+	defer fake.tailLogsMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,220 @@
+package rpc
+
+import (
+	"os"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/formatters"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/plugin/models"
+)
+
+func (cmd *CliRpcCmd) StartApp(appName string, retVal *plugin_models.OperationResult) error {
+	*retVal = cmd.runAppOperation(appName, []string{"start", appName})
+	return nil
+}
+
+func (cmd *CliRpcCmd) StopApp(appName string, retVal *plugin_models.OperationResult) error {
+	*retVal = cmd.runAppOperation(appName, []string{"stop", appName})
+	return nil
+}
+
+func (cmd *CliRpcCmd) RestartApp(appName string, retVal *plugin_models.OperationResult) error {
+	*retVal = cmd.runAppOperation(appName, []string{"restart", appName})
+	return nil
+}
+
+func (cmd *CliRpcCmd) ScaleApp(args plugin_models.ScaleApp_Args, retVal *plugin_models.OperationResult) error {
+	options := args.Options
+	if options.Instances == nil && options.Memory == "" && options.DiskQuota == "" {
+		*retVal = invalidArgument("scale", T("Requires at least one of instances, memory or disk quota"))
+		return nil
+	}
+
+	cmdArgs := []string{"scale", args.AppName, "-f"}
+	if options.Instances != nil {
+		cmdArgs = append(cmdArgs, "-i", strconv.Itoa(*options.Instances))
+	}
+	for _, limit := range []struct{ flag, value string }{{"-m", options.Memory}, {"-k", options.DiskQuota}} {
+		if limit.value == "" {
+			continue
+		}
+		if _, err := formatters.ToMegabytes(limit.value); err != nil {
+			*retVal = invalidArgument("scale", T("Invalid byte quantity {{.Value}}", map[string]interface{}{"Value": limit.value}))
+			return nil
+		}
+		cmdArgs = append(cmdArgs, limit.flag, limit.value)
+	}
+
+	*retVal = cmd.runAppOperation(args.AppName, cmdArgs)
+	return nil
+}
+
+// SetEnv takes the app name, the variable name and its value.
+func (cmd *CliRpcCmd) SetEnv(args []string, retVal *plugin_models.OperationResult) error {
+	if len(args) != 3 || args[1] == "" {
+		*retVal = invalidArgument("set-env", T("Requires an app name, a variable name and a value"))
+		return nil
+	}
+
+	*retVal = cmd.runAppOperation(args[0], append([]string{"set-env"}, args...))
+	return nil
+}
+
+// BindService takes the app name and the service instance name.
+func (cmd *CliRpcCmd) BindService(args []string, retVal *plugin_models.OperationResult) error {
+	if len(args) != 2 || args[1] == "" {
+		*retVal = invalidArgument("bind-service", T("Requires an app name and a service instance name"))
+		return nil
+	}
+
+	*retVal = cmd.runAppOperation(args[0], append([]string{"bind-service"}, args...))
+	return nil
+}
+
+// UnbindService takes the app name and the service instance name.
+func (cmd *CliRpcCmd) UnbindService(args []string, retVal *plugin_models.OperationResult) error {
+	if len(args) != 2 || args[1] == "" {
+		*retVal = invalidArgument("unbind-service", T("Requires an app name and a service instance name"))
+		return nil
+	}
+
+	*retVal = cmd.runAppOperation(args[0], append([]string{"unbind-service"}, args...))
+	return nil
+}
+
+func (cmd *CliRpcCmd) MapRoute(args plugin_models.Route_Args, retVal *plugin_models.OperationResult) error {
+	*retVal = cmd.runRouteOperation("map-route", args)
+	return nil
+}
+
+func (cmd *CliRpcCmd) UnmapRoute(args plugin_models.Route_Args, retVal *plugin_models.OperationResult) error {
+	*retVal = cmd.runRouteOperation("unmap-route", args)
+	return nil
+}
+
+func (cmd *CliRpcCmd) runRouteOperation(operation string, args plugin_models.Route_Args) plugin_models.OperationResult {
+	if args.Domain == "" {
+		return invalidArgument(operation, T("Requires a domain"))
+	}
+
+	cmdArgs := []string{operation, args.AppName, args.Domain}
+	if args.Options.Hostname != "" {
+		cmdArgs = append(cmdArgs, "--hostname", args.Options.Hostname)
+	}
+	if args.Options.Path != "" {
+		cmdArgs = append(cmdArgs, "--path", args.Options.Path)
+	}
+	if args.Options.Port != 0 {
+		cmdArgs = append(cmdArgs, "--port", strconv.Itoa(args.Options.Port))
+	}
+
+	return cmd.runAppOperation(args.AppName, cmdArgs)
+}
+
+// runAppOperation runs the core command in args against the app without
+// terminal output, and turns its failure into an OperationError.
+func (cmd *CliRpcCmd) runAppOperation(appName string, args []string) plugin_models.OperationResult {
+	operation := args[0]
+
+	if appName == "" {
+		return invalidArgument(operation, T("Requires an app name"))
+	}
+
+	if opErr := cmd.checkTargetedSpace(operation); opErr != nil {
+		return plugin_models.OperationResult{Err: opErr}
+	}
+
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
+	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+
+	//set deps objs to be the one used by all other commands
+	//once all commands are converted, we can make fresh deps for each command run
+	deps.Config = cmd.cliConfig
+	deps.RepoLocator = cmd.repoLocator
+	cmd.terminalOutputSwitch.DisableTerminalOutput(true)
+	deps.UI = terminal.NewUI(os.Stdin, cmd.stdout, cmd.terminalOutputSwitch.(*terminal.TeePrinter), cmd.logger)
+
+	err := cmd.newCmdRunner.Command(args, deps, true)
+	if err != nil {
+		return plugin_models.OperationResult{Err: newOperationError(operation, err)}
+	}
+
+	return plugin_models.OperationResult{}
+}
+
+// checkTargetedSpace returns an OperationError unless the user is logged in
+// and has targeted a space.
+func (cmd *CliRpcCmd) checkTargetedSpace(operation string) *plugin_models.OperationError {
+	if !cmd.cliConfig.IsLoggedIn() {
+		return &plugin_models.OperationError{
+			Operation: operation,
+			Code:      plugin_models.OperationErrorNotLoggedIn,
+			Message:   terminal.NotLoggedInText(),
+		}
+	}
+
+	if err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute(); err != nil {
+		return &plugin_models.OperationError{
+			Operation: operation,
+			Code:      plugin_models.OperationErrorNoTargetedSpace,
+			Message:   err.Error(),
+		}
+	}
+
+	return nil
+}
+
+func invalidArgument(operation string, message string) plugin_models.OperationResult {
+	return plugin_models.OperationResult{Err: &plugin_models.OperationError{
+		Operation: operation,
+		Code:      plugin_models.OperationErrorInvalidArgument,
+		Message:   message,
+	}}
+}
+
+func newOperationError(operation string, err error) *plugin_models.OperationError {
+	opErr := &plugin_models.OperationError{
+		Operation: operation,
+		Code:      plugin_models.OperationErrorFailed,
+		Message:   err.Error(),
+	}
+
+	switch typedErr := err.(type) {
+	case *errors.ModelNotFoundError:
+		opErr.Code = plugin_models.OperationErrorNotFound
+		opErr.ResourceType = typedErr.ModelType
+		opErr.ResourceName = typedErr.ModelName
+	case *errors.ModelAlreadyExistsError:
+		opErr.Code = plugin_models.OperationErrorAlreadyExists
+		opErr.ResourceType = typedErr.ModelType
+		opErr.ResourceName = typedErr.ModelName
+	case *errors.InvalidTokenError:
+		opErr.Code = plugin_models.OperationErrorNotLoggedIn
+	case *errors.NotAuthorizedError, *errors.AccessDeniedError:
+		opErr.Code = plugin_models.OperationErrorNotAuthorized
+		opErr.StatusCode = 403
+	case *errors.NetworkError, *errors.InvalidSSLCert:
+		opErr.Code = plugin_models.OperationErrorNetworkError
+	case errors.HTTPError:
+		opErr.Code = plugin_models.OperationErrorAPIError
+		opErr.StatusCode = typedErr.StatusCode()
+		opErr.APIErrorCode = typedErr.ErrorCode()
+		switch typedErr.StatusCode() {
+		case 401:
+			opErr.Code = plugin_models.OperationErrorNotLoggedIn
+		case 403:
+			opErr.Code = plugin_models.OperationErrorNotAuthorized
+		case 404:
+			opErr.Code = plugin_models.OperationErrorNotFound
+		}
+	}
+
+	return opErr
+}
@@ -0,0 +1,261 @@
+package rpc_test
+
+import (
+	"net/rpc"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/plugin/models"
+	. "code.cloudfoundry.org/cli/plugin/rpc"
+	"code.cloudfoundry.org/cli/plugin/rpc/rpcfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("App lifecycle API", func() {
+	var (
+		client *rpc.Client
+		runner *rpcfakes.FakeCommandRunner
+		config coreconfig.Repository
+		result plugin_models.OperationResult
+	)
+
+	BeforeEach(func() {
+		rpc.DefaultServer = rpc.NewServer()
+		runner = new(rpcfakes.FakeCommandRunner)
+		config = testconfig.NewRepositoryWithDefaults()
+		result = plugin_models.OperationResult{}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		outputCapture := terminal.NewTeePrinter(os.Stdout)
+		terminalOutputSwitch := terminal.NewTeePrinter(os.Stdout)
+
+		rpcService, err = NewRpcService(outputCapture, terminalOutputSwitch, config, api.RepositoryLocator{}, runner, nil, nil, rpc.DefaultServer)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = rpcService.Start()
+		Expect(err).ToNot(HaveOccurred())
+
+		pingCli(rpcService.Port())
+
+		client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		client.Close()
+		rpcService.Stop()
+
+		//give time for server to stop
+		time.Sleep(50 * time.Millisecond)
+	})
+
+	Describe("StartApp", func() {
+		It("runs start with the app name", func() {
+			err := client.Call("CliRpcCmd.StartApp", "my-app", &result)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Err).To(BeNil())
+
+			Expect(runner.CommandCallCount()).To(Equal(1))
+			args, _, pluginApiCall := runner.CommandArgsForCall(0)
+			Expect(args).To(Equal([]string{"start", "my-app"}))
+			Expect(pluginApiCall).To(BeTrue())
+		})
+
+		It("returns an invalid argument error without an app name", func() {
+			err := client.Call("CliRpcCmd.StartApp", "", &result)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorInvalidArgument))
+			Expect(result.Err.Operation).To(Equal("start"))
+			Expect(runner.CommandCallCount()).To(Equal(0))
+		})
+
+		Context("when the user is not logged in", func() {
+			BeforeEach(func() {
+				config = testconfig.NewRepository()
+			})
+
+			It("returns a not logged in error without running the command", func() {
+				err := client.Call("CliRpcCmd.StartApp", "my-app", &result)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorNotLoggedIn))
+				Expect(result.Err.Message).To(ContainSubstring("Not logged in."))
+				Expect(runner.CommandCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when no space is targeted", func() {
+			BeforeEach(func() {
+				config.SetSpaceFields(models.SpaceFields{})
+			})
+
+			It("returns a no targeted space error without running the command", func() {
+				err := client.Call("CliRpcCmd.StartApp", "my-app", &result)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorNoTargetedSpace))
+				Expect(runner.CommandCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the app does not exist", func() {
+			BeforeEach(func() {
+				runner.CommandReturns(errors.NewModelNotFoundError("App", "my-app"))
+			})
+
+			It("returns a not found error naming the app", func() {
+				err := client.Call("CliRpcCmd.StartApp", "my-app", &result)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorNotFound))
+				Expect(result.Err.ResourceType).To(Equal("App"))
+				Expect(result.Err.ResourceName).To(Equal("my-app"))
+			})
+		})
+
+		Context("when the API returns an error", func() {
+			BeforeEach(func() {
+				runner.CommandReturns(errors.NewHTTPError(500, "CF-ServerError", "boom"))
+			})
+
+			It("returns an API error with the status and error code", func() {
+				err := client.Call("CliRpcCmd.StartApp", "my-app", &result)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorAPIError))
+				Expect(result.Err.StatusCode).To(Equal(500))
+				Expect(result.Err.APIErrorCode).To(Equal("CF-ServerError"))
+				Expect(result.Err.Message).To(ContainSubstring("boom"))
+			})
+		})
+
+		Context("when the API rejects the request as unauthorized", func() {
+			BeforeEach(func() {
+				runner.CommandReturns(errors.NewHTTPError(403, "CF-NotAuthorized", "not allowed"))
+			})
+
+			It("returns a not authorized error", func() {
+				err := client.Call("CliRpcCmd.StartApp", "my-app", &result)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorNotAuthorized))
+				Expect(result.Err.StatusCode).To(Equal(403))
+			})
+		})
+	})
+
+	Describe("StopApp and RestartApp", func() {
+		It("runs stop and restart with the app name", func() {
+			err := client.Call("CliRpcCmd.StopApp", "my-app", &result)
+			Expect(err).ToNot(HaveOccurred())
+			err = client.Call("CliRpcCmd.RestartApp", "my-app", &result)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(runner.CommandCallCount()).To(Equal(2))
+			args, _, _ := runner.CommandArgsForCall(0)
+			Expect(args).To(Equal([]string{"stop", "my-app"}))
+			args, _, _ = runner.CommandArgsForCall(1)
+			Expect(args).To(Equal([]string{"restart", "my-app"}))
+		})
+	})
+
+	Describe("ScaleApp", func() {
+		It("runs scale with the given options without prompting", func() {
+			instances := 3
+			err := client.Call("CliRpcCmd.ScaleApp", plugin_models.ScaleApp_Args{
+				AppName: "my-app",
+				Options: plugin_models.ScaleAppOptions{Instances: &instances, Memory: "512M", DiskQuota: "1G"},
+			}, &result)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Err).To(BeNil())
+
+			args, _, _ := runner.CommandArgsForCall(0)
+			Expect(args).To(Equal([]string{"scale", "my-app", "-f", "-i", "3", "-m", "512M", "-k", "1G"}))
+		})
+
+		It("returns an invalid argument error without any option", func() {
+			err := client.Call("CliRpcCmd.ScaleApp", plugin_models.ScaleApp_Args{AppName: "my-app"}, &result)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorInvalidArgument))
+			Expect(runner.CommandCallCount()).To(Equal(0))
+		})
+
+		It("returns an invalid argument error for an invalid memory limit", func() {
+			err := client.Call("CliRpcCmd.ScaleApp", plugin_models.ScaleApp_Args{
+				AppName: "my-app",
+				Options: plugin_models.ScaleAppOptions{Memory: "lots"},
+			}, &result)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorInvalidArgument))
+			Expect(result.Err.Message).To(ContainSubstring("lots"))
+			Expect(runner.CommandCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("SetEnv", func() {
+		It("runs set-env with the app, name and value", func() {
+			err := client.Call("CliRpcCmd.SetEnv", []string{"my-app", "NAME", "value"}, &result)
+			Expect(err).ToNot(HaveOccurred())
+
+			args, _, _ := runner.CommandArgsForCall(0)
+			Expect(args).To(Equal([]string{"set-env", "my-app", "NAME", "value"}))
+		})
+
+		It("returns an invalid argument error without a variable name", func() {
+			err := client.Call("CliRpcCmd.SetEnv", []string{"my-app", "", "value"}, &result)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorInvalidArgument))
+		})
+	})
+
+	Describe("BindService and UnbindService", func() {
+		It("runs bind-service and unbind-service with the app and service instance", func() {
+			err := client.Call("CliRpcCmd.BindService", []string{"my-app", "my-db"}, &result)
+			Expect(err).ToNot(HaveOccurred())
+			err = client.Call("CliRpcCmd.UnbindService", []string{"my-app", "my-db"}, &result)
+			Expect(err).ToNot(HaveOccurred())
+
+			args, _, _ := runner.CommandArgsForCall(0)
+			Expect(args).To(Equal([]string{"bind-service", "my-app", "my-db"}))
+			args, _, _ = runner.CommandArgsForCall(1)
+			Expect(args).To(Equal([]string{"unbind-service", "my-app", "my-db"}))
+		})
+	})
+
+	Describe("MapRoute and UnmapRoute", func() {
+		It("runs map-route with the route options", func() {
+			err := client.Call("CliRpcCmd.MapRoute", plugin_models.Route_Args{
+				AppName: "my-app",
+				Domain:  "example.com",
+				Options: plugin_models.RouteOptions{Hostname: "www", Path: "/api"},
+			}, &result)
+			Expect(err).ToNot(HaveOccurred())
+
+			args, _, _ := runner.CommandArgsForCall(0)
+			Expect(args).To(Equal([]string{"map-route", "my-app", "example.com", "--hostname", "www", "--path", "/api"}))
+		})
+
+		It("runs unmap-route with a TCP port", func() {
+			err := client.Call("CliRpcCmd.UnmapRoute", plugin_models.Route_Args{
+				AppName: "my-app",
+				Domain:  "tcp.example.com",
+				Options: plugin_models.RouteOptions{Port: 1234},
+			}, &result)
+			Expect(err).ToNot(HaveOccurred())
+
+			args, _, _ := runner.CommandArgsForCall(0)
+			Expect(args).To(Equal([]string{"unmap-route", "my-app", "tcp.example.com", "--port", "1234"}))
+		})
+
+		It("returns an invalid argument error without a domain", func() {
+			err := client.Call("CliRpcCmd.MapRoute", plugin_models.Route_Args{AppName: "my-app"}, &result)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorInvalidArgument))
+			Expect(runner.CommandCallCount()).To(Equal(0))
+		})
+	})
+})
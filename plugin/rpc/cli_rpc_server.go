@@ -47,6 +47,9 @@ type CliRpcCmd struct {
 	outputBucket         *bytes.Buffer
 	logger               trace.Printer
 	stdout               io.Writer
+	logStream            *logStream
+	logStreamMutex       *sync.Mutex
+	commandMutex         *sync.Mutex
 }
 
 //go:generate counterfeiter . TerminalOutputSwitch
@@ -84,6 +87,8 @@ func NewRpcService(
 			logger:               logger,
 			outputBucket:         &bytes.Buffer{},
 			stdout:               w,
+			logStreamMutex:       &sync.Mutex{},
+			commandMutex:         &sync.Mutex{},
 		},
 	}
 
@@ -170,42 +175,73 @@ func (cmd *CliRpcCmd) DisableTerminalOutput(disable bool, retVal *bool) error {
 }
 
 func (cmd *CliRpcCmd) CallCoreCommand(args []string, retVal *bool) error {
-	var err error
-	cmdRegistry := commandregistry.Commands
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
 
 	cmd.outputBucket = &bytes.Buffer{}
-	cmd.outputCapture.SetOutputBucket(cmd.outputBucket)
 
-	if cmdRegistry.CommandExists(args[0]) {
-		deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	var err error
+	*retVal, err = cmd.callCoreCommand(args, cmd.outputBucket)
+	return err
+}
+
+func (cmd *CliRpcCmd) GetOutputAndReset(args bool, retVal *[]string) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
 
-		//set deps objs to be the one used by all other commands
-		//once all commands are converted, we can make fresh deps for each command run
-		deps.Config = cmd.cliConfig
-		deps.RepoLocator = cmd.repoLocator
+	*retVal = splitOutput(cmd.outputBucket)
+	return nil
+}
 
-		//set command ui's TeePrinter to be the one used by RpcService, for output to be captured
-		deps.UI = terminal.NewUI(os.Stdin, cmd.stdout, cmd.outputCapture.(*terminal.TeePrinter), cmd.logger)
+// CallCoreCommandWithOutput runs a core command and returns its output in
+// the same call, so that plugins sharing one connection between goroutines
+// do not read the output of each other's commands.
+func (cmd *CliRpcCmd) CallCoreCommandWithOutput(args plugin_models.CallCoreCommand_Args, retVal *plugin_models.CallCoreCommand_Result) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
 
-		err = cmd.newCmdRunner.Command(args, deps, false)
-	} else {
-		*retVal = false
-		return nil
+	cmd.terminalOutputSwitch.DisableTerminalOutput(args.Silently)
+
+	outputBucket := &bytes.Buffer{}
+	success, err := cmd.callCoreCommand(args.Args, outputBucket)
+
+	retVal.Success = success
+	retVal.Output = splitOutput(outputBucket)
+	return err
+}
+
+// callCoreCommand runs a core command with its output captured in
+// outputBucket. Callers must hold the command mutex.
+func (cmd *CliRpcCmd) callCoreCommand(args []string, outputBucket *bytes.Buffer) (bool, error) {
+	cmdRegistry := commandregistry.Commands
+
+	cmd.outputCapture.SetOutputBucket(outputBucket)
+
+	if !cmdRegistry.CommandExists(args[0]) {
+		return false, nil
 	}
 
+	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+
+	//set deps objs to be the one used by all other commands
+	//once all commands are converted, we can make fresh deps for each command run
+	deps.Config = cmd.cliConfig
+	deps.RepoLocator = cmd.repoLocator
+
+	//set command ui's TeePrinter to be the one used by RpcService, for output to be captured
+	deps.UI = terminal.NewUI(os.Stdin, cmd.stdout, cmd.outputCapture.(*terminal.TeePrinter), cmd.logger)
+
+	err := cmd.newCmdRunner.Command(args, deps, false)
 	if err != nil {
-		*retVal = false
-		return err
+		return false, err
 	}
 
-	*retVal = true
-	return nil
+	return true, nil
 }
 
-func (cmd *CliRpcCmd) GetOutputAndReset(args bool, retVal *[]string) error {
-	v := strings.TrimSuffix(cmd.outputBucket.String(), "\n")
-	*retVal = strings.Split(v, "\n")
-	return nil
+func splitOutput(outputBucket *bytes.Buffer) []string {
+	v := strings.TrimSuffix(outputBucket.String(), "\n")
+	return strings.Split(v, "\n")
 }
 
 func (cmd *CliRpcCmd) GetCurrentOrg(args string, retVal *plugin_models.Organization) error {
@@ -305,6 +341,9 @@ func (cmd *CliRpcCmd) AccessToken(args string, retVal *string) error {
 }
 
 func (cmd *CliRpcCmd) GetApp(appName string, retVal *plugin_models.GetAppModel) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
@@ -319,6 +358,9 @@ func (cmd *CliRpcCmd) GetApp(appName string, retVal *plugin_models.GetAppModel)
 }
 
 func (cmd *CliRpcCmd) GetApps(_ string, retVal *[]plugin_models.GetAppsModel) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
@@ -333,6 +375,9 @@ func (cmd *CliRpcCmd) GetApps(_ string, retVal *[]plugin_models.GetAppsModel) er
 }
 
 func (cmd *CliRpcCmd) GetOrgs(_ string, retVal *[]plugin_models.GetOrgs_Model) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
@@ -347,6 +392,9 @@ func (cmd *CliRpcCmd) GetOrgs(_ string, retVal *[]plugin_models.GetOrgs_Model) e
 }
 
 func (cmd *CliRpcCmd) GetSpaces(_ string, retVal *[]plugin_models.GetSpaces_Model) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
@@ -361,6 +409,9 @@ func (cmd *CliRpcCmd) GetSpaces(_ string, retVal *[]plugin_models.GetSpaces_Mode
 }
 
 func (cmd *CliRpcCmd) GetServices(_ string, retVal *[]plugin_models.GetServices_Model) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
@@ -376,6 +427,9 @@ func (cmd *CliRpcCmd) GetServices(_ string, retVal *[]plugin_models.GetServices_
 }
 
 func (cmd *CliRpcCmd) GetOrgUsers(args []string, retVal *[]plugin_models.GetOrgUsers_Model) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
@@ -390,6 +444,9 @@ func (cmd *CliRpcCmd) GetOrgUsers(args []string, retVal *[]plugin_models.GetOrgU
 }
 
 func (cmd *CliRpcCmd) GetSpaceUsers(args []string, retVal *[]plugin_models.GetSpaceUsers_Model) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
@@ -404,6 +461,9 @@ func (cmd *CliRpcCmd) GetSpaceUsers(args []string, retVal *[]plugin_models.GetSp
 }
 
 func (cmd *CliRpcCmd) GetOrg(orgName string, retVal *plugin_models.GetOrg_Model) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
@@ -418,6 +478,9 @@ func (cmd *CliRpcCmd) GetOrg(orgName string, retVal *plugin_models.GetOrg_Model)
 }
 
 func (cmd *CliRpcCmd) GetSpace(spaceName string, retVal *plugin_models.GetSpace_Model) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
@@ -432,6 +495,9 @@ func (cmd *CliRpcCmd) GetSpace(spaceName string, retVal *plugin_models.GetSpace_
 }
 
 func (cmd *CliRpcCmd) GetService(serviceInstance string, retVal *plugin_models.GetService_Model) error {
+	cmd.commandMutex.Lock()
+	defer cmd.commandMutex.Unlock()
+
 	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
 
 	//set deps objs to be the one used by all other commands
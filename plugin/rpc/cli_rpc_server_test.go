@@ -5,11 +5,13 @@ import (
 	"net"
 	"net/rpc"
 	"os"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/terminal"
@@ -421,6 +423,89 @@ var _ = Describe("Server", func() {
 
 	})
 
+	Describe(".CallCoreCommandWithOutput", func() {
+		var (
+			runner        *rpcfakes.FakeCommandRunner
+			outputCapture *terminal.TeePrinter
+		)
+
+		BeforeEach(func() {
+			outputCapture = terminal.NewTeePrinter(os.Stdout)
+			runner = new(rpcfakes.FakeCommandRunner)
+			runner.CommandStub = func(args []string, deps commandregistry.Dependency, _ bool) error {
+				if args[0] == "fake-command4" {
+					return errors.New("command failed")
+				}
+				deps.UI.Say("output of " + args[0])
+				return nil
+			}
+
+			rpcService, err = NewRpcService(outputCapture, outputCapture, nil, api.RepositoryLocator{}, runner, nil, nil, rpc.DefaultServer)
+			Expect(err).ToNot(HaveOccurred())
+
+			err := rpcService.Start()
+			Expect(err).ToNot(HaveOccurred())
+
+			pingCli(rpcService.Port())
+
+			client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			rpcService.Stop()
+
+			//give time for server to stop
+			time.Sleep(50 * time.Millisecond)
+		})
+
+		It("returns the output of the command", func() {
+			var result plugin_models.CallCoreCommand_Result
+			err = client.Call("CliRpcCmd.CallCoreCommandWithOutput", plugin_models.CallCoreCommand_Args{Args: []string{"fake-command"}, Silently: true}, &result)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(result.Success).To(BeTrue())
+			Expect(result.Output).To(Equal([]string{"output of fake-command"}))
+		})
+
+		It("is not successful when the command does not exist", func() {
+			var result plugin_models.CallCoreCommand_Result
+			err = client.Call("CliRpcCmd.CallCoreCommandWithOutput", plugin_models.CallCoreCommand_Args{Args: []string{"not_a_cmd"}, Silently: true}, &result)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(result.Success).To(BeFalse())
+			Expect(runner.CommandCallCount()).To(Equal(0))
+		})
+
+		It("returns the error of a failing command", func() {
+			var result plugin_models.CallCoreCommand_Result
+			err = client.Call("CliRpcCmd.CallCoreCommandWithOutput", plugin_models.CallCoreCommand_Args{Args: []string{"fake-command4"}, Silently: true}, &result)
+			Expect(err).To(MatchError("command failed"))
+		})
+
+		It("keeps the output of concurrent calls on one connection apart", func() {
+			commands := []string{"fake-command", "fake-command2", "fake-command3"}
+
+			results := make([]plugin_models.CallCoreCommand_Result, len(commands))
+			errs := make([]error, len(commands))
+
+			var wg sync.WaitGroup
+			for i, command := range commands {
+				wg.Add(1)
+				go func(i int, command string) {
+					defer wg.Done()
+					errs[i] = client.Call("CliRpcCmd.CallCoreCommandWithOutput", plugin_models.CallCoreCommand_Args{Args: []string{command}, Silently: true}, &results[i])
+				}(i, command)
+			}
+			wg.Wait()
+
+			for i, command := range commands {
+				Expect(errs[i]).ToNot(HaveOccurred())
+				Expect(results[i].Output).To(Equal([]string{"output of " + command}))
+			}
+		})
+	})
+
 	Describe(".CallCoreCommand", func() {
 		var runner *rpcfakes.FakeCommandRunner
 
@@ -0,0 +1,220 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/plugin/models"
+)
+
+// logsPollTimeout is how long NextLogs waits for messages before it returns
+// an empty batch, so that the plugin gets a chance to stop the stream.
+var logsPollTimeout = time.Second
+
+// logStream buffers the messages of a TailLogs call until the plugin asks for
+// them with NextLogs.
+type logStream struct {
+	mutex    sync.Mutex
+	messages []plugin_models.LogMessage
+	err      error
+	closed   bool
+	stopped  bool
+	updated  chan struct{}
+}
+
+func newLogStream() *logStream {
+	return &logStream{updated: make(chan struct{}, 1)}
+}
+
+func (s *logStream) push(msg logs.Loggable) {
+	s.mutex.Lock()
+	s.messages = append(s.messages, newLogMessage(msg))
+	s.mutex.Unlock()
+	s.notify()
+}
+
+// end closes the stream. err is dropped once the plugin stopped the stream,
+// as closing the connection can make the consumer report an error.
+func (s *logStream) end(err error) {
+	s.mutex.Lock()
+	if !s.stopped {
+		s.err = err
+	}
+	s.closed = true
+	s.mutex.Unlock()
+	s.notify()
+}
+
+func (s *logStream) notify() {
+	select {
+	case s.updated <- struct{}{}:
+	default:
+	}
+}
+
+func (s *logStream) isClosed() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.closed
+}
+
+func (s *logStream) stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stopped = true
+}
+
+// next returns the buffered messages, waiting up to timeout for some to
+// arrive when there are none.
+func (s *logStream) next(timeout time.Duration) ([]plugin_models.LogMessage, bool, error) {
+	s.mutex.Lock()
+	waiting := len(s.messages) == 0 && !s.closed
+	s.mutex.Unlock()
+
+	if waiting {
+		select {
+		case <-s.updated:
+		case <-time.After(timeout):
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	messages := s.messages
+	s.messages = nil
+	return messages, s.closed && len(messages) == 0, s.err
+}
+
+func (cmd *CliRpcCmd) GetRecentLogs(appName string, retVal *plugin_models.RecentLogs_Model) error {
+	appGUID, opErr := cmd.logsAppGUID("logs", appName)
+	if opErr != nil {
+		retVal.Err = opErr
+		return nil
+	}
+
+	messages, err := cmd.repoLocator.GetLogsRepository().RecentLogsFor(appGUID)
+	if err != nil {
+		retVal.Err = newOperationError("logs", err)
+		return nil
+	}
+
+	retVal.Messages = make([]plugin_models.LogMessage, len(messages))
+	for i, msg := range messages {
+		retVal.Messages[i] = newLogMessage(msg)
+	}
+	return nil
+}
+
+// TailLogs starts tailing the logs of the app. The messages are fetched with
+// NextLogs until the stream is closed or the plugin calls StopLogs. Only one
+// stream can be open at a time.
+func (cmd *CliRpcCmd) TailLogs(appName string, retVal *plugin_models.LogStream_Model) error {
+	cmd.logStreamMutex.Lock()
+	defer cmd.logStreamMutex.Unlock()
+
+	if cmd.logStream != nil && !cmd.logStream.isClosed() {
+		retVal.Err = &plugin_models.OperationError{
+			Operation: "logs",
+			Code:      plugin_models.OperationErrorFailed,
+			Message:   T("Logs are already being tailed. Stop the current stream first."),
+		}
+		return nil
+	}
+
+	appGUID, opErr := cmd.logsAppGUID("logs", appName)
+	if opErr != nil {
+		retVal.Err = opErr
+		return nil
+	}
+
+	stream := newLogStream()
+	logChan := make(chan logs.Loggable)
+	errChan := make(chan error)
+
+	go func() {
+		for {
+			select {
+			case msg, ok := <-logChan:
+				if !ok {
+					stream.end(nil)
+					return
+				}
+				stream.push(msg)
+			case err := <-errChan:
+				stream.end(err)
+				return
+			}
+		}
+	}()
+
+	cmd.logStream = stream
+	cmd.repoLocator.GetLogsRepository().TailLogsFor(appGUID, func() {}, logChan, errChan)
+	return nil
+}
+
+// NextLogs returns the messages received since the last call, waiting a
+// short while for new ones when there are none.
+func (cmd *CliRpcCmd) NextLogs(_ string, retVal *plugin_models.LogStream_Model) error {
+	cmd.logStreamMutex.Lock()
+	stream := cmd.logStream
+	cmd.logStreamMutex.Unlock()
+
+	if stream == nil {
+		retVal.Closed = true
+		return nil
+	}
+
+	messages, closed, err := stream.next(logsPollTimeout)
+	retVal.Messages = messages
+	retVal.Closed = closed
+	if err != nil {
+		retVal.Err = newOperationError("logs", err)
+	}
+	return nil
+}
+
+// StopLogs closes the stream opened by TailLogs.
+func (cmd *CliRpcCmd) StopLogs(_ string, retVal *bool) error {
+	cmd.logStreamMutex.Lock()
+	defer cmd.logStreamMutex.Unlock()
+
+	if cmd.logStream != nil {
+		cmd.logStream.stop()
+		cmd.repoLocator.GetLogsRepository().Close()
+		cmd.logStream = nil
+	}
+
+	*retVal = true
+	return nil
+}
+
+func (cmd *CliRpcCmd) logsAppGUID(operation string, appName string) (string, *plugin_models.OperationError) {
+	if appName == "" {
+		return "", invalidArgument(operation, T("Requires an app name")).Err
+	}
+
+	if opErr := cmd.checkTargetedSpace(operation); opErr != nil {
+		return "", opErr
+	}
+
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return "", newOperationError(operation, err)
+	}
+
+	return app.GUID, nil
+}
+
+func newLogMessage(msg logs.Loggable) plugin_models.LogMessage {
+	return plugin_models.LogMessage{
+		AppGuid:        msg.GetAppGUID(),
+		SourceType:     msg.GetSourceName(),
+		SourceInstance: msg.GetSourceInstance(),
+		Timestamp:      msg.GetTimestamp(),
+		Stream:         msg.GetStream(),
+		Message:        msg.ToSimpleLog(),
+	}
+}
@@ -0,0 +1,230 @@
+package rpc_test
+
+import (
+	"errors"
+	"net/rpc"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/api/logs/logsfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/plugin/models"
+	. "code.cloudfoundry.org/cli/plugin/rpc"
+	"code.cloudfoundry.org/cli/plugin/rpc/rpcfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testlogs "code.cloudfoundry.org/cli/testhelpers/logs"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logs API", func() {
+	var (
+		client    *rpc.Client
+		config    coreconfig.Repository
+		appRepo   *applicationsfakes.FakeRepository
+		logsRepo  *logsfakes.FakeRepository
+		timestamp time.Time
+	)
+
+	BeforeEach(func() {
+		rpc.DefaultServer = rpc.NewServer()
+		config = testconfig.NewRepositoryWithDefaults()
+
+		appRepo = new(applicationsfakes.FakeRepository)
+		appRepo.ReadReturns(models.Application{ApplicationFields: models.ApplicationFields{Name: "my-app", GUID: "my-app-guid"}}, nil)
+		logsRepo = new(logsfakes.FakeRepository)
+
+		timestamp = time.Unix(1480000000, 0)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		outputCapture := terminal.NewTeePrinter(os.Stdout)
+		terminalOutputSwitch := terminal.NewTeePrinter(os.Stdout)
+		repoLocator := api.RepositoryLocator{}.
+			SetApplicationRepository(appRepo).
+			SetLogsRepository(logsRepo)
+
+		rpcService, err = NewRpcService(outputCapture, terminalOutputSwitch, config, repoLocator, new(rpcfakes.FakeCommandRunner), nil, nil, rpc.DefaultServer)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = rpcService.Start()
+		Expect(err).ToNot(HaveOccurred())
+
+		pingCli(rpcService.Port())
+
+		client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		client.Close()
+		rpcService.Stop()
+
+		//give time for server to stop
+		time.Sleep(50 * time.Millisecond)
+	})
+
+	Describe("GetRecentLogs", func() {
+		var result plugin_models.RecentLogs_Model
+
+		BeforeEach(func() {
+			result = plugin_models.RecentLogs_Model{}
+			logsRepo.RecentLogsForReturns([]logs.Loggable{
+				testlogs.NewLogMessage("hello\n", "my-app-guid", "APP", "0", logmessage.LogMessage_OUT, timestamp),
+				testlogs.NewLogMessage("oops", "my-app-guid", "STG", "1", logmessage.LogMessage_ERR, timestamp),
+			}, nil)
+		})
+
+		It("returns the recent logs of the app as log messages", func() {
+			err := client.Call("CliRpcCmd.GetRecentLogs", "my-app", &result)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Err).To(BeNil())
+
+			Expect(appRepo.ReadArgsForCall(0)).To(Equal("my-app"))
+			Expect(logsRepo.RecentLogsForArgsForCall(0)).To(Equal("my-app-guid"))
+
+			Expect(result.Messages).To(HaveLen(2))
+			Expect(result.Messages[0].AppGuid).To(Equal("my-app-guid"))
+			Expect(result.Messages[0].SourceType).To(Equal("APP"))
+			Expect(result.Messages[0].SourceInstance).To(Equal("0"))
+			Expect(result.Messages[0].Stream).To(Equal(plugin_models.LogStreamOut))
+			Expect(result.Messages[0].Message).To(Equal("hello"))
+			Expect(result.Messages[0].Timestamp.Equal(timestamp)).To(BeTrue())
+			Expect(result.Messages[1].Stream).To(Equal(plugin_models.LogStreamErr))
+		})
+
+		Context("when the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.ReadReturns(models.Application{}, cferrors.NewModelNotFoundError("App", "my-app"))
+			})
+
+			It("returns a not found error", func() {
+				err := client.Call("CliRpcCmd.GetRecentLogs", "my-app", &result)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorNotFound))
+				Expect(logsRepo.RecentLogsForCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the user is not logged in", func() {
+			BeforeEach(func() {
+				config = testconfig.NewRepository()
+			})
+
+			It("returns a not logged in error", func() {
+				err := client.Call("CliRpcCmd.GetRecentLogs", "my-app", &result)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorNotLoggedIn))
+				Expect(appRepo.ReadCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when getting the logs fails", func() {
+			BeforeEach(func() {
+				logsRepo.RecentLogsForReturns(nil, errors.New("doppler is down"))
+			})
+
+			It("returns the error", func() {
+				err := client.Call("CliRpcCmd.GetRecentLogs", "my-app", &result)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Err.Code).To(Equal(plugin_models.OperationErrorFailed))
+				Expect(result.Err.Message).To(Equal("doppler is down"))
+			})
+		})
+	})
+
+	Describe("TailLogs", func() {
+		var (
+			logChan chan<- logs.Loggable
+			errChan chan<- error
+		)
+
+		BeforeEach(func() {
+			logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), lc chan<- logs.Loggable, ec chan<- error) {
+				logChan = lc
+				errChan = ec
+			}
+			logsRepo.CloseStub = func() {
+				close(logChan)
+			}
+		})
+
+		It("tails the logs of the app and returns them with NextLogs", func() {
+			var stream plugin_models.LogStream_Model
+			err := client.Call("CliRpcCmd.TailLogs", "my-app", &stream)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stream.Err).To(BeNil())
+
+			appGUID, _, _, _ := logsRepo.TailLogsForArgsForCall(0)
+			Expect(appGUID).To(Equal("my-app-guid"))
+
+			logChan <- testlogs.NewLogMessage("hello", "my-app-guid", "APP", "0", logmessage.LogMessage_OUT, timestamp)
+
+			var batch plugin_models.LogStream_Model
+			err = client.Call("CliRpcCmd.NextLogs", "", &batch)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(batch.Closed).To(BeFalse())
+			Expect(batch.Messages).To(HaveLen(1))
+			Expect(batch.Messages[0].Message).To(Equal("hello"))
+		})
+
+		It("does not open a second stream while one is open", func() {
+			var stream plugin_models.LogStream_Model
+			err := client.Call("CliRpcCmd.TailLogs", "my-app", &stream)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = client.Call("CliRpcCmd.TailLogs", "my-app", &stream)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stream.Err.Code).To(Equal(plugin_models.OperationErrorFailed))
+			Expect(logsRepo.TailLogsForCallCount()).To(Equal(1))
+		})
+
+		It("reports the error that ended the stream", func() {
+			var stream plugin_models.LogStream_Model
+			err := client.Call("CliRpcCmd.TailLogs", "my-app", &stream)
+			Expect(err).ToNot(HaveOccurred())
+
+			errChan <- cferrors.NewHTTPError(503, "", "unavailable")
+
+			var batch plugin_models.LogStream_Model
+			Eventually(func() bool {
+				batch = plugin_models.LogStream_Model{}
+				Expect(client.Call("CliRpcCmd.NextLogs", "", &batch)).To(Succeed())
+				return batch.Closed
+			}).Should(BeTrue())
+			Expect(batch.Err.Code).To(Equal(plugin_models.OperationErrorAPIError))
+			Expect(batch.Err.StatusCode).To(Equal(503))
+		})
+
+		It("closes the stream when the plugin stops it", func() {
+			var stream plugin_models.LogStream_Model
+			err := client.Call("CliRpcCmd.TailLogs", "my-app", &stream)
+			Expect(err).ToNot(HaveOccurred())
+
+			var success bool
+			err = client.Call("CliRpcCmd.StopLogs", "", &success)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(success).To(BeTrue())
+			Expect(logsRepo.CloseCallCount()).To(Equal(1))
+
+			var batch plugin_models.LogStream_Model
+			err = client.Call("CliRpcCmd.NextLogs", "", &batch)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(batch.Closed).To(BeTrue())
+			Expect(batch.Err).To(BeNil())
+
+			err = client.Call("CliRpcCmd.TailLogs", "my-app", &stream)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stream.Err).To(BeNil())
+			Expect(logsRepo.TailLogsForCallCount()).To(Equal(2))
+		})
+	})
+})
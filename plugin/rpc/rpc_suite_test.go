@@ -1,7 +1,9 @@
 package rpc_test
 
 import (
+	"code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/plugin/rpc"
+	"code.cloudfoundry.org/cli/testhelpers/configuration"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -11,6 +13,9 @@ import (
 var rpcService *rpc.CliRpcService
 
 func TestRpc(t *testing.T) {
+	config := configuration.NewRepositoryWithDefaults()
+	i18n.T = i18n.Init(config)
+
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Rpc Suite")
 }
@@ -280,6 +280,15 @@ type FakeHandlers struct {
 	getServiceReturns struct {
 		result1 error
 	}
+	CallCoreCommandWithOutputStub        func(args plugin_models.CallCoreCommand_Args, retVal *plugin_models.CallCoreCommand_Result) error
+	callCoreCommandWithOutputMutex       sync.RWMutex
+	callCoreCommandWithOutputArgsForCall []struct {
+		args   plugin_models.CallCoreCommand_Args
+		retVal *plugin_models.CallCoreCommand_Result
+	}
+	callCoreCommandWithOutputReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1319,6 +1328,40 @@ func (fake *FakeHandlers) GetServiceReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeHandlers) CallCoreCommandWithOutput(args plugin_models.CallCoreCommand_Args, retVal *plugin_models.CallCoreCommand_Result) error {
+	fake.callCoreCommandWithOutputMutex.Lock()
+	fake.callCoreCommandWithOutputArgsForCall = append(fake.callCoreCommandWithOutputArgsForCall, struct {
+		args   plugin_models.CallCoreCommand_Args
+		retVal *plugin_models.CallCoreCommand_Result
+	}{args, retVal})
+	fake.recordInvocation("CallCoreCommandWithOutput", []interface{}{args, retVal})
+	fake.callCoreCommandWithOutputMutex.Unlock()
+	if fake.CallCoreCommandWithOutputStub != nil {
+		return fake.CallCoreCommandWithOutputStub(args, retVal)
+	} else {
+		return fake.callCoreCommandWithOutputReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) CallCoreCommandWithOutputCallCount() int {
+	fake.callCoreCommandWithOutputMutex.RLock()
+	defer fake.callCoreCommandWithOutputMutex.RUnlock()
+	return len(fake.callCoreCommandWithOutputArgsForCall)
+}
+
+func (fake *FakeHandlers) CallCoreCommandWithOutputArgsForCall(i int) (plugin_models.CallCoreCommand_Args, *plugin_models.CallCoreCommand_Result) {
+	fake.callCoreCommandWithOutputMutex.RLock()
+	defer fake.callCoreCommandWithOutputMutex.RUnlock()
+	return fake.callCoreCommandWithOutputArgsForCall[i].args, fake.callCoreCommandWithOutputArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) CallCoreCommandWithOutputReturns(result1 error) {
+	fake.CallCoreCommandWithOutputStub = nil
+	fake.callCoreCommandWithOutputReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeHandlers) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1382,6 +1425,8 @@ func (fake *FakeHandlers) Invocations() map[string][][]interface{} {
 	defer fake.getSpaceMutex.RUnlock()
 	fake.getServiceMutex.RLock()
 	defer fake.getServiceMutex.RUnlock()
+	fake.callCoreCommandWithOutputMutex.RLock()
+	defer fake.callCoreCommandWithOutputMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -8,6 +8,7 @@ import (
 	"net/rpc"
 	"os"
 	"strconv"
+	"sync/atomic"
 
 	"code.cloudfoundry.org/cli/plugin"
 	"code.cloudfoundry.org/cli/plugin/models"
@@ -21,6 +22,7 @@ type Handlers interface {
 	DisableTerminalOutput(disable bool, retVal *bool) error
 	CallCoreCommand(args []string, retVal *bool) error
 	GetOutputAndReset(args bool, retVal *[]string) error
+	CallCoreCommandWithOutput(args plugin_models.CallCoreCommand_Args, retVal *plugin_models.CallCoreCommand_Result) error
 	GetCurrentOrg(args string, retVal *plugin_models.Organization) error
 	GetCurrentSpace(args string, retVal *plugin_models.Space) error
 	Username(args string, retVal *string) error
@@ -49,10 +51,11 @@ type Handlers interface {
 }
 
 type TestServer struct {
-	listener net.Listener
-	Handlers Handlers
-	stopCh   chan struct{}
-	server   *rpc.Server
+	listener    net.Listener
+	Handlers    Handlers
+	stopCh      chan struct{}
+	server      *rpc.Server
+	connections int32
 }
 
 func NewTestRPCServer(handlers Handlers) (*TestServer, error) {
@@ -83,10 +86,16 @@ func (ts *TestServer) Port() string {
 	return strconv.Itoa(ts.listener.Addr().(*net.TCPAddr).Port)
 }
 
+// ConnectionCount returns the number of connections accepted since Start.
+func (ts *TestServer) ConnectionCount() int {
+	return int(atomic.LoadInt32(&ts.connections))
+}
+
 func (ts *TestServer) Start() error {
 	var err error
 
 	ts.stopCh = make(chan struct{})
+	atomic.StoreInt32(&ts.connections, 0)
 
 	ts.listener, err = net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -104,6 +113,7 @@ func (ts *TestServer) Start() error {
 					fmt.Println(err)
 				}
 			} else {
+				atomic.AddInt32(&ts.connections, 1)
 				go ts.server.ServeConn(conn)
 			}
 		}
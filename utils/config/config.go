@@ -56,6 +56,7 @@ func LoadConfig() (*Config, error) {
 		BinaryName:       os.Args[0],
 		CFColor:          os.Getenv("CF_COLOR"),
 		CFPluginHome:     os.Getenv("CF_PLUGIN_HOME"),
+		CFProfile:        os.Getenv("CF_PROFILE"),
 		CFStagingTimeout: os.Getenv("CF_STAGING_TIMEOUT"),
 		CFStartupTimeout: os.Getenv("CF_STARTUP_TIMEOUT"),
 		CFTrace:          os.Getenv("CF_TRACE"),
@@ -64,6 +65,11 @@ func LoadConfig() (*Config, error) {
 		LCAll:            os.Getenv("LC_ALL"),
 	}
 
+	err := config.applyProfileOverride()
+	if err != nil {
+		return nil, err
+	}
+
 	pluginFilePath := filepath.Join(config.PluginHome(), "config.json")
 	if _, err := os.Stat(pluginFilePath); os.IsNotExist(err) {
 		config.pluginConfig = PluginsConfig{}
@@ -83,7 +89,7 @@ func LoadConfig() (*Config, error) {
 }
 
 func WriteConfig(c *Config) error {
-	rawConfig, err := json.MarshalIndent(c.ConfigFile, "", "  ")
+	rawConfig, err := json.MarshalIndent(c.configFileToWrite(), "", "  ")
 	if err != nil {
 		return err
 	}
@@ -100,30 +106,36 @@ type Config struct {
 	ConfigFile   CFConfig
 	ENV          EnvOverride
 	pluginConfig PluginsConfig
+
+	// savedTarget is the target written to the config file while a profile
+	// is selected through CF_PROFILE.
+	savedTarget Profile
 }
 
 type CFConfig struct {
-	ConfigVersion            int           `json:"ConfigVersion"`
-	Target                   string        `json:"Target"`
-	APIVersion               string        `json:"APIVersion"`
-	AuthorizationEndpoint    string        `json:"AuthorizationEndpoint"`
-	LoggregatorEndpoint      string        `json:"LoggregatorEndPoint"`
-	DopplerEndpoint          string        `json:"DopplerEndPoint"`
-	UAAEndpoint              string        `json:"UaaEndpoint"`
-	RoutingEndpoint          string        `json:"RoutingAPIEndpoint"`
-	AccessToken              string        `json:"AccessToken"`
-	SSHOAuthClient           string        `json:"SSHOAuthClient"`
-	RefreshToken             string        `json:"RefreshToken"`
-	TargetedOrganization     Organization  `json:"OrganizationFields"`
-	TargetedSpace            Space         `json:"SpaceFields"`
-	SkipSSLValidation        bool          `json:"SSLDisabled"`
-	AsyncTimeout             int           `json:"AsyncTimeout"`
-	Trace                    string        `json:"Trace"`
-	ColorEnabled             string        `json:"ColorEnabled"`
-	Locale                   string        `json:"Locale"`
-	PluginRepos              []PluginRepos `json:"PluginRepos"`
-	MinCLIVersion            string        `json:"MinCLIVersion"`
-	MinRecommendedCLIVersion string        `json:"MinRecommendedCLIVersion"`
+	ConfigVersion            int                `json:"ConfigVersion"`
+	Target                   string             `json:"Target"`
+	APIVersion               string             `json:"APIVersion"`
+	AuthorizationEndpoint    string             `json:"AuthorizationEndpoint"`
+	LoggregatorEndpoint      string             `json:"LoggregatorEndPoint"`
+	DopplerEndpoint          string             `json:"DopplerEndPoint"`
+	UAAEndpoint              string             `json:"UaaEndpoint"`
+	RoutingEndpoint          string             `json:"RoutingAPIEndpoint"`
+	AccessToken              string             `json:"AccessToken"`
+	SSHOAuthClient           string             `json:"SSHOAuthClient"`
+	RefreshToken             string             `json:"RefreshToken"`
+	TargetedOrganization     Organization       `json:"OrganizationFields"`
+	TargetedSpace            Space              `json:"SpaceFields"`
+	SkipSSLValidation        bool               `json:"SSLDisabled"`
+	AsyncTimeout             int                `json:"AsyncTimeout"`
+	Trace                    string             `json:"Trace"`
+	ColorEnabled             string             `json:"ColorEnabled"`
+	Locale                   string             `json:"Locale"`
+	PluginRepos              []PluginRepos      `json:"PluginRepos"`
+	MinCLIVersion            string             `json:"MinCLIVersion"`
+	MinRecommendedCLIVersion string             `json:"MinRecommendedCLIVersion"`
+	Profiles                 map[string]Profile `json:"Profiles,omitempty"`
+	CurrentProfile           string             `json:"CurrentProfile,omitempty"`
 }
 
 type Organization struct {
@@ -160,6 +172,7 @@ type EnvOverride struct {
 	CFColor          string
 	CFHome           string
 	CFPluginHome     string
+	CFProfile        string
 	CFStagingTimeout string
 	CFStartupTimeout string
 	CFTrace          string
@@ -379,6 +379,69 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Describe("target profiles", func() {
+		BeforeEach(func() {
+			rawConfig := `
+			{
+				"Target": "https://api.one.example.com",
+				"CurrentProfile": "one",
+				"Profiles": {
+					"one": {"Target": "https://api.one.example.com"},
+					"two": {"Target": "https://api.two.example.com", "SpaceFields": {"Name": "space-two"}}
+				}
+			}`
+			setConfig(homeDir, rawConfig)
+		})
+
+		Context("when CF_PROFILE is set", func() {
+			BeforeEach(func() {
+				os.Setenv("CF_PROFILE", "two")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("CF_PROFILE")
+			})
+
+			It("targets the selected profile", func() {
+				config, err := LoadConfig()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(config.Target()).To(Equal("https://api.two.example.com"))
+				Expect(config.TargetedSpace().Name).To(Equal("space-two"))
+			})
+
+			It("writes changes to the selected profile and keeps the saved target", func() {
+				config, err := LoadConfig()
+				Expect(err).ToNot(HaveOccurred())
+
+				config.SetSpaceInformation("space-guid", "other-space", false)
+				Expect(WriteConfig(config)).To(Succeed())
+
+				file, err := ioutil.ReadFile(filepath.Join(homeDir, ".cf", "config.json"))
+				Expect(err).ToNot(HaveOccurred())
+
+				var writtenCFConfig CFConfig
+				err = json.Unmarshal(file, &writtenCFConfig)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(writtenCFConfig.Target).To(Equal("https://api.one.example.com"))
+				Expect(writtenCFConfig.CurrentProfile).To(Equal("one"))
+				Expect(writtenCFConfig.Profiles["two"].TargetedSpace.Name).To(Equal("other-space"))
+			})
+
+			Context("when the profile does not exist", func() {
+				BeforeEach(func() {
+					os.Setenv("CF_PROFILE", "missing")
+				})
+
+				It("returns a ProfileNotFoundError", func() {
+					_, err := LoadConfig()
+					Expect(err).To(MatchError(ProfileNotFoundError{Name: "missing"}))
+				})
+			})
+		})
+	})
+
 	Describe("setter functions", func() {
 		Describe("SetTargetInformation", func() {
 			It("sets the api target and other related endpoints", func() {
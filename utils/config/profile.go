@@ -0,0 +1,119 @@
+package config
+
+import "fmt"
+
+// Profile is a named target saved with 'cf target-profile save'. It holds
+// the same target fields as CFConfig.
+type Profile struct {
+	Target                   string       `json:"Target"`
+	APIVersion               string       `json:"APIVersion"`
+	AuthorizationEndpoint    string       `json:"AuthorizationEndpoint"`
+	LoggregatorEndpoint      string       `json:"LoggregatorEndPoint"`
+	DopplerEndpoint          string       `json:"DopplerEndPoint"`
+	UAAEndpoint              string       `json:"UaaEndpoint"`
+	RoutingEndpoint          string       `json:"RoutingAPIEndpoint"`
+	AccessToken              string       `json:"AccessToken"`
+	SSHOAuthClient           string       `json:"SSHOAuthClient"`
+	RefreshToken             string       `json:"RefreshToken"`
+	TargetedOrganization     Organization `json:"OrganizationFields"`
+	TargetedSpace            Space        `json:"SpaceFields"`
+	SkipSSLValidation        bool         `json:"SSLDisabled"`
+	MinCLIVersion            string       `json:"MinCLIVersion"`
+	MinRecommendedCLIVersion string       `json:"MinRecommendedCLIVersion"`
+}
+
+// ProfileNotFoundError is returned when CF_PROFILE names a profile that is
+// not in the config file.
+type ProfileNotFoundError struct {
+	Name string
+}
+
+func (e ProfileNotFoundError) Error() string {
+	return fmt.Sprintf("Target profile %s set in CF_PROFILE was not found", e.Name)
+}
+
+// applyProfileOverride makes the profile selected through CF_PROFILE the
+// target of config.
+func (config *Config) applyProfileOverride() error {
+	name := config.ENV.CFProfile
+	if name == "" {
+		return nil
+	}
+
+	profile, ok := config.ConfigFile.Profiles[name]
+	if !ok {
+		return ProfileNotFoundError{Name: name}
+	}
+
+	config.savedTarget = config.ConfigFile.targetProfile()
+	config.ConfigFile.setTargetProfile(profile)
+	return nil
+}
+
+// configFileToWrite records the current target in the profile it belongs
+// to and returns the contents of the config file. Once the API endpoint no
+// longer matches the profile, the target stops belonging to it.
+func (config *Config) configFileToWrite() CFConfig {
+	target := config.ConfigFile.targetProfile()
+
+	name := config.ENV.CFProfile
+	if name == "" {
+		if profile, ok := config.ConfigFile.Profiles[config.ConfigFile.CurrentProfile]; ok {
+			if profile.Target == target.Target {
+				config.ConfigFile.Profiles[config.ConfigFile.CurrentProfile] = target
+			} else {
+				config.ConfigFile.CurrentProfile = ""
+			}
+		}
+		return config.ConfigFile
+	}
+
+	configFile := config.ConfigFile
+	if profile, ok := configFile.Profiles[name]; ok && profile.Target == target.Target {
+		configFile.Profiles[name] = target
+		if configFile.CurrentProfile == name {
+			config.savedTarget = target
+		}
+	}
+
+	configFile.setTargetProfile(config.savedTarget)
+	return configFile
+}
+
+func (c *CFConfig) targetProfile() Profile {
+	return Profile{
+		Target:                   c.Target,
+		APIVersion:               c.APIVersion,
+		AuthorizationEndpoint:    c.AuthorizationEndpoint,
+		LoggregatorEndpoint:      c.LoggregatorEndpoint,
+		DopplerEndpoint:          c.DopplerEndpoint,
+		UAAEndpoint:              c.UAAEndpoint,
+		RoutingEndpoint:          c.RoutingEndpoint,
+		AccessToken:              c.AccessToken,
+		SSHOAuthClient:           c.SSHOAuthClient,
+		RefreshToken:             c.RefreshToken,
+		TargetedOrganization:     c.TargetedOrganization,
+		TargetedSpace:            c.TargetedSpace,
+		SkipSSLValidation:        c.SkipSSLValidation,
+		MinCLIVersion:            c.MinCLIVersion,
+		MinRecommendedCLIVersion: c.MinRecommendedCLIVersion,
+	}
+}
+
+func (c *CFConfig) setTargetProfile(p Profile) {
+	c.Target = p.Target
+	c.APIVersion = p.APIVersion
+	c.AuthorizationEndpoint = p.AuthorizationEndpoint
+	c.LoggregatorEndpoint = p.LoggregatorEndpoint
+	c.DopplerEndpoint = p.DopplerEndpoint
+	c.UAAEndpoint = p.UAAEndpoint
+	c.RoutingEndpoint = p.RoutingEndpoint
+	c.AccessToken = p.AccessToken
+	c.SSHOAuthClient = p.SSHOAuthClient
+	c.RefreshToken = p.RefreshToken
+	c.TargetedOrganization = p.TargetedOrganization
+	c.TargetedSpace = p.TargetedSpace
+	c.SkipSSLValidation = p.SkipSSLValidation
+	c.MinCLIVersion = p.MinCLIVersion
+	c.MinRecommendedCLIVersion = p.MinRecommendedCLIVersion
+}
@@ -15,6 +15,17 @@ type Downloader interface {
 	SavePath() string
 }
 
+// DownloadError is returned by DownloadFile when the server does not respond
+// with the file.
+type DownloadError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *DownloadError) Error() string {
+	return fmt.Sprintf("Error downloading file from %s", e.URL)
+}
+
 type downloader struct {
 	saveDir    string
 	filename   string
@@ -72,7 +83,7 @@ func (d *downloader) DownloadFile(url string) (int64, string, error) {
 		return size, d.filename, nil
 
 	}
-	return 0, "", fmt.Errorf("Error downloading file from %s", url)
+	return 0, "", &DownloadError{URL: url, StatusCode: r.StatusCode}
 }
 
 func (d *downloader) RemoveFile() error {
@@ -152,6 +152,23 @@ var _ = Describe("Downloader", func() {
 			})
 		})
 
+		Context("when the server does not respond with the file", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/abc.zip"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns an error with the status code", func() {
+				_, _, err := d.DownloadFile(server.URL() + "/abc.zip")
+				Expect(err).To(MatchError("Error downloading file from " + server.URL() + "/abc.zip"))
+				Expect(err.(*downloader.DownloadError).StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
 		Context("when the URL is invalid", func() {
 			It("returns an error", func() {
 				_, _, err := d.DownloadFile("http://going.nowwhere/abc.zip")
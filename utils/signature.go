@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+//go:generate counterfeiter . SignatureVerifier
+
+// SignatureVerifier checks detached signatures of files against a list of
+// trusted public keys.
+type SignatureVerifier interface {
+	// Verify checks signature, a base64 encoded ed25519 signature of the
+	// contents of the file, and returns the name of the trusted key that
+	// made it.
+	Verify(filepath string, signature string) (string, error)
+	TrustedKeysPath() string
+}
+
+// ErrUntrustedSignature is returned by Verify when the signature was not
+// made with any of the trusted keys.
+var ErrUntrustedSignature = errors.New("signature does not match any trusted key")
+
+// TrustedKey is a named ed25519 public key.
+type TrustedKey struct {
+	Name string
+	Key  ed25519.PublicKey
+}
+
+type signatureVerifier struct {
+	trustedKeysPath string
+}
+
+// NewSignatureVerifier returns a SignatureVerifier trusting the keys listed
+// in the file at trustedKeysPath. Each line of the file holds a name and a
+// base64 encoded public key, separated by whitespace. Empty lines and lines
+// starting with # are ignored. A missing file trusts no key.
+func NewSignatureVerifier(trustedKeysPath string) SignatureVerifier {
+	return &signatureVerifier{
+		trustedKeysPath: trustedKeysPath,
+	}
+}
+
+func (v *signatureVerifier) Verify(filepath string, signature string) (string, error) {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return "", ErrUntrustedSignature
+	}
+
+	keys, err := ReadTrustedKeys(v.trustedKeysPath)
+	if err != nil {
+		return "", err
+	}
+
+	contents, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return "", err
+	}
+
+	for _, key := range keys {
+		if ed25519.Verify(key.Key, contents, sig) {
+			return key.Name, nil
+		}
+	}
+
+	return "", ErrUntrustedSignature
+}
+
+func (v *signatureVerifier) TrustedKeysPath() string {
+	return v.trustedKeysPath
+}
+
+// ReadTrustedKeys reads the keys of a trusted keys file. It returns no keys
+// when the file does not exist.
+func ReadTrustedKeys(path string) ([]TrustedKey, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys := []TrustedKey{}
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected a name and a public key", path, lineNumber)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%s:%d: invalid public key for %s", path, lineNumber, fields[0])
+		}
+
+		keys = append(keys, TrustedKey{Name: fields[0], Key: ed25519.PublicKey(key)})
+	}
+
+	return keys, scanner.Err()
+}
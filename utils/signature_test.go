@@ -0,0 +1,113 @@
+package utils_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/utils"
+	"golang.org/x/crypto/ed25519"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SignatureVerifier", func() {
+	var (
+		dir             string
+		pluginPath      string
+		trustedKeysPath string
+		publicKey       ed25519.PublicKey
+		privateKey      ed25519.PrivateKey
+		verifier        SignatureVerifier
+	)
+
+	sign := func(contents []byte) string {
+		return base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, contents))
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "signature_test")
+		Expect(err).NotTo(HaveOccurred())
+
+		publicKey, privateKey, err = ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		pluginPath = filepath.Join(dir, "plugin")
+		err = ioutil.WriteFile(pluginPath, []byte("plugin binary"), 0700)
+		Expect(err).NotTo(HaveOccurred())
+
+		trustedKeysPath = filepath.Join(dir, "trusted_plugin_keys")
+		verifier = NewSignatureVerifier(trustedKeysPath)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	writeTrustedKeys := func(contents string) {
+		err := ioutil.WriteFile(trustedKeysPath, []byte(contents), 0600)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Describe("Verify", func() {
+		Context("when the signature was made with a trusted key", func() {
+			BeforeEach(func() {
+				_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+				Expect(err).NotTo(HaveOccurred())
+
+				writeTrustedKeys(fmt.Sprintf("# publishers\n\nother %s\nacme %s\n",
+					base64.StdEncoding.EncodeToString(otherKey.Public().(ed25519.PublicKey)),
+					base64.StdEncoding.EncodeToString(publicKey),
+				))
+			})
+
+			It("returns the name of the key", func() {
+				publisher, err := verifier.Verify(pluginPath, sign([]byte("plugin binary")))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(publisher).To(Equal("acme"))
+			})
+
+			It("returns ErrUntrustedSignature when the file was changed after signing", func() {
+				_, err := verifier.Verify(pluginPath, sign([]byte("another binary")))
+				Expect(err).To(Equal(ErrUntrustedSignature))
+			})
+
+			It("returns ErrUntrustedSignature for a malformed signature", func() {
+				_, err := verifier.Verify(pluginPath, "not base64!")
+				Expect(err).To(Equal(ErrUntrustedSignature))
+			})
+		})
+
+		Context("when the trusted keys file does not exist", func() {
+			It("trusts no key", func() {
+				_, err := verifier.Verify(pluginPath, sign([]byte("plugin binary")))
+				Expect(err).To(Equal(ErrUntrustedSignature))
+			})
+		})
+
+		Context("when the trusted keys file is malformed", func() {
+			BeforeEach(func() {
+				writeTrustedKeys("acme not-a-key\n")
+			})
+
+			It("returns an error naming the line", func() {
+				_, err := verifier.Verify(pluginPath, sign([]byte("plugin binary")))
+				Expect(err).To(MatchError(trustedKeysPath + ":1: invalid public key for acme"))
+			})
+		})
+	})
+
+	Describe("ReadTrustedKeys", func() {
+		It("requires a name and a key on each line", func() {
+			writeTrustedKeys("acme\n")
+
+			_, err := ReadTrustedKeys(trustedKeysPath)
+			Expect(err).To(MatchError(trustedKeysPath + ":1: expected a name and a public key"))
+		})
+	})
+})
@@ -0,0 +1,113 @@
+// This file was generated by counterfeiter
+package utilsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/utils"
+)
+
+type FakeSignatureVerifier struct {
+	VerifyStub        func(filepath string, signature string) (string, error)
+	verifyMutex       sync.RWMutex
+	verifyArgsForCall []struct {
+		filepath  string
+		signature string
+	}
+	verifyReturns struct {
+		result1 string
+		result2 error
+	}
+	TrustedKeysPathStub        func() string
+	trustedKeysPathMutex       sync.RWMutex
+	trustedKeysPathArgsForCall []struct{}
+	trustedKeysPathReturns     struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSignatureVerifier) Verify(filepath string, signature string) (string, error) {
+	fake.verifyMutex.Lock()
+	fake.verifyArgsForCall = append(fake.verifyArgsForCall, struct {
+		filepath  string
+		signature string
+	}{filepath, signature})
+	fake.recordInvocation("Verify", []interface{}{filepath, signature})
+	fake.verifyMutex.Unlock()
+	if fake.VerifyStub != nil {
+		return fake.VerifyStub(filepath, signature)
+	} else {
+		return fake.verifyReturns.result1, fake.verifyReturns.result2
+	}
+}
+
+func (fake *FakeSignatureVerifier) VerifyCallCount() int {
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	return len(fake.verifyArgsForCall)
+}
+
+func (fake *FakeSignatureVerifier) VerifyArgsForCall(i int) (string, string) {
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	return fake.verifyArgsForCall[i].filepath, fake.verifyArgsForCall[i].signature
+}
+
+func (fake *FakeSignatureVerifier) VerifyReturns(result1 string, result2 error) {
+	fake.VerifyStub = nil
+	fake.verifyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSignatureVerifier) TrustedKeysPath() string {
+	fake.trustedKeysPathMutex.Lock()
+	fake.trustedKeysPathArgsForCall = append(fake.trustedKeysPathArgsForCall, struct{}{})
+	fake.recordInvocation("TrustedKeysPath", []interface{}{})
+	fake.trustedKeysPathMutex.Unlock()
+	if fake.TrustedKeysPathStub != nil {
+		return fake.TrustedKeysPathStub()
+	} else {
+		return fake.trustedKeysPathReturns.result1
+	}
+}
+
+func (fake *FakeSignatureVerifier) TrustedKeysPathCallCount() int {
+	fake.trustedKeysPathMutex.RLock()
+	defer fake.trustedKeysPathMutex.RUnlock()
+	return len(fake.trustedKeysPathArgsForCall)
+}
+
+func (fake *FakeSignatureVerifier) TrustedKeysPathReturns(result1 string) {
+	fake.TrustedKeysPathStub = nil
+	fake.trustedKeysPathReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeSignatureVerifier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	fake.trustedKeysPathMutex.RLock()
+	defer fake.trustedKeysPathMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeSignatureVerifier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ utils.SignatureVerifier = new(FakeSignatureVerifier)